@@ -5,6 +5,8 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -17,6 +19,44 @@ type Config struct {
 	Group1ChatID   int64
 	Group2ChatID   int64
 	ChatDBPath     string
+
+	// StateTTL har bir holat turi uchun yashash muddati (kalit: "order_session", "group_thread"...).
+	// Env orqali almashtiriladi: STATE_TTL_ORDER_SESSION=2h
+	StateTTL        map[string]time.Duration
+	JanitorInterval time.Duration
+
+	// RateSource valyuta kurslari manbai: "cbu" (Markaziy bank) yoki bo'sh (faqat qo'lda).
+	// RateRefreshInterval fon yangilanishi oralig'i (RATE_REFRESH_INTERVAL, default: 6h)
+	RateSource          string
+	RateRefreshInterval time.Duration
+
+	// MaxUploadSize admin yuboradigan katalog faylining eng katta hajmi baytlarda
+	// (MAX_UPLOAD_SIZE=50MB, default: 20MB - Telegram Bot API getFile chegarasi)
+	MaxUploadSize int64
+}
+
+// defaultMaxUploadSize Telegram bulutli Bot API orqali yuklab olinadigan eng katta fayl
+const defaultMaxUploadSize = 20 << 20
+
+// defaultStateTTL holatlar uchun default muddatlar
+func defaultStateTTL() map[string]time.Duration {
+	return map[string]time.Duration{
+		"config_session":     30 * time.Minute,
+		"order_session":      2 * time.Hour,
+		"pending_change":     30 * time.Minute,
+		"pending_approval":   24 * time.Hour,
+		"feedback":           24 * time.Hour,
+		"shop_mode":          6 * time.Hour,
+		"config_reminded":    24 * time.Hour,
+		"awaiting_admin_msg": 30 * time.Minute,
+		"awaiting_password":  10 * time.Minute,
+		"pending_import":     time.Hour,
+		"audit_filter":       24 * time.Hour,
+		"alert_menu":         24 * time.Hour,
+		"group_thread":       7 * 24 * time.Hour,
+		"admin_approval":     7 * 24 * time.Hour,
+		"chat_context":       24 * time.Hour,
+	}
 }
 
 // defaultChatDBPath joriy foydalanuvchi uchun xavfsiz default chat DB yo'lini beradi.
@@ -43,10 +83,57 @@ func Load() (*Config, error) {
 	_ = godotenv.Load()
 
 	config := &Config{
-		TelegramToken:  os.Getenv("TELEGRAM_BOT_TOKEN"),
-		GeminiAPIKey:   os.Getenv("GEMINI_API_KEY"),
-		MaxContextSize: 20, // Default qiymat
-		ChatDBPath:     defaultChatDBPath(),
+		TelegramToken:   os.Getenv("TELEGRAM_BOT_TOKEN"),
+		GeminiAPIKey:    os.Getenv("GEMINI_API_KEY"),
+		MaxContextSize:  20, // Default qiymat
+		ChatDBPath:      defaultChatDBPath(),
+		StateTTL:        defaultStateTTL(),
+		JanitorInterval: 5 * time.Minute,
+
+		RateSource:          strings.ToLower(strings.TrimSpace(os.Getenv("RATE_SOURCE"))),
+		RateRefreshInterval: 6 * time.Hour,
+		MaxUploadSize:       defaultMaxUploadSize,
+	}
+
+	for kind := range config.StateTTL {
+		envKey := "STATE_TTL_" + strings.ToUpper(kind)
+		if raw := os.Getenv(envKey); raw != "" {
+			ttl, err := time.ParseDuration(raw)
+			if err != nil || ttl <= 0 {
+				return nil, fmt.Errorf("%s noto'g'ri formatda (masalan: 30m, 2h): %q", envKey, raw)
+			}
+			config.StateTTL[kind] = ttl
+		}
+	}
+
+	if raw := os.Getenv("JANITOR_INTERVAL"); raw != "" {
+		interval, err := time.ParseDuration(raw)
+		if err != nil || interval <= 0 {
+			return nil, fmt.Errorf("JANITOR_INTERVAL noto'g'ri formatda (masalan: 5m): %q", raw)
+		}
+		config.JanitorInterval = interval
+	}
+
+	switch config.RateSource {
+	case "", "cbu":
+	default:
+		return nil, fmt.Errorf("RATE_SOURCE noma'lum (qo'llab-quvvatlanadi: cbu): %q", config.RateSource)
+	}
+
+	if raw := os.Getenv("RATE_REFRESH_INTERVAL"); raw != "" {
+		interval, err := time.ParseDuration(raw)
+		if err != nil || interval <= 0 {
+			return nil, fmt.Errorf("RATE_REFRESH_INTERVAL noto'g'ri formatda (masalan: 6h): %q", raw)
+		}
+		config.RateRefreshInterval = interval
+	}
+
+	if raw := os.Getenv("MAX_UPLOAD_SIZE"); raw != "" {
+		size, err := parseByteSize(raw)
+		if err != nil || size <= 0 {
+			return nil, fmt.Errorf("MAX_UPLOAD_SIZE noto'g'ri formatda (masalan: 20MB, 512KB): %q", raw)
+		}
+		config.MaxUploadSize = size
 	}
 
 	if rawGroupID := os.Getenv("GROUP_1_CHAT_ID"); rawGroupID != "" {
@@ -75,3 +162,23 @@ func Load() (*Config, error) {
 
 	return config, nil
 }
+
+// parseByteSize "20MB", "512KB", "1.5GB" yoki baytlar sonini o'qish (1KB = 1024 bayt)
+func parseByteSize(raw string) (int64, error) {
+	s := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(raw), " ", ""))
+	multiplier := int64(1)
+	for _, unit := range []struct {
+		suffix string
+		size   int64
+	}{{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"B", 1}} {
+		if strings.HasSuffix(s, unit.suffix) {
+			s, multiplier = strings.TrimSuffix(s, unit.suffix), unit.size
+			break
+		}
+	}
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+	return int64(value * float64(multiplier)), nil
+}
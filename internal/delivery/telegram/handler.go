@@ -2,10 +2,13 @@ package telegram
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
+	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
@@ -13,7 +16,9 @@ import (
 	"time"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/google/uuid"
 	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/repository"
 	"github.com/yourusername/telegram-ai-bot/internal/usecase"
 )
 
@@ -29,6 +34,7 @@ const (
 
 type configSession struct {
 	Stage      configStage
+	ChatID     int64
 	PCType     string
 	Budget     string
 	CPUBrand   string
@@ -90,6 +96,7 @@ const (
 
 type orderSession struct {
 	Stage     orderStage
+	ChatID    int64
 	Name      string
 	Phone     string
 	Location  string
@@ -106,38 +113,65 @@ type adminApprovalRequest struct {
 
 // BotHandler Telegram bot handler
 type BotHandler struct {
-	bot              *tgbotapi.BotAPI
-	group1ChatID     int64
-	group2ChatID     int64
-	chatUseCase      usecase.ChatUseCase
-	adminUseCase     usecase.AdminUseCase
-	productUseCase   usecase.ProductUseCase
-	configMu         sync.RWMutex
-	configSessions   map[int64]*configSession
-	feedbackMu       sync.RWMutex
-	feedbacks        map[int64]feedbackInfo
-	groupMu          sync.RWMutex
-	groupThreads     map[int]groupThreadInfo
-	approvalMu       sync.RWMutex
-	pendingApprove   map[int64]pendingApproval
-	adminApprovalMu  sync.RWMutex
-	adminApprovals   map[int]adminApprovalRequest
-	reminderMu       sync.RWMutex
-	configReminded   map[int64]bool
-	changeMu         sync.RWMutex
-	pendingChange    map[int64]changeRequest
-	orderMu          sync.RWMutex
-	orderSessions    map[int64]*orderSession
-	userMsgMu        sync.RWMutex
-	awaitingAdminMsg map[int64]bool
-	shopMu           sync.RWMutex
-	shopMode         map[int64]bool
-
-	// Admin login kutilayotgan userlar
-	awaitingPassword map[int64]bool
-	mu               sync.RWMutex
+	bot             *tgbotapi.BotAPI
+	group1ChatID    int64
+	group2ChatID    int64
+	maxUploadSize   int64 // katalog fayli hajmi chegarasi (baytlarda)
+	chatUseCase     usecase.ChatUseCase
+	adminUseCase    usecase.AdminUseCase
+	productUseCase  usecase.ProductUseCase
+	orderUseCase    usecase.OrderUseCase
+	privacyUseCase  usecase.PrivacyUseCase
+	currencyUseCase usecase.CurrencyUseCase
+	taxonomyUseCase usecase.TaxonomyUseCase
+	profileUseCase  usecase.ImportProfileUseCase
+	alertUseCase    usecase.PriceAlertUseCase
+
+	// Dialog holatlari state store da saqlanadi (restartdan keyin ham davom etadi).
+	// Mutexlar o'qib-o'zgartirib-yozish amallarini ketma-ket qilish uchun.
+	stateStore      repository.StateRepository
+	configMu        sync.RWMutex
+	feedbackMu      sync.RWMutex
+	groupMu         sync.RWMutex
+	approvalMu      sync.RWMutex
+	adminApprovalMu sync.RWMutex
+	reminderMu      sync.RWMutex
+	changeMu        sync.RWMutex
+	orderMu         sync.RWMutex
+	userMsgMu       sync.RWMutex
+	shopMu          sync.RWMutex
+	auditMu         sync.RWMutex
+	importMu        sync.RWMutex
+	alertMu         sync.Mutex // narx tushishi xabarlari ketma-ket tekshiriladi
+
+	// importReports tasdiqlanmagan import hisobotlari (importMu bilan). Katta prayslar hisoboti
+	// state store ga yozilmaydi - u yerda faqat ID saqlanadi; restartdan keyin fayl qayta yuboriladi.
+	importReports map[string]*entity.ImportReport
+
+	mu sync.RWMutex
 }
 
+// State store namespace lari
+const (
+	stateConfigSession    = "config_session"
+	stateFeedback         = "feedback"
+	stateGroupThread      = "group_thread"
+	statePendingApproval  = "pending_approval"
+	stateAdminApproval    = "admin_approval"
+	stateConfigReminded   = "config_reminded"
+	statePendingChange    = "pending_change"
+	stateOrderSession     = "order_session"
+	stateAwaitingAdminMsg = "awaiting_admin_msg"
+	stateShopMode         = "shop_mode"
+	stateAuditFilter      = "audit_filter"
+	stateAwaitingPassword = "awaiting_password"
+	statePendingImport    = "pending_import"
+	stateAlertMenu        = "alert_menu"
+
+	// stateChatContext state store da emas, chat repository da (faqat TTL kaliti)
+	stateChatContext = "chat_context"
+)
+
 const orderDoneStickerID = "CAACAgIAAxkBAAEBzPVpIV1OxMMb6EKyrMB5V4ffAZs3wwACpFoAAmmL2UsgcfjSRzVqDDYE"
 
 // NewBotHandler yangi bot handler yaratish
@@ -145,9 +179,17 @@ func NewBotHandler(
 	token string,
 	group1ChatID int64,
 	group2ChatID int64,
+	maxUploadSize int64,
 	chatUseCase usecase.ChatUseCase,
 	adminUseCase usecase.AdminUseCase,
 	productUseCase usecase.ProductUseCase,
+	orderUseCase usecase.OrderUseCase,
+	privacyUseCase usecase.PrivacyUseCase,
+	currencyUseCase usecase.CurrencyUseCase,
+	taxonomyUseCase usecase.TaxonomyUseCase,
+	profileUseCase usecase.ImportProfileUseCase,
+	alertUseCase usecase.PriceAlertUseCase,
+	stateStore repository.StateRepository,
 ) (*BotHandler, error) {
 	bot, err := tgbotapi.NewBotAPI(token)
 	if err != nil {
@@ -155,26 +197,258 @@ func NewBotHandler(
 	}
 
 	return &BotHandler{
-		bot:              bot,
-		group1ChatID:     group1ChatID,
-		group2ChatID:     group2ChatID,
-		chatUseCase:      chatUseCase,
-		adminUseCase:     adminUseCase,
-		productUseCase:   productUseCase,
-		configSessions:   make(map[int64]*configSession),
-		feedbacks:        make(map[int64]feedbackInfo),
-		groupThreads:     make(map[int]groupThreadInfo),
-		pendingApprove:   make(map[int64]pendingApproval),
-		adminApprovals:   make(map[int]adminApprovalRequest),
-		configReminded:   make(map[int64]bool),
-		pendingChange:    make(map[int64]changeRequest),
-		orderSessions:    make(map[int64]*orderSession),
-		awaitingAdminMsg: make(map[int64]bool),
-		shopMode:         make(map[int64]bool),
-		awaitingPassword: make(map[int64]bool),
+		bot:             bot,
+		group1ChatID:    group1ChatID,
+		group2ChatID:    group2ChatID,
+		maxUploadSize:   maxUploadSize,
+		chatUseCase:     chatUseCase,
+		adminUseCase:    adminUseCase,
+		productUseCase:  productUseCase,
+		orderUseCase:    orderUseCase,
+		privacyUseCase:  privacyUseCase,
+		currencyUseCase: currencyUseCase,
+		taxonomyUseCase: taxonomyUseCase,
+		profileUseCase:  profileUseCase,
+		alertUseCase:    alertUseCase,
+		stateStore:      stateStore,
+		importReports:   make(map[string]*entity.ImportReport),
 	}, nil
 }
 
+// loadState holatni state store dan o'qish (topilmasa yoki xatolik bo'lsa false)
+func (h *BotHandler) loadState(namespace, key string, dest any) bool {
+	entry, ok, err := h.stateStore.Get(context.Background(), namespace, key)
+	if err != nil {
+		log.Printf("State o'qishda xatolik (%s/%s): %v", namespace, key, err)
+		return false
+	}
+	if !ok {
+		return false
+	}
+	if err := json.Unmarshal(entry.Value, dest); err != nil {
+		log.Printf("State ni decode qilib bo'lmadi (%s/%s): %v", namespace, key, err)
+		return false
+	}
+	return true
+}
+
+// saveState holatni state store ga yozish
+func (h *BotHandler) saveState(namespace, key string, userID int64, value any) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		log.Printf("State ni encode qilib bo'lmadi (%s/%s): %v", namespace, key, err)
+		return
+	}
+	entry := entity.StateEntry{
+		Namespace: namespace,
+		Key:       key,
+		UserID:    userID,
+		Value:     data,
+		UpdatedAt: time.Now(),
+	}
+	if err := h.stateStore.Put(context.Background(), entry); err != nil {
+		log.Printf("State saqlashda xatolik (%s/%s): %v", namespace, key, err)
+	}
+}
+
+// deleteState holatni o'chirish
+func (h *BotHandler) deleteState(namespace, key string) {
+	if err := h.stateStore.Delete(context.Background(), namespace, key); err != nil {
+		log.Printf("State o'chirishda xatolik (%s/%s): %v", namespace, key, err)
+	}
+}
+
+// hasState holat mavjudligini tekshirish
+func (h *BotHandler) hasState(namespace, key string) bool {
+	_, ok, err := h.stateStore.Get(context.Background(), namespace, key)
+	if err != nil {
+		log.Printf("State o'qishda xatolik (%s/%s): %v", namespace, key, err)
+		return false
+	}
+	return ok
+}
+
+// setFlag bool holatni yoqish/o'chirish
+func (h *BotHandler) setFlag(namespace string, userID int64, on bool) {
+	if on {
+		h.saveState(namespace, stateKey(userID), userID, true)
+	} else {
+		h.deleteState(namespace, stateKey(userID))
+	}
+}
+
+func stateKey(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
+
+// StartJanitor eskirgan dialog holatlarini fon rejimida tozalash.
+// ttl kalitlari state namespace lari ("order_session", "group_thread"...) va "chat_context".
+func (h *BotHandler) StartJanitor(ctx context.Context, interval time.Duration, ttl map[string]time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			h.expireState(ctx, ttl)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// StartRateRefresher valyuta kurslarini tashqi manbadan fon rejimida yangilash.
+// Manba ulanmagan bo'lsa hech narsa qilmaydi; qo'lda kiritilgan kurslarga tegilmaydi.
+func (h *BotHandler) StartRateRefresher(ctx context.Context, interval time.Duration) {
+	if !h.currencyUseCase.HasSource() || interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if updated, err := h.currencyUseCase.SyncRates(ctx); err != nil {
+				log.Printf("Valyuta kurslarini yangilashda xatolik: %v", err)
+			} else if updated > 0 {
+				log.Printf("💱 %d ta valyuta kursi yangilandi", updated)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// rates narxlarni so'm/dollarda ko'rsatish uchun kurslar (xatolikda faqat asl valyuta)
+func (h *BotHandler) rates(ctx context.Context) entity.ExchangeRates {
+	rates, err := h.currencyUseCase.Rates(ctx)
+	if err != nil {
+		log.Printf("Valyuta kurslarini o'qishda xatolik: %v", err)
+	}
+	return rates
+}
+
+// expireState har bir holat turi uchun muddati o'tganlarini o'chirish
+func (h *BotHandler) expireState(ctx context.Context, ttl map[string]time.Duration) {
+	now := time.Now()
+	for namespace, maxAge := range ttl {
+		if maxAge <= 0 {
+			continue
+		}
+
+		if namespace == stateChatContext {
+			if pruned, err := h.chatUseCase.PruneContexts(ctx, maxAge); err != nil {
+				log.Printf("Chat kontekstlarini tozalashda xatolik: %v", err)
+			} else if pruned > 0 {
+				log.Printf("🧹 %d ta eskirgan chat konteksti tozalandi", pruned)
+			}
+			continue
+		}
+
+		// Dialog o'rtasida o'chirib yubormaslik uchun shu holat mutexi olinadi
+		mu := h.stateMutex(namespace)
+		if mu != nil {
+			mu.Lock()
+		}
+		expired, err := h.stateStore.DeleteOlderThan(ctx, namespace, now.Add(-maxAge))
+		if mu != nil {
+			mu.Unlock()
+		}
+		if err != nil {
+			log.Printf("State tozalashda xatolik (%s): %v", namespace, err)
+			continue
+		}
+		if len(expired) > 0 {
+			log.Printf("🧹 %s: %d ta eskirgan holat tozalandi", namespace, len(expired))
+		}
+
+		for _, entry := range expired {
+			h.notifyStateExpired(entry)
+		}
+	}
+}
+
+// notifyStateExpired yarim qolgan buyurtma yoki konfiguratsiya haqida foydalanuvchini ogohlantirish
+func (h *BotHandler) notifyStateExpired(entry entity.StateEntry) {
+	switch entry.Namespace {
+	case stateOrderSession:
+		var session orderSession
+		if err := json.Unmarshal(entry.Value, &session); err != nil {
+			return
+		}
+		chatID := session.ChatID
+		if chatID == 0 {
+			chatID = entry.UserID
+		}
+		msg := tgbotapi.NewMessage(chatID, "⌛ Buyurtmani rasmiylashtirish vaqti tugadi, ma'lumotlar bekor qilindi. Qayta buyurtma berish uchun /configuratsiya ni bosing.")
+		msg.ReplyMarkup = tgbotapi.NewRemoveKeyboard(true)
+		if _, err := h.bot.Send(msg); err != nil {
+			log.Printf("Buyurtma muddati haqida xabar yuborilmadi: %v", err)
+		}
+	case stateConfigSession:
+		var session configSession
+		if err := json.Unmarshal(entry.Value, &session); err != nil {
+			return
+		}
+		chatID := session.ChatID
+		if chatID == 0 {
+			chatID = entry.UserID
+		}
+		h.sendMessage(chatID, "⌛ Konfiguratsiya sessiyasi muddati tugadi. Qayta boshlash uchun /configuratsiya ni bosing.")
+	case statePendingImport:
+		// Tasdiqlanmagan import hisoboti xotiradan ham o'chiriladi
+		var pending pendingImport
+		if err := json.Unmarshal(entry.Value, &pending); err != nil {
+			return
+		}
+		h.importMu.Lock()
+		delete(h.importReports, pending.ReportID)
+		h.importMu.Unlock()
+	}
+}
+
+// stateMutex namespace ga tegishli mutex (read-modify-write qiladigan joylar bilan bir xil)
+func (h *BotHandler) stateMutex(namespace string) *sync.RWMutex {
+	switch namespace {
+	case stateConfigSession:
+		return &h.configMu
+	case stateOrderSession:
+		return &h.orderMu
+	case stateFeedback:
+		return &h.feedbackMu
+	case stateGroupThread:
+		return &h.groupMu
+	case statePendingApproval:
+		return &h.approvalMu
+	case stateAdminApproval:
+		return &h.adminApprovalMu
+	case stateConfigReminded:
+		return &h.reminderMu
+	case statePendingChange:
+		return &h.changeMu
+	case stateAwaitingAdminMsg:
+		return &h.userMsgMu
+	case stateShopMode:
+		return &h.shopMu
+	case stateAuditFilter:
+		return &h.auditMu
+	case stateAwaitingPassword:
+		return &h.mu
+	case statePendingImport:
+		return &h.importMu
+	default:
+		return nil
+	}
+}
+
 // Start botni ishga tushirish
 func (h *BotHandler) Start(ctx context.Context) error {
 	log.Printf("Bot @%s ishga tushdi!", h.bot.Self.UserName)
@@ -253,12 +527,26 @@ func (h *BotHandler) handleCommand(ctx context.Context, message *tgbotapi.Messag
 		h.handleClearCommand(ctx, message)
 	case "history":
 		h.handleHistoryCommand(ctx, message)
+	case "mydata":
+		h.handleMyDataCommand(ctx, message)
+	case "forgetme":
+		h.handleForgetMeCommand(ctx, message)
 	case "admin":
 		h.handleAdminCommand(ctx, message)
 	case "logout":
 		h.handleLogoutCommand(ctx, message)
 	case "catalog":
 		h.handleCatalogCommand(ctx, message)
+	case "export_catalog":
+		h.handleExportCatalogCommand(ctx, message)
+	case "rate":
+		h.handleRateCommand(ctx, message)
+	case "taxonomy":
+		h.handleTaxonomyCommand(ctx, message)
+	case "recategorize":
+		h.handleRecategorizeCommand(ctx, message)
+	case "profile", "profiles":
+		h.handleProfileCommand(ctx, message)
 	case "products":
 		h.handleProductsCommand(ctx, message)
 	case "configuratsiya":
@@ -267,6 +555,22 @@ func (h *BotHandler) handleCommand(ctx context.Context, message *tgbotapi.Messag
 		h.handleCleanCommand(ctx, message)
 	case "shop":
 		h.handleShopCommand(ctx, message)
+	case "versions":
+		h.handleVersionsCommand(ctx, message)
+	case "diff":
+		h.handleDiffCommand(ctx, message)
+	case "rollback":
+		h.handleRollbackCommand(ctx, message)
+	case "audit":
+		h.handleAuditCommand(ctx, message)
+	case "orders":
+		h.handleOrdersCommand(ctx, message)
+	case "alerts":
+		h.handleAlertsCommand(ctx, message)
+	case "pricehistory":
+		h.handlePriceHistoryCommand(ctx, message)
+	case "find":
+		h.handleFindCommand(ctx, message)
 	default:
 		h.sendMessage(message.Chat.ID, "Noma'lum komanda. /help yordam uchun.")
 	}
@@ -314,24 +618,64 @@ func (h *BotHandler) handlePasswordInput(ctx context.Context, message *tgbotapi.
 	}
 
 	// Muvaffaqiyatli login
-	welcomeMsg := `✅ Admin panelga xush kelibsiz!
+	welcomeMsg := fmt.Sprintf(`✅ Admin panelga xush kelibsiz!
 
 🔧 Admin imkoniyatlari:
-• Excel fayl yuklash orqali mahsulot katalogini yangilash
+• Excel (.xlsx, .xls), OpenDocument (.ods), CSV yoki JSON fayl yuklash orqali mahsulot katalogini yangilash
 • Mahsulotlar ro'yxatini ko'rish
 • Katalog statistikasi
 
 📤 Mahsulot katalogini yuklash uchun:
-Excel faylni (maksimal 5MB) botga yuboring. Fayl quyidagi ustunlarni o'z ichiga olishi kerak:
+Excel (.xlsx, .xls), .ods, CSV/TSV yoki JSON faylni (maksimal %s) botga yuboring. Fayl quyidagi ustunlarni o'z ichiga olishi kerak:
 - Nomi / Name
 - Kategoriya / Category
 - Narx / Price
 - Tavsif / Description (ixtiyoriy)
-- Soni / Stock (ixtiyoriy)
+- Soni / Stock (ixtiyoriy, bo'sh bo'lsa ombor hisobisiz)
+
+- Artikul / SKU (ixtiyoriy, birlashtirishda kalit)
+- Valyuta / Currency (ixtiyoriy: USD, UZS; yoki narxning o'zida "so'm", "$")
+
+Har bir sheet alohida o'qiladi; kategoriya ustuni bo'lmasa sheet nomi kategoriya bo'ladi. Keraksiz sheetlarni fayl izohida chiqarib tashlang: exclude: Izoh, Arxiv
+
+Katalogni almashtirmasdan birlashtirish uchun izohga yozing: mode: merge (faylda yo'q mahsulotlarni o'chirmasdan sotuvdan olish: missing: discontinue)
+
+Narxlarda valyuta ko'rsatilmagan bo'lsa dollar deb olinadi; so'mdagi katalog uchun izohga yozing: currency: UZS
+Narxlar 1,299.50 va 1.299,50 ko'rinishida ham, "850k", "1.2 mln", "120-130$" kabi ham o'qiladi; vergul o'nlik ekanini aniq ko'rsatish uchun: number: eu
+
+🧾 Import profillari (yetkazib beruvchi ustunlari boshqacha nomlangan bo'lsa):
+/profile - Profillar ro'yxati
+/profile Mega - Profilni ko'rish
+/profile save Mega - Keyingi qatorlarda "Sarlavha = maydon" (name, price, category, description, stock, sku, currency, image, spec:Kalit, skip) va currency:, header:, data: sozlamalari
+/profile del Mega - Profilni o'chirish
+Sarlavhasi profilga mos fayl avtomatik aniqlanadi; aniq ko'rsatish uchun izohga: profile: Mega (o'chirish: profile: none)
+
+💱 Valyuta kurslari:
+/rate - Joriy kurslar
+/rate USD 12650 - Kursni qo'lda o'rnatish
+/rate refresh - Kurslarni Markaziy bankdan olish
+Kurslar faylini "kurslar" izohi bilan yuboring (har qatorda: USD 12650)
+
+🏷 Kategoriya qoidalari (kategoriya ustuni va sheet nomi bo'lmasa ishlatiladi):
+/taxonomy - Qoidalar ro'yxati
+/taxonomy add GPU 70 rtx, re:arc\s?[ab]\d{3} -ex: table - Qoida qo'shish
+/taxonomy del 5 - Qoidani o'chirish
+/taxonomy name GPU Videokarta | Видеокарта - Kategoriya nomlari
+/taxonomy test <nom> - Nom qaysi kategoriyaga tushishini ko'rish
+/taxonomy export - Qoidalarni JSON faylga olish ("kategoriyalar" izohi bilan qayta yuboriladi)
+/taxonomy reset - Standart qoidalarga qaytish
+/recategorize - Katalogni qayta yuklamasdan qoidalar bo'yicha kategoriyalash (all - hammasini)
 
 /catalog - Hozirgi katalog haqida ma'lumot
+/export_catalog - Katalogni Excel faylga yuklab olish
 /products - Barcha mahsulotlar ro'yxati
-/logout - Admin paneldan chiqish`
+/versions - Katalog versiyalari
+/diff 1 2 - Ikki versiya farqi
+/rollback 1 - Katalogni versiyaga qaytarish
+/audit - Admin harakatlari logi
+/orders all - Buyurtmalar ro'yxati
+/find RTX 4070 - Yozishmalar bo'yicha qidiruv
+/logout - Admin paneldan chiqish`, formatFileSize(h.maxUploadSize))
 
 	btns := tgbotapi.NewInlineKeyboardMarkup(
 		tgbotapi.NewInlineKeyboardRow(
@@ -363,8 +707,10 @@ func (h *BotHandler) handleShopMessage(ctx context.Context, userID int64, userna
 		return true
 	}
 
-	preview := buildProductPreview(products, 6)
+	rates := h.rates(ctx)
+	preview := buildProductPreview(products, 6, rates)
 	h.setShopMode(userID, false)
+	h.sendProductPhotos(ctx, chatID, products, 6, rates)
 
 	h.savePendingApproval(userID, pendingApproval{
 		UserID:   userID,
@@ -381,7 +727,11 @@ func (h *BotHandler) handleShopMessage(ctx context.Context, userID int64, userna
 			tgbotapi.NewInlineKeyboardButtonData("Yo'q ❌", "shop_no"),
 			tgbotapi.NewInlineKeyboardButtonData("Variant ko'raman 🔄", "shop_more"),
 		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🔔 Narx tushsa xabar ber", "alert_menu"),
+		),
 	)
+	h.saveAlertMenu(userID, products, 6)
 
 	msg := tgbotapi.NewMessage(chatID, fmt.Sprintf("Topdim!\n\n%s\nRasmiylashtiramizmi?", preview))
 	msg.ReplyMarkup = markup
@@ -411,7 +761,9 @@ func (h *BotHandler) handleDirectProductSearch(ctx context.Context, userID int64
 		return h.handleAIProductSearch(ctx, userID, username, text, chatID)
 	}
 
-	preview := buildProductPreview(products, 6)
+	rates := h.rates(ctx)
+	preview := buildProductPreview(products, 6, rates)
+	h.sendProductPhotos(ctx, chatID, products, 6, rates)
 	h.savePendingApproval(userID, pendingApproval{
 		UserID:   userID,
 		UserChat: chatID,
@@ -426,7 +778,11 @@ func (h *BotHandler) handleDirectProductSearch(ctx context.Context, userID int64
 			tgbotapi.NewInlineKeyboardButtonData("Ha ✅", "buy_yes"),
 			tgbotapi.NewInlineKeyboardButtonData("Yo'q ❌", "buy_no"),
 		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🔔 Narx tushsa xabar ber", "alert_menu"),
+		),
 	)
+	h.saveAlertMenu(userID, products, 6)
 
 	msg := tgbotapi.NewMessage(chatID, fmt.Sprintf("Ha, topdim! %s\n\nRasmiylashtiramizmi?\n\n%s", text, preview))
 	msg.ReplyMarkup = markup
@@ -434,6 +790,288 @@ func (h *BotHandler) handleDirectProductSearch(ctx context.Context, userID int64
 	return true
 }
 
+// sendProductPhotos qidiruv natijasidagi rasmli mahsulotlarni (birinchi limit tasi) rasm yoki albom
+// ko'rinishida yuborish; izohdagi raqam buildProductPreview dagi raqamga mos. Faylga joylangan rasm
+// birinchi marta yuklanadi, keyin Telegram file_id si ishlatiladi.
+func (h *BotHandler) sendProductPhotos(ctx context.Context, chatID int64, products []entity.Product, limit int, rates entity.ExchangeRates) {
+	if limit > 0 && len(products) > limit {
+		products = products[:limit]
+	}
+
+	var media []interface{}
+	var uploaded []string // media bilan bir xil tartibda: yuklanayotgan rasm ID si (bo'lmasa bo'sh)
+	for i, p := range products {
+		file, imageID := h.productPhoto(ctx, p.Image)
+		if file == nil {
+			continue
+		}
+		photo := tgbotapi.NewInputMediaPhoto(file)
+		photo.Caption = fmt.Sprintf("%d) %s - %s", i+1, p.Name, rates.FormatDual(p.Price, p.PriceCurrency()))
+		media = append(media, photo)
+		uploaded = append(uploaded, imageID)
+	}
+
+	var sent []tgbotapi.Message
+	var err error
+	switch len(media) {
+	case 0:
+		return
+	case 1:
+		photo := media[0].(tgbotapi.InputMediaPhoto)
+		cfg := tgbotapi.NewPhoto(chatID, photo.Media)
+		cfg.Caption = photo.Caption
+		var msg tgbotapi.Message
+		msg, err = h.bot.Send(cfg)
+		sent = []tgbotapi.Message{msg}
+	default:
+		sent, err = h.bot.SendMediaGroup(tgbotapi.NewMediaGroup(chatID, media))
+	}
+	if err != nil {
+		log.Printf("Mahsulot rasmlarini yuborishda xatolik: %v", err)
+		return
+	}
+
+	for i, msg := range sent {
+		if i >= len(uploaded) || uploaded[i] == "" || len(msg.Photo) == 0 {
+			continue
+		}
+		fileID := msg.Photo[len(msg.Photo)-1].FileID
+		if err := h.productUseCase.SetImageFileID(ctx, uploaded[i], fileID); err != nil {
+			log.Printf("Rasm file_id sini saqlashda xatolik: %v", err)
+		}
+	}
+}
+
+// productPhoto Product.Image dan yuboriladigan rasm: havola, saqlangan file_id yoki rasm fayli.
+// Ikkinchi qiymat - birinchi marta yuklanayotgan rasm ID si (file_id ni saqlash uchun).
+func (h *BotHandler) productPhoto(ctx context.Context, image string) (tgbotapi.RequestFileData, string) {
+	if image == "" {
+		return nil, ""
+	}
+	if entity.IsImageURL(image) {
+		return tgbotapi.FileURL(image), ""
+	}
+
+	stored, err := h.productUseCase.GetImage(ctx, image)
+	if err != nil {
+		log.Printf("Mahsulot rasmini o'qishda xatolik: %v", err)
+		return nil, ""
+	}
+	if stored.FileID != "" {
+		return tgbotapi.FileID(stored.FileID), ""
+	}
+	return tgbotapi.FileBytes{Name: stored.ID[:12] + stored.Extension, Bytes: stored.Data}, stored.ID
+}
+
+// alertMenuItem "narx tushsa xabar ber" tugmasi bosilganda tanlanadigan mahsulot
+type alertMenuItem struct {
+	ProductID string
+	Name      string
+}
+
+// saveAlertMenu qidiruv natijasidagi mahsulotlarni (preview dagi tartibda) obuna tanlovi uchun saqlash
+func (h *BotHandler) saveAlertMenu(userID int64, products []entity.Product, limit int) {
+	if limit > 0 && len(products) > limit {
+		products = products[:limit]
+	}
+	items := make([]alertMenuItem, 0, len(products))
+	for _, p := range products {
+		items = append(items, alertMenuItem{ProductID: p.ID, Name: p.Name})
+	}
+	h.saveState(stateAlertMenu, stateKey(userID), userID, items)
+}
+
+// handleAlertCallback narx obunasi tugmalari: alert_menu - mahsulot tanlash, alert_add:<raqam> - obuna,
+// alert_del:<id> - obunani o'chirish
+func (h *BotHandler) handleAlertCallback(ctx context.Context, cq *tgbotapi.CallbackQuery) {
+	userID := cq.From.ID
+	chatID := cq.Message.Chat.ID
+	data := cq.Data
+
+	if alertID, ok := strings.CutPrefix(data, "alert_del:"); ok {
+		if err := h.alertUseCase.Unsubscribe(ctx, userID, alertID); err != nil {
+			log.Printf("Narx obunasini o'chirishda xatolik: %v", err)
+			h.sendMessage(chatID, "❌ Obuna topilmadi yoki allaqachon o'chirilgan. /alerts")
+			return
+		}
+		h.sendMessage(chatID, "🔕 Narx kuzatuvi to'xtatildi.")
+		return
+	}
+
+	var items []alertMenuItem
+	if !h.loadState(stateAlertMenu, stateKey(userID), &items) || len(items) == 0 {
+		h.sendMessage(chatID, "❌ Mahsulotlar ro'yxati eskirgan. Mahsulotni qayta qidiring.")
+		return
+	}
+
+	if data == "alert_menu" && len(items) > 1 {
+		var rows [][]tgbotapi.InlineKeyboardButton
+		for i, item := range items {
+			label := fmt.Sprintf("🔔 %d) %s", i+1, truncateString(item.Name, 40))
+			rows = append(rows, tgbotapi.NewInlineKeyboardRow(tgbotapi.NewInlineKeyboardButtonData(label, fmt.Sprintf("alert_add:%d", i))))
+		}
+		msg := tgbotapi.NewMessage(chatID, "Qaysi mahsulot narxini kuzatamiz?")
+		msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+		h.bot.Send(msg)
+		return
+	}
+
+	index := 0
+	if raw, ok := strings.CutPrefix(data, "alert_add:"); ok {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 || n >= len(items) {
+			h.sendMessage(chatID, "❌ Mahsulot topilmadi. Mahsulotni qayta qidiring.")
+			return
+		}
+		index = n
+	}
+
+	alert, err := h.alertUseCase.Subscribe(ctx, userID, chatID, items[index].ProductID)
+	if err != nil {
+		log.Printf("Narx obunasida xatolik: %v", err)
+		h.sendMessage(chatID, fmt.Sprintf("❌ Kuzatuvga olib bo'lmadi: mahsulot katalogdan olingan yoki obunalar soni chegarada (%d ta). /alerts", usecase.MaxPriceAlertsPerUser))
+		return
+	}
+	h.sendMessage(chatID, fmt.Sprintf("🔔 Kuzatuvga olindi: %s\nNarxi %s dan tushsa xabar beraman.\n\nObunalaringiz: /alerts",
+		alert.ProductName, entity.FormatMoney(alert.Threshold, alert.Currency)))
+}
+
+// handleAlertsCommand mijozning narx obunalari (har biri o'chirish tugmasi bilan)
+func (h *BotHandler) handleAlertsCommand(ctx context.Context, message *tgbotapi.Message) {
+	alerts, err := h.alertUseCase.ListUserAlerts(ctx, message.From.ID)
+	if err != nil {
+		log.Printf("Narx obunalarini o'qishda xatolik: %v", err)
+		h.sendMessage(message.Chat.ID, "❌ Obunalarni o'qib bo'lmadi.")
+		return
+	}
+	if len(alerts) == 0 {
+		h.sendMessage(message.Chat.ID, "Hozircha narxi kuzatilayotgan mahsulot yo'q. Mahsulotni qidiring va \"🔔 Narx tushsa xabar ber\" tugmasini bosing.")
+		return
+	}
+
+	var b strings.Builder
+	b.WriteString("🔔 Narxi kuzatilayotgan mahsulotlar:\n\n")
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for i, alert := range alerts {
+		fmt.Fprintf(&b, "%d) %s - %s dan arzonlashsa\n", i+1, alert.ProductName, entity.FormatMoney(alert.Threshold, alert.Currency))
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData(fmt.Sprintf("🔕 %d) %s", i+1, truncateString(alert.ProductName, 40)), "alert_del:"+alert.ID),
+		))
+	}
+
+	msg := tgbotapi.NewMessage(message.Chat.ID, b.String())
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(rows...)
+	h.bot.Send(msg)
+}
+
+// priceAlertSendInterval narx tushishi xabarlari orasidagi pauza (Telegram cheklovlari uchun)
+const priceAlertSendInterval = 50 * time.Millisecond
+
+// notifyPriceDrops katalog o'zgargandan keyin narxi obuna chegarasidan tushgan mijozlarga xabar yuborish
+func (h *BotHandler) notifyPriceDrops(ctx context.Context) {
+	h.alertMu.Lock()
+	defer h.alertMu.Unlock()
+
+	drops, err := h.alertUseCase.CheckAlerts(ctx)
+	if err != nil {
+		log.Printf("Narx obunalarini tekshirishda xatolik: %v", err)
+	}
+	if len(drops) == 0 {
+		return
+	}
+
+	rates := h.rates(ctx)
+	sent := 0
+	for _, drop := range drops {
+		text := fmt.Sprintf("🔔 Narx tushdi!\n\n%s\n%s → %s",
+			drop.Product.Name,
+			entity.FormatMoney(drop.Alert.Threshold, drop.Alert.Currency),
+			entity.FormatMoney(drop.NewPrice, drop.Alert.Currency))
+		if drop.Product.PriceCurrency() != drop.Alert.Currency {
+			text += fmt.Sprintf("\nKatalog narxi: %s", rates.FormatDual(drop.Product.Price, drop.Product.PriceCurrency()))
+		}
+		text += "\n\nBuyurtma berish uchun mahsulot nomini yozing."
+
+		msg := tgbotapi.NewMessage(drop.Alert.ChatID, text)
+		msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🔕 Kuzatishni to'xtatish", "alert_del:"+drop.Alert.ID),
+		))
+		if _, err := h.bot.Send(msg); err != nil {
+			log.Printf("Narx tushishi xabarini yuborishda xatolik (%d): %v", drop.Alert.UserID, err)
+		} else {
+			sent++
+			// Chegara faqat xabar yetkazilgandan keyin yangilanadi, aks holda keyingi safar qayta uriniladi
+			if err := h.alertUseCase.MarkNotified(ctx, drop); err != nil {
+				log.Printf("Narx obunasini yangilashda xatolik (%s): %v", drop.Alert.ID, err)
+			}
+		}
+		time.Sleep(priceAlertSendInterval)
+	}
+	log.Printf("🔔 Narx tushishi: %d ta obunachiga xabar yuborildi", sent)
+}
+
+// handlePriceHistoryCommand /pricehistory <so'rov> - mos mahsulotlar narxlari tarixi (admin)
+func (h *BotHandler) handlePriceHistoryCommand(ctx context.Context, message *tgbotapi.Message) {
+	userID := message.From.ID
+
+	isAdmin, _ := h.adminUseCase.IsAdmin(ctx, userID)
+	if !isAdmin {
+		h.sendMessage(message.Chat.ID, "❌ Bu komanda faqat adminlar uchun.")
+		return
+	}
+
+	query := strings.TrimSpace(message.CommandArguments())
+	if query == "" {
+		h.sendMessage(message.Chat.ID, "Foydalanish: /pricehistory <mahsulot nomi yoki artikuli>")
+		return
+	}
+
+	history, err := h.alertUseCase.GetPriceHistory(ctx, userID, query, 10)
+	if err != nil {
+		log.Printf("Price history error: %v", err)
+		h.sendMessage(message.Chat.ID, fmt.Sprintf("❌ Narxlar tarixini o'qishda xatolik: %v", err))
+		return
+	}
+	if len(history) == 0 {
+		h.sendMessage(message.Chat.ID, "Mahsulot topilmadi.")
+		return
+	}
+
+	h.sendMessage(message.Chat.ID, buildPriceHistoryText(history))
+}
+
+// buildPriceHistoryText narxlar tarixi: har bir mahsulot uchun oxirgi o'zgarishlar (yangidan eskiga)
+func buildPriceHistoryText(history []usecase.ProductPriceHistory) string {
+	var b strings.Builder
+	b.WriteString("📈 Narxlar tarixi\n")
+	for _, item := range history {
+		p := item.Product
+		fmt.Fprintf(&b, "\n%s - %s\n", p.Name, entity.FormatMoney(p.Price, p.PriceCurrency()))
+		if len(item.Points) == 0 {
+			b.WriteString("   tarix yo'q\n")
+			continue
+		}
+		for i, point := range item.Points {
+			line := fmt.Sprintf("   %s  %s", point.RecordedAt.Format("2006-01-02 15:04"), entity.FormatMoney(point.Price, point.Currency))
+			if i+1 < len(item.Points) {
+				prev := item.Points[i+1]
+				switch {
+				case prev.Currency != point.Currency:
+				case point.Price < prev.Price:
+					line += " 🔻"
+				case point.Price > prev.Price:
+					line += " 🔺"
+				}
+			}
+			if point.Version > 0 {
+				line += fmt.Sprintf(" (v%d)", point.Version)
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+	return b.String()
+}
+
 // AI javobidan keyin sotib olish taklifini ko'rsatish
 func (h *BotHandler) maybeAskToBuy(chatID, userID int64, username, userText, response string) {
 	if !shouldOfferPurchase(userText, response) {
@@ -515,44 +1153,857 @@ func (h *BotHandler) handleCatalogCommand(ctx context.Context, message *tgbotapi
 	h.sendMessage(message.Chat.ID, info)
 }
 
-// handleProductsCommand mahsulotlar ro'yxati
-func (h *BotHandler) handleProductsCommand(ctx context.Context, message *tgbotapi.Message) {
-	products, err := h.productUseCase.GetAll(ctx)
-	if err != nil || len(products) == 0 {
-		h.sendMessage(message.Chat.ID, "❌ Mahsulotlar topilmadi.")
+// handleExportCatalogCommand bot ishlatayotgan katalogni .xlsx qilib yuborish (admin)
+func (h *BotHandler) handleExportCatalogCommand(ctx context.Context, message *tgbotapi.Message) {
+	isAdmin, _ := h.adminUseCase.IsAdmin(ctx, message.From.ID)
+	if !isAdmin {
+		h.sendMessage(message.Chat.ID, "❌ Bu komanda faqat adminlar uchun.")
 		return
 	}
 
-	productsText, err := h.productUseCase.GetProductsAsText(ctx)
+	data, count, err := h.adminUseCase.ExportCatalog(ctx, message.From.ID)
 	if err != nil {
-		h.sendMessage(message.Chat.ID, "❌ Mahsulotlarni yuklashda xatolik.")
+		log.Printf("Catalog export error: %v", err)
+		h.sendMessage(message.Chat.ID, "❌ Katalogni eksport qilib bo'lmadi. Katalog bo'sh bo'lishi mumkin.")
 		return
 	}
 
-	// Uzun bo'lsa, qismlarga bo'lib yuborish
-	if len(productsText) > 4000 {
-		h.sendMessage(message.Chat.ID, fmt.Sprintf("📦 Jami %d ta mahsulot mavjud. Katalog juda katta, AI bilan savdo qilishingiz mumkin.", len(products)))
-	} else {
-		h.sendMessage(message.Chat.ID, productsText)
+	doc := tgbotapi.NewDocument(message.Chat.ID, tgbotapi.FileBytes{
+		Name:  fmt.Sprintf("catalog_%s.xlsx", time.Now().Format("20060102_1504")),
+		Bytes: data,
+	})
+	doc.Caption = fmt.Sprintf("📤 Joriy katalog: %d ta mahsulot, har bir kategoriya alohida sheetda. Tahrirlab qayta yuborishingiz mumkin.", count)
+	if _, err := h.bot.Send(doc); err != nil {
+		log.Printf("Katalog faylini yuborishda xatolik: %v", err)
+		h.sendMessage(message.Chat.ID, "❌ Faylni yuborib bo'lmadi.")
 	}
 }
 
-// handleConfigCommand konfiguratsiya sessiyasini boshlash
-func (h *BotHandler) handleConfigCommand(ctx context.Context, message *tgbotapi.Message) {
-	userID := message.From.ID
+// handleRateCommand valyuta kurslari (admin): /rate, /rate USD 12650, /rate refresh
+func (h *BotHandler) handleRateCommand(ctx context.Context, message *tgbotapi.Message) {
+	isAdmin, _ := h.adminUseCase.IsAdmin(ctx, message.From.ID)
+	if !isAdmin {
+		h.sendMessage(message.Chat.ID, "❌ Bu komanda faqat adminlar uchun.")
+		return
+	}
 
-	h.startConfigSession(userID)
+	args := strings.Fields(message.CommandArguments())
+	switch {
+	case len(args) == 0:
+		h.sendMessage(message.Chat.ID, h.buildRatesText(ctx))
 
-	firstStep := "🛠️ PC yig'ishni boshlaymiz! Qaysi turdagi PC kerak? (Office / Gaming / Montaj / Server / Boshqa)"
-	h.sendMessage(message.Chat.ID, firstStep)
-}
+	case len(args) == 1 && (strings.EqualFold(args[0], "refresh") || strings.EqualFold(args[0], "yangilash")):
+		if !h.currencyUseCase.HasSource() {
+			h.sendMessage(message.Chat.ID, "❌ Kurs manbai ulanmagan (RATE_SOURCE=cbu). Kursni qo'lda kiriting: /rate USD 12650")
+			return
+		}
+		rates, err := h.currencyUseCase.RefreshRates(ctx, message.From.ID)
+		if err != nil {
+			log.Printf("Refresh rates error: %v", err)
+			h.sendMessage(message.Chat.ID, fmt.Sprintf("❌ Kurslarni olib bo'lmadi: %v", err))
+			return
+		}
+		h.sendMessage(message.Chat.ID, fmt.Sprintf("✅ %d ta kurs yangilandi.\n\n%s", len(rates), h.buildRatesText(ctx)))
 
-// handleConfigFlow konfiguratsiya savollarini bosqichma-bosqich ko'rsatish
-func (h *BotHandler) handleConfigFlow(ctx context.Context, userID int64, username, text string, chatID int64) {
+	case len(args) >= 2:
+		currency, ok := entity.ParseCurrency(args[0])
+		rate, err := entity.ParseAmount(strings.Join(args[1:], ""))
+		if !ok || currency == entity.CurrencyUZS || err != nil || rate <= 0 {
+			h.sendMessage(message.Chat.ID, "❌ Format: /rate USD 12650 (1 birlik necha so'm)")
+			return
+		}
+		if err := h.currencyUseCase.SetRate(ctx, message.From.ID, currency, rate); err != nil {
+			log.Printf("Set rate error: %v", err)
+			h.sendMessage(message.Chat.ID, "❌ Kursni saqlab bo'lmadi.")
+			return
+		}
+		h.sendMessage(message.Chat.ID, fmt.Sprintf("✅ 1 %s = %s", currency, entity.FormatMoney(rate, entity.CurrencyUZS)))
+
+	default:
+		h.sendMessage(message.Chat.ID, "Foydalanish: /rate, /rate USD 12650 yoki /rate refresh")
+	}
+}
+
+// buildRatesText joriy valyuta kurslari ro'yxati
+func (h *BotHandler) buildRatesText(ctx context.Context) string {
+	rates, err := h.currencyUseCase.ListRates(ctx)
+	if err != nil {
+		log.Printf("List rates error: %v", err)
+		return "❌ Kurslarni yuklab bo'lmadi."
+	}
+	if len(rates) == 0 {
+		return "💱 Valyuta kurslari hali kiritilmagan. Narxlar faqat asl valyutada ko'rsatiladi.\nKiritish: /rate USD 12650"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("💱 Valyuta kurslari (1 birlik):\n")
+	for _, rate := range rates {
+		sb.WriteString(fmt.Sprintf("• %s = %s (%s, %s)\n", rate.Currency,
+			strings.TrimSuffix(fmt.Sprintf("%.2f", rate.Rate), ".00")+" so'm", rateSourceLabel(rate.Source), rate.UpdatedAt.Format("2006-01-02 15:04")))
+	}
+	if h.currencyUseCase.HasSource() {
+		sb.WriteString("\nQo'lda kiritilgan kurslar avtomatik yangilanmaydi; /rate refresh hammasini manbadan oladi.")
+	}
+	return sb.String()
+}
+
+func rateSourceLabel(source string) string {
+	switch source {
+	case entity.RateSourceAdmin:
+		return "qo'lda"
+	case entity.RateSourceFile:
+		return "fayldan"
+	default:
+		return source
+	}
+}
+
+// isRatesCaption fayl izohi kurslar faylini bildiradimi ("kurslar", "rates")
+func isRatesCaption(caption string) bool {
+	first, _, _ := strings.Cut(strings.TrimSpace(caption), "\n")
+	switch strings.ToLower(strings.TrimSpace(first)) {
+	case "kurs", "kurslar", "rates", "rate", "exchange rates", "valyuta kurslari":
+		return true
+	}
+	return false
+}
+
+// handleRatesFile kurslar faylini yuklash (har qatorda "USD 12650" yoki JSON)
+func (h *BotHandler) handleRatesFile(ctx context.Context, message *tgbotapi.Message) {
+	data, err := h.downloadFile(message.Document.FileID)
+	if err != nil {
+		log.Printf("File download error: %v", err)
+		h.sendMessage(message.Chat.ID, "❌ Faylni yuklashda xatolik yuz berdi.")
+		return
+	}
+
+	rates, err := h.currencyUseCase.ImportRates(ctx, message.From.ID, data)
+	if err != nil {
+		log.Printf("Import rates error: %v", err)
+		h.sendMessage(message.Chat.ID, fmt.Sprintf("❌ Kurslar faylini o'qib bo'lmadi: %v\nHar qatorda: USD 12650", err))
+		return
+	}
+	h.sendMessage(message.Chat.ID, fmt.Sprintf("✅ %d ta kurs saqlandi.\n\n%s", len(rates), h.buildRatesText(ctx)))
+}
+
+// handleTaxonomyCommand kategoriya qoidalari (admin): /taxonomy [add|del|name|test|export|reset]
+func (h *BotHandler) handleTaxonomyCommand(ctx context.Context, message *tgbotapi.Message) {
+	userID := message.From.ID
+
+	isAdmin, _ := h.adminUseCase.IsAdmin(ctx, userID)
+	if !isAdmin {
+		h.sendMessage(message.Chat.ID, "❌ Bu komanda faqat adminlar uchun.")
+		return
+	}
+
+	sub, rest, _ := strings.Cut(strings.TrimSpace(message.CommandArguments()), " ")
+	rest = strings.TrimSpace(rest)
+
+	switch strings.ToLower(sub) {
+	case "", "list":
+		taxonomy, err := h.taxonomyUseCase.Taxonomy(ctx)
+		if err != nil {
+			log.Printf("Load taxonomy error: %v", err)
+			h.sendMessage(message.Chat.ID, "❌ Kategoriya qoidalarini yuklab bo'lmadi.")
+			return
+		}
+		h.sendMessage(message.Chat.ID, buildTaxonomyText(taxonomy, 3900))
+
+	case "add":
+		rule, err := parseRuleArgs(rest)
+		if err != nil {
+			h.sendMessage(message.Chat.ID, "❌ Format: /taxonomy add <Kategoriya> <ustuvorlik> <naqsh, naqsh> [-ex: istisno, istisno]\nMasalan: /taxonomy add GPU 70 rtx, re:arc\\s?[ab]\\d{3} -ex: table")
+			return
+		}
+		id, err := h.taxonomyUseCase.AddRule(ctx, userID, rule)
+		if err != nil {
+			log.Printf("Add category rule error: %v", err)
+			h.sendMessage(message.Chat.ID, fmt.Sprintf("❌ Qoidani saqlab bo'lmadi: %v", err))
+			return
+		}
+		rule.ID = id
+		h.sendMessage(message.Chat.ID, fmt.Sprintf("✅ Qoida qo'shildi:\n%s\n\nKatalogga qo'llash: /recategorize", formatCategoryRule(rule)))
+
+	case "del", "delete", "rm":
+		id, err := strconv.Atoi(strings.TrimPrefix(rest, "#"))
+		if err != nil {
+			h.sendMessage(message.Chat.ID, "❌ Format: /taxonomy del <qoida raqami>")
+			return
+		}
+		if err := h.taxonomyUseCase.DeleteRule(ctx, userID, id); err != nil {
+			log.Printf("Delete category rule error: %v", err)
+			h.sendMessage(message.Chat.ID, fmt.Sprintf("❌ Qoidani o'chirib bo'lmadi: %v", err))
+			return
+		}
+		h.sendMessage(message.Chat.ID, fmt.Sprintf("🗑 #%d qoida o'chirildi. Katalogga qo'llash: /recategorize", id))
+
+	case "name":
+		category, names, _ := strings.Cut(rest, " ")
+		uz, ru, _ := strings.Cut(names, "|")
+		name := entity.CategoryName{Category: strings.TrimSpace(category), Uz: strings.TrimSpace(uz), Ru: strings.TrimSpace(ru)}
+		if name.Category == "" || (name.Uz == "" && name.Ru == "") {
+			h.sendMessage(message.Chat.ID, "❌ Format: /taxonomy name <Kategoriya> <o'zbekcha> | <ruscha>\nMasalan: /taxonomy name GPU Videokarta | Видеокарта")
+			return
+		}
+		if err := h.taxonomyUseCase.SetCategoryName(ctx, userID, name); err != nil {
+			log.Printf("Set category name error: %v", err)
+			h.sendMessage(message.Chat.ID, "❌ Nomni saqlab bo'lmadi.")
+			return
+		}
+		h.sendMessage(message.Chat.ID, fmt.Sprintf("✅ %s", name.Label()))
+
+	case "test":
+		if rest == "" {
+			h.sendMessage(message.Chat.ID, "❌ Format: /taxonomy test <mahsulot nomi>")
+			return
+		}
+		taxonomy, err := h.taxonomyUseCase.Taxonomy(ctx)
+		if err != nil {
+			log.Printf("Load taxonomy error: %v", err)
+			h.sendMessage(message.Chat.ID, "❌ Kategoriya qoidalarini yuklab bo'lmadi.")
+			return
+		}
+		rule, ok := taxonomy.Match(rest)
+		if !ok {
+			h.sendMessage(message.Chat.ID, fmt.Sprintf("📂 %s\nHech bir qoida mos kelmadi.", entity.DefaultCategory))
+			return
+		}
+		h.sendMessage(message.Chat.ID, fmt.Sprintf("📂 %s\nQoida: %s", taxonomy.DisplayName(rule.Category), formatCategoryRule(rule)))
+
+	case "export":
+		data, err := h.taxonomyUseCase.ExportTaxonomy(ctx)
+		if err != nil {
+			log.Printf("Export taxonomy error: %v", err)
+			h.sendMessage(message.Chat.ID, "❌ Qoidalarni eksport qilib bo'lmadi.")
+			return
+		}
+		doc := tgbotapi.NewDocument(message.Chat.ID, tgbotapi.FileBytes{Name: "taxonomy.json", Bytes: data})
+		doc.Caption = "🏷 Kategoriya qoidalari. Tahrirlab \"kategoriyalar\" izohi bilan qayta yuboring."
+		if _, err := h.bot.Send(doc); err != nil {
+			log.Printf("Taxonomy faylini yuborishda xatolik: %v", err)
+			h.sendMessage(message.Chat.ID, "❌ Faylni yuborib bo'lmadi.")
+		}
+
+	case "reset":
+		if err := h.taxonomyUseCase.ResetTaxonomy(ctx, userID); err != nil {
+			log.Printf("Reset taxonomy error: %v", err)
+			h.sendMessage(message.Chat.ID, "❌ Qoidalarni tiklab bo'lmadi.")
+			return
+		}
+		h.sendMessage(message.Chat.ID, "♻️ Standart kategoriya qoidalari tiklandi. Katalogga qo'llash: /recategorize")
+
+	default:
+		h.sendMessage(message.Chat.ID, "Foydalanish: /taxonomy, /taxonomy add|del|name|test|export|reset")
+	}
+}
+
+// parseRuleArgs "GPU 70 rtx, re:arc\s?[ab]\d{3} -ex: table" argumentlaridan qoida
+func parseRuleArgs(args string) (entity.CategoryRule, error) {
+	fields := strings.Fields(args)
+	if len(fields) < 3 {
+		return entity.CategoryRule{}, fmt.Errorf("not enough arguments")
+	}
+	priority, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return entity.CategoryRule{}, fmt.Errorf("invalid priority: %q", fields[1])
+	}
+
+	// Kategoriya va ustuvorlikdan keyingi qism - naqshlar (regex ichida bo'shliq bo'lishi mumkin)
+	_, rest, _ := strings.Cut(strings.TrimSpace(args), " ")
+	_, rest, _ = strings.Cut(strings.TrimSpace(rest), " ")
+	patterns, exclude, _ := strings.Cut(rest, "-ex:")
+	rule := entity.CategoryRule{
+		Category: fields[0],
+		Priority: priority,
+		Patterns: splitList(patterns),
+		Exclude:  splitList(exclude),
+	}
+	if len(rule.Patterns) == 0 {
+		return entity.CategoryRule{}, fmt.Errorf("no patterns")
+	}
+	return rule, nil
+}
+
+// splitList vergul bilan ajratilgan ro'yxat (bo'sh elementlarsiz)
+func splitList(s string) []string {
+	var list []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// formatCategoryRule "#10 GPU (65): rtx, gtx | -ex: table" ko'rinishi
+func formatCategoryRule(rule entity.CategoryRule) string {
+	line := fmt.Sprintf("#%d %s (%d): %s", rule.ID, rule.Category, rule.Priority, strings.Join(rule.Patterns, ", "))
+	if len(rule.Exclude) > 0 {
+		line += " | -ex: " + strings.Join(rule.Exclude, ", ")
+	}
+	return line
+}
+
+// buildTaxonomyText qoidalar (tekshirish tartibida) va kategoriya nomlari
+func buildTaxonomyText(taxonomy *entity.Taxonomy, maxLen int) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("🏷 Kategoriya qoidalari (%d ta, yuqoridan pastga tekshiriladi):\n\n", len(taxonomy.Rules)))
+	for i, rule := range taxonomy.Rules {
+		line := formatCategoryRule(rule) + "\n"
+		if sb.Len()+len(line) > maxLen-200 {
+			sb.WriteString(fmt.Sprintf("... yana %d ta qoida (/taxonomy export)\n", len(taxonomy.Rules)-i))
+			break
+		}
+		sb.WriteString(line)
+	}
+
+	sb.WriteString("\n🌐 Nomlar:\n")
+	for _, name := range taxonomy.Names {
+		line := "• " + name.Label() + "\n"
+		if sb.Len()+len(line) > maxLen {
+			sb.WriteString("...\n")
+			break
+		}
+		sb.WriteString(line)
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// isTaxonomyCaption fayl izohi kategoriya qoidalari faylini bildiradimi ("kategoriyalar", "taxonomy")
+func isTaxonomyCaption(caption string) bool {
+	first, _, _ := strings.Cut(strings.TrimSpace(caption), "\n")
+	switch strings.ToLower(strings.TrimSpace(first)) {
+	case "taxonomy", "kategoriyalar", "kategoriya qoidalari", "categories", "category rules":
+		return true
+	}
+	return false
+}
+
+// handleTaxonomyFile kategoriya qoidalari JSON faylini yuklash (/taxonomy export formati)
+func (h *BotHandler) handleTaxonomyFile(ctx context.Context, message *tgbotapi.Message) {
+	data, err := h.downloadFile(message.Document.FileID)
+	if err != nil {
+		log.Printf("File download error: %v", err)
+		h.sendMessage(message.Chat.ID, "❌ Faylni yuklashda xatolik yuz berdi.")
+		return
+	}
+
+	taxonomy, err := h.taxonomyUseCase.ImportTaxonomy(ctx, message.From.ID, data)
+	if err != nil {
+		log.Printf("Import taxonomy error: %v", err)
+		h.sendMessage(message.Chat.ID, fmt.Sprintf("❌ Qoidalar faylini o'qib bo'lmadi: %v\nNamuna: /taxonomy export", err))
+		return
+	}
+	h.sendMessage(message.Chat.ID, fmt.Sprintf("✅ %d ta qoida saqlandi. Katalogga qo'llash: /recategorize", len(taxonomy.Rules)))
+}
+
+// handleProfileCommand import profillari (admin): /profile [nom|save|del]
+func (h *BotHandler) handleProfileCommand(ctx context.Context, message *tgbotapi.Message) {
+	userID := message.From.ID
+
+	isAdmin, _ := h.adminUseCase.IsAdmin(ctx, userID)
+	if !isAdmin {
+		h.sendMessage(message.Chat.ID, "❌ Bu komanda faqat adminlar uchun.")
+		return
+	}
+
+	// Profil matni komandadan keyingi qatorlarda keladi
+	args, body, _ := strings.Cut(strings.TrimSpace(message.CommandArguments()), "\n")
+	sub, name, _ := strings.Cut(strings.TrimSpace(args), " ")
+	name = strings.TrimSpace(name)
+
+	switch strings.ToLower(sub) {
+	case "", "list":
+		profiles, err := h.profileUseCase.ListProfiles(ctx)
+		if err != nil {
+			log.Printf("List import profiles error: %v", err)
+			h.sendMessage(message.Chat.ID, "❌ Profillarni olishda xatolik yuz berdi.")
+			return
+		}
+		h.sendMessage(message.Chat.ID, buildProfilesText(profiles))
+	case "save", "add":
+		if name == "" || strings.TrimSpace(body) == "" {
+			h.sendMessage(message.Chat.ID, profileUsageText)
+			return
+		}
+		profile, err := h.profileUseCase.SaveProfile(ctx, userID, name, body)
+		if err != nil {
+			log.Printf("Save import profile error: %v", err)
+			h.sendMessage(message.Chat.ID, fmt.Sprintf("❌ Profil saqlanmadi: %v\n\n%s", err, profileUsageText))
+			return
+		}
+		h.sendMessage(message.Chat.ID, fmt.Sprintf("✅ \"%s\" profili saqlandi:\n\n%s\n\nSarlavhasi mos fayllar avtomatik shu profil bilan o'qiladi.", profile.Name, profile.Format()))
+	case "del", "delete":
+		if name == "" {
+			h.sendMessage(message.Chat.ID, "❌ Format: /profile del <nom>")
+			return
+		}
+		if err := h.profileUseCase.DeleteProfile(ctx, userID, name); err != nil {
+			log.Printf("Delete import profile error: %v", err)
+			h.sendMessage(message.Chat.ID, fmt.Sprintf("❌ Profil o'chirilmadi: %v", err))
+			return
+		}
+		h.sendMessage(message.Chat.ID, fmt.Sprintf("🗑 \"%s\" profili o'chirildi.", name))
+	default:
+		// /profile <nom> - profilni ko'rish (nomda bo'shliq bo'lishi mumkin)
+		profile, err := h.profileUseCase.GetProfile(ctx, strings.TrimSpace(args))
+		if err != nil {
+			h.sendMessage(message.Chat.ID, fmt.Sprintf("❌ Profil topilmadi: %s\n/profile - ro'yxat", strings.TrimSpace(args)))
+			return
+		}
+		h.sendMessage(message.Chat.ID, fmt.Sprintf("🧾 %s:\n\n%s\n\nO'zgartirish: /profile save %s va keyingi qatorlarda yangi matn", profile.Name, profile.Format(), profile.Name))
+	}
+}
+
+// profileUsageText /profile save namunasi
+const profileUsageText = `Format:
+/profile save <nom>
+Наименование = name
+Цена (у.е.) = price
+Группа = category
+Код = sku
+Сокет = spec:Socket
+Примечание = skip
+currency: USD
+header: 3
+
+Maydonlar: name, price, category, description, stock, sku, currency, image; spec:<Kalit> - xususiyat nomi; skip - ustunni o'qimaslik.
+header: / data: - sarlavha va birinchi mahsulot qatori raqami (ixtiyoriy).`
+
+// buildProfilesText saqlangan import profillari ro'yxati
+func buildProfilesText(profiles []entity.ImportProfile) string {
+	if len(profiles) == 0 {
+		return "🧾 Import profillari yo'q.\n\n" + profileUsageText
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("🧾 Import profillari (%d ta):\n\n", len(profiles)))
+	for _, p := range profiles {
+		sb.WriteString(fmt.Sprintf("• %s: %s → nom, %s → narx (%d ustun)", p.Name,
+			p.FieldHeader(entity.FieldName), p.FieldHeader(entity.FieldPrice), len(p.Columns)))
+		if p.Currency != "" {
+			sb.WriteString(", " + string(p.Currency))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\nKo'rish: /profile <nom>. Faylga qo'llash: izohga profile: <nom>")
+	return sb.String()
+}
+
+// profileMatchSource profil qanday tanlangani
+func profileMatchSource(match entity.ProfileMatch) string {
+	if match.Detected {
+		return "sarlavhadan avtomatik aniqlandi"
+	}
+	return "izohda ko'rsatilgan"
+}
+
+// handleRecategorizeCommand katalogni qoidalar bo'yicha qayta kategoriyalash (admin): /recategorize [all]
+func (h *BotHandler) handleRecategorizeCommand(ctx context.Context, message *tgbotapi.Message) {
+	userID := message.From.ID
+
+	isAdmin, _ := h.adminUseCase.IsAdmin(ctx, userID)
+	if !isAdmin {
+		h.sendMessage(message.Chat.ID, "❌ Bu komanda faqat adminlar uchun.")
+		return
+	}
+
+	arg := strings.ToLower(strings.TrimSpace(message.CommandArguments()))
+	all := arg == "all" || arg == "hammasi"
+	if arg != "" && !all {
+		h.sendMessage(message.Chat.ID, "Foydalanish: /recategorize yoki /recategorize all")
+		return
+	}
+
+	changes, err := h.taxonomyUseCase.Recategorize(ctx, userID, all)
+	if err != nil {
+		log.Printf("Recategorize error: %v", err)
+		h.sendMessage(message.Chat.ID, fmt.Sprintf("❌ Qayta kategoriyalashda xatolik: %v", err))
+		return
+	}
+	if len(changes) == 0 {
+		text := "✅ Kategoriyalar qoidalarga mos, o'zgarish yo'q."
+		if !all {
+			text += "\nFayldagi kategoriya ustuni yoki sheet nomidan olingan kategoriyalar ham qayta hisoblanishi uchun: /recategorize all"
+		}
+		h.sendMessage(message.Chat.ID, text)
+		return
+	}
+
+	h.sendMessage(message.Chat.ID, buildRecategorizeText(changes, 3900))
+}
+
+func buildRecategorizeText(changes []entity.ProductChange, maxLen int) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("🏷 %d ta mahsulot kategoriyasi o'zgardi (yangi katalog versiyasi saqlandi, /rollback bilan qaytarish mumkin):\n\n", len(changes)))
+	for i, c := range changes {
+		line := fmt.Sprintf("• %s: %s → %s\n", c.After.Name, c.Before.Category, c.After.Category)
+		if sb.Len()+len(line) > maxLen {
+			sb.WriteString(fmt.Sprintf("... va yana %d ta", len(changes)-i))
+			break
+		}
+		sb.WriteString(line)
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// handleVersionsCommand katalog versiyalari ro'yxati (admin)
+func (h *BotHandler) handleVersionsCommand(ctx context.Context, message *tgbotapi.Message) {
+	isAdmin, _ := h.adminUseCase.IsAdmin(ctx, message.From.ID)
+	if !isAdmin {
+		h.sendMessage(message.Chat.ID, "❌ Bu komanda faqat adminlar uchun.")
+		return
+	}
+
+	versions, err := h.adminUseCase.ListCatalogVersions(ctx)
+	if err != nil {
+		log.Printf("List versions error: %v", err)
+		h.sendMessage(message.Chat.ID, "❌ Versiyalarni yuklab bo'lmadi.")
+		return
+	}
+	if len(versions) == 0 {
+		h.sendMessage(message.Chat.ID, "Hali katalog versiyalari yo'q. Excel fayl yuklang.")
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString("🗃 Katalog versiyalari:\n\n")
+	for _, v := range versions {
+		entry := fmt.Sprintf("v%d • %s\n   📄 %s • %d ta mahsulot • admin %d\n",
+			v.Version,
+			v.CreatedAt.Format("2006-01-02 15:04"),
+			nonEmpty(v.Source, "nomalum"),
+			v.ProductCount,
+			v.UploadedBy,
+		)
+		if sb.Len()+len(entry) > 3900 {
+			sb.WriteString("…")
+			break
+		}
+		sb.WriteString(entry)
+	}
+	sb.WriteString("\n/diff 1 2 - farqni ko'rish\n/rollback 1 - versiyaga qaytarish")
+
+	h.sendMessage(message.Chat.ID, sb.String())
+}
+
+// handleDiffCommand ikki katalog versiyasi farqi (admin)
+func (h *BotHandler) handleDiffCommand(ctx context.Context, message *tgbotapi.Message) {
+	isAdmin, _ := h.adminUseCase.IsAdmin(ctx, message.From.ID)
+	if !isAdmin {
+		h.sendMessage(message.Chat.ID, "❌ Bu komanda faqat adminlar uchun.")
+		return
+	}
+
+	args := strings.Fields(message.CommandArguments())
+	if len(args) != 2 {
+		h.sendMessage(message.Chat.ID, "Foydalanish: /diff <eski versiya> <yangi versiya>. Masalan: /diff 3 4")
+		return
+	}
+	from, errFrom := parseVersionArg(args[0])
+	to, errTo := parseVersionArg(args[1])
+	if errFrom != nil || errTo != nil {
+		h.sendMessage(message.Chat.ID, "❌ Versiya raqami noto'g'ri. Masalan: /diff 3 4")
+		return
+	}
+
+	diff, err := h.adminUseCase.DiffCatalogVersions(ctx, from, to)
+	if err != nil {
+		log.Printf("Diff versions error: %v", err)
+		h.sendMessage(message.Chat.ID, "❌ Versiya topilmadi. /versions ro'yxatini tekshiring.")
+		return
+	}
+
+	h.sendMessage(message.Chat.ID, buildCatalogDiffText(diff, 3900))
+}
+
+// handleRollbackCommand katalogni oldingi versiyaga qaytarish (admin)
+func (h *BotHandler) handleRollbackCommand(ctx context.Context, message *tgbotapi.Message) {
+	userID := message.From.ID
+
+	isAdmin, _ := h.adminUseCase.IsAdmin(ctx, userID)
+	if !isAdmin {
+		h.sendMessage(message.Chat.ID, "❌ Bu komanda faqat adminlar uchun.")
+		return
+	}
+
+	version, err := parseVersionArg(message.CommandArguments())
+	if err != nil {
+		h.sendMessage(message.Chat.ID, "Foydalanish: /rollback <versiya>. Versiyalar: /versions")
+		return
+	}
+
+	count, err := h.adminUseCase.RollbackCatalog(ctx, userID, version)
+	if err != nil {
+		log.Printf("Rollback error: %v", err)
+		h.sendMessage(message.Chat.ID, fmt.Sprintf("❌ Katalogni qaytarishda xatolik: %v", err))
+		return
+	}
+
+	h.sendMessage(message.Chat.ID, fmt.Sprintf("⏪ Katalog v%d ga qaytarildi. Mahsulotlar: %d ta.", version, count))
+	go h.notifyPriceDrops(context.WithoutCancel(ctx))
+}
+
+func parseVersionArg(raw string) (int, error) {
+	raw = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(raw)), "v")
+	version, err := strconv.Atoi(raw)
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("invalid version: %q", raw)
+	}
+	return version, nil
+}
+
+func buildCatalogDiffText(diff *entity.CatalogDiff, maxLen int) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("🔀 v%d → v%d\n➕ Qo'shilgan: %d\n➖ O'chirilgan: %d\n✏️ O'zgargan: %d\n\n",
+		diff.From, diff.To, len(diff.Added), len(diff.Removed), len(diff.Changed)))
+
+	var lines []string
+	for _, p := range diff.Added {
+		lines = append(lines, fmt.Sprintf("➕ %s - %s", p.Name, entity.FormatMoney(p.Price, p.PriceCurrency())))
+	}
+	for _, p := range diff.Removed {
+		lines = append(lines, fmt.Sprintf("➖ %s - %s", p.Name, entity.FormatMoney(p.Price, p.PriceCurrency())))
+	}
+	for _, c := range diff.Changed {
+		line := fmt.Sprintf("✏️ %s", c.After.Name)
+		if c.Before.Name != c.After.Name {
+			line = fmt.Sprintf("✏️ %s → %s", c.Before.Name, c.After.Name)
+		}
+		if c.Before.Price != c.After.Price || c.Before.PriceCurrency() != c.After.PriceCurrency() {
+			line += fmt.Sprintf(" %s → %s", entity.FormatMoney(c.Before.Price, c.Before.PriceCurrency()),
+				entity.FormatMoney(c.After.Price, c.After.PriceCurrency()))
+		}
+		if c.Before.Stock != c.After.Stock {
+			line += fmt.Sprintf(" (ombor %d → %d)", c.Before.Stock, c.After.Stock)
+		}
+		if c.Before.Category != c.After.Category {
+			line += fmt.Sprintf(" [%s → %s]", c.Before.Category, c.After.Category)
+		}
+		lines = append(lines, line)
+	}
+
+	if len(lines) == 0 {
+		sb.WriteString("Farq yo'q.")
+		return sb.String()
+	}
+
+	for _, line := range lines {
+		if maxLen > 0 && sb.Len()+len(line)+1 > maxLen {
+			sb.WriteString("…")
+			break
+		}
+		sb.WriteString(line + "\n")
+	}
+	return sb.String()
+}
+
+const auditPageSize = 10
+
+// handleAuditCommand admin harakatlari logini ko'rish (admin)
+// Foydalanish: /audit [user=ID] [action=nom] [from=YYYY-MM-DD] [to=YYYY-MM-DD]
+func (h *BotHandler) handleAuditCommand(ctx context.Context, message *tgbotapi.Message) {
+	userID := message.From.ID
+
+	isAdmin, _ := h.adminUseCase.IsAdmin(ctx, userID)
+	if !isAdmin {
+		h.sendMessage(message.Chat.ID, "❌ Bu komanda faqat adminlar uchun.")
+		return
+	}
+
+	filter, err := parseAuditFilter(message.CommandArguments())
+	if err != nil {
+		h.sendMessage(message.Chat.ID, fmt.Sprintf("❌ %v\nFoydalanish: /audit [user=ID] [action=clean_all] [from=2025-01-01] [to=2025-01-31]", err))
+		return
+	}
+
+	h.setAuditFilter(userID, filter)
+	h.sendAuditPage(ctx, userID, message.Chat.ID, 0, 0)
+}
+
+// sendAuditPage audit logning bitta sahifasini yuborish (messageID != 0 bo'lsa tahrirlash)
+func (h *BotHandler) sendAuditPage(ctx context.Context, adminID, chatID int64, messageID int, offset int) {
+	filter := h.getAuditFilter(adminID)
+	filter.Limit = auditPageSize
+	filter.Offset = offset
+
+	actions, total, err := h.adminUseCase.GetAuditLog(ctx, filter)
+	if err != nil {
+		log.Printf("Audit log error: %v", err)
+		h.sendMessage(chatID, "❌ Audit logni yuklab bo'lmadi.")
+		return
+	}
+
+	text := buildAuditPageText(actions, total, offset)
+	markup := buildAuditPageButtons(total, offset)
+
+	if messageID != 0 {
+		edit := tgbotapi.NewEditMessageText(chatID, messageID, text)
+		edit.ReplyMarkup = &markup
+		if _, err := h.bot.Send(edit); err != nil {
+			log.Printf("Audit sahifasini yangilashda xatolik: %v", err)
+		}
+		return
+	}
+
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ReplyMarkup = markup
+	h.bot.Send(msg)
+}
+
+// sendAuditExport filtrlangan audit logni .xlsx fayl qilib yuborish
+func (h *BotHandler) sendAuditExport(ctx context.Context, adminID, chatID int64) {
+	data, err := h.adminUseCase.ExportAuditLog(ctx, h.getAuditFilter(adminID))
+	if err != nil {
+		log.Printf("Audit export error: %v", err)
+		h.sendMessage(chatID, "❌ Audit logni eksport qilib bo'lmadi.")
+		return
+	}
+
+	doc := tgbotapi.NewDocument(chatID, tgbotapi.FileBytes{
+		Name:  fmt.Sprintf("audit_%s.xlsx", time.Now().Format("20060102_1504")),
+		Bytes: data,
+	})
+	doc.Caption = "📥 Admin harakatlari logi"
+	if _, err := h.bot.Send(doc); err != nil {
+		log.Printf("Audit faylini yuborishda xatolik: %v", err)
+		h.sendMessage(chatID, "❌ Faylni yuborib bo'lmadi.")
+	}
+}
+
+func (h *BotHandler) setAuditFilter(adminID int64, filter entity.AuditFilter) {
+	h.auditMu.Lock()
+	defer h.auditMu.Unlock()
+	h.saveState(stateAuditFilter, stateKey(adminID), adminID, filter)
+}
+
+func (h *BotHandler) getAuditFilter(adminID int64) entity.AuditFilter {
+	h.auditMu.RLock()
+	defer h.auditMu.RUnlock()
+	var filter entity.AuditFilter
+	h.loadState(stateAuditFilter, stateKey(adminID), &filter)
+	return filter
+}
+
+// parseAuditFilter "/audit" argumentlarini filtrga aylantirish
+func parseAuditFilter(args string) (entity.AuditFilter, error) {
+	var filter entity.AuditFilter
+	for _, field := range strings.Fields(args) {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok || value == "" {
+			return filter, fmt.Errorf("noto'g'ri parametr: %s", field)
+		}
+		switch strings.ToLower(key) {
+		case "user":
+			id, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return filter, fmt.Errorf("user ID noto'g'ri: %s", value)
+			}
+			filter.UserID = id
+		case "action":
+			filter.Action = value
+		case "from":
+			t, err := time.ParseInLocation("2006-01-02", value, time.Local)
+			if err != nil {
+				return filter, fmt.Errorf("sana noto'g'ri: %s", value)
+			}
+			filter.From = t
+		case "to":
+			t, err := time.ParseInLocation("2006-01-02", value, time.Local)
+			if err != nil {
+				return filter, fmt.Errorf("sana noto'g'ri: %s", value)
+			}
+			// "to" kuni ham kiradi
+			filter.To = t.AddDate(0, 0, 1)
+		default:
+			return filter, fmt.Errorf("noma'lum parametr: %s", key)
+		}
+	}
+	return filter, nil
+}
+
+func buildAuditPageText(actions []entity.AdminAction, total, offset int) string {
+	if total == 0 {
+		return "📝 Audit log bo'sh (filtr bo'yicha hech narsa topilmadi)."
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("📝 Audit log: %d–%d / %d\n\n", offset+1, offset+len(actions), total))
+	for _, a := range actions {
+		sb.WriteString(fmt.Sprintf("🕒 %s • admin %d\n▫️ %s: %s\n\n",
+			a.Timestamp.Format("2006-01-02 15:04"),
+			a.UserID,
+			a.Action,
+			truncateString(a.Details, 200),
+		))
+	}
+	return sb.String()
+}
+
+func buildAuditPageButtons(total, offset int) tgbotapi.InlineKeyboardMarkup {
+	var nav []tgbotapi.InlineKeyboardButton
+	if offset > 0 {
+		prev := offset - auditPageSize
+		if prev < 0 {
+			prev = 0
+		}
+		nav = append(nav, tgbotapi.NewInlineKeyboardButtonData("⬅️ Oldingi", fmt.Sprintf("audit_page:%d", prev)))
+	}
+	if offset+auditPageSize < total {
+		nav = append(nav, tgbotapi.NewInlineKeyboardButtonData("Keyingi ➡️", fmt.Sprintf("audit_page:%d", offset+auditPageSize)))
+	}
+
+	rows := [][]tgbotapi.InlineKeyboardButton{}
+	if len(nav) > 0 {
+		rows = append(rows, nav)
+	}
+	if total > 0 {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("📥 Excel (.xlsx)", "audit_export"),
+		))
+	}
+	return tgbotapi.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+// handleProductsCommand mahsulotlar ro'yxati
+func (h *BotHandler) handleProductsCommand(ctx context.Context, message *tgbotapi.Message) {
+	products, err := h.productUseCase.GetAll(ctx)
+	if err != nil || len(products) == 0 {
+		h.sendMessage(message.Chat.ID, "❌ Mahsulotlar topilmadi.")
+		return
+	}
+
+	productsText, err := h.productUseCase.GetProductsAsText(ctx)
+	if err != nil {
+		h.sendMessage(message.Chat.ID, "❌ Mahsulotlarni yuklashda xatolik.")
+		return
+	}
+
+	// Uzun bo'lsa, qismlarga bo'lib yuborish
+	if len(productsText) > 4000 {
+		h.sendMessage(message.Chat.ID, fmt.Sprintf("📦 Jami %d ta mahsulot mavjud. Katalog juda katta, AI bilan savdo qilishingiz mumkin.", len(products)))
+	} else {
+		h.sendMessage(message.Chat.ID, productsText)
+	}
+}
+
+// handleConfigCommand konfiguratsiya sessiyasini boshlash
+func (h *BotHandler) handleConfigCommand(ctx context.Context, message *tgbotapi.Message) {
+	userID := message.From.ID
+
+	h.startConfigSession(userID, message.Chat.ID)
+
+	firstStep := "🛠️ PC yig'ishni boshlaymiz! Qaysi turdagi PC kerak? (Office / Gaming / Montaj / Server / Boshqa)"
+	h.sendMessage(message.Chat.ID, firstStep)
+}
+
+// handleConfigFlow konfiguratsiya savollarini bosqichma-bosqich ko'rsatish
+func (h *BotHandler) handleConfigFlow(ctx context.Context, userID int64, username, text string, chatID int64) {
 	input := strings.TrimSpace(text)
 
 	h.configMu.Lock()
-	session, ok := h.configSessions[userID]
+	var session configSession
+	ok := h.loadState(stateConfigSession, stateKey(userID), &session)
 	if !ok {
 		h.configMu.Unlock()
 		h.sendMessage(chatID, "Konfiguratsiya sessiyasi topilmadi. Boshlash uchun /configuratsiya ni bosing.")
@@ -560,41 +2011,46 @@ func (h *BotHandler) handleConfigFlow(ctx context.Context, userID int64, usernam
 	}
 
 	session.LastUpdate = time.Now()
+	key := stateKey(userID)
 
 	switch session.Stage {
 	case configStageNeedType:
 		session.PCType = input
 		session.Stage = configStageNeedBudget
+		h.saveState(stateConfigSession, key, userID, session)
 		h.configMu.Unlock()
 		h.sendMessage(chatID, "💰 Budjetni kiriting (masalan: 800$, 10 000 000 so'm). Aniq bo'lmasa, taxminiy yozing.")
 		return
 	case configStageNeedBudget:
 		session.Budget = input
 		session.Stage = configStageNeedCPU
+		h.saveState(stateConfigSession, key, userID, session)
 		h.configMu.Unlock()
 		h.sendMessage(chatID, "🧠 Qaysi protsessor turini xohlaysiz? Intel yoki AMD?")
 		return
 	case configStageNeedCPU:
 		session.CPUBrand = input
 		session.Stage = configStageNeedStorage
+		h.saveState(stateConfigSession, key, userID, session)
 		h.configMu.Unlock()
 		h.sendMessage(chatID, "💾 Xotira turi? HDD, SSD yoki NVMe?")
 		return
 	case configStageNeedStorage:
 		session.Storage = input
 		session.Stage = configStageNeedGPU
+		h.saveState(stateConfigSession, key, userID, session)
 		h.configMu.Unlock()
 		h.sendMessage(chatID, "🎮 Grafik karta: NVIDIA RTXmi yoki AMD Radeon?")
 		return
 	case configStageNeedGPU:
 		session.GPUBrand = input
-		completed := *session
-		delete(h.configSessions, userID)
+		completed := session
+		h.deleteState(stateConfigSession, key)
 		h.configMu.Unlock()
 		h.finishConfigSession(ctx, userID, username, chatID, completed)
 		return
 	default:
-		delete(h.configSessions, userID)
+		h.deleteState(stateConfigSession, key)
 		h.configMu.Unlock()
 		h.sendMessage(chatID, "Sessiya qayta ishga tushirildi. Yangi boshlash uchun /configuratsiya ni bosing.")
 		return
@@ -673,47 +2129,523 @@ func (h *BotHandler) handleDocumentMessage(ctx context.Context, message *tgbotap
 
 	doc := message.Document
 
-	// Fayl hajmini tekshirish (5MB)
-	if doc.FileSize > 5*1024*1024 {
-		h.sendMessage(message.Chat.ID, "❌ Fayl hajmi 5MB dan oshmasligi kerak!")
-		return
+	// Fayl hajmini tekshirish (MAX_UPLOAD_SIZE)
+	if int64(doc.FileSize) > h.maxUploadSize {
+		h.sendMessage(message.Chat.ID, fmt.Sprintf("❌ Fayl hajmi %s dan oshmasligi kerak!", formatFileSize(h.maxUploadSize)))
+		return
+	}
+
+	// "kurslar" izohli fayl - valyuta kurslari, katalog emas
+	if isRatesCaption(message.Caption) {
+		h.handleRatesFile(ctx, message)
+		return
+	}
+
+	// "kategoriyalar" izohli JSON - kategoriya qoidalari
+	if isTaxonomyCaption(message.Caption) {
+		h.handleTaxonomyFile(ctx, message)
+		return
+	}
+
+	// Fayl turini tekshirish (kengaytmasiz yoki .txt fayllar tarkibiga qarab aniqlanadi)
+	if !isCatalogFile(doc.FileName, doc.MimeType) {
+		h.sendMessage(message.Chat.ID, "❌ Faqat katalog fayllari qabul qilinadi: Excel (.xlsx, .xls), OpenDocument (.ods), CSV/TSV yoki JSON!")
+		return
+	}
+
+	status, _ := h.sendMessageWithResp(message.Chat.ID, "⏳ Fayl yuklanmoqda va qayta ishlanmoqda...")
+
+	// Faylni yuklash
+	fileBytes, err := h.downloadFile(doc.FileID)
+	if err != nil {
+		log.Printf("File download error: %v", err)
+		h.sendMessage(message.Chat.ID, "❌ Faylni yuklashda xatolik yuz berdi.")
+		return
+	}
+
+	// Fayl izohidagi import sozlamalari (masalan: "exclude: Izoh, Arxiv")
+	opts := parseImportOptions(message.Caption)
+	if status != nil {
+		opts.Progress = h.importProgress(message.Chat.ID, status.MessageID)
+	}
+
+	// Birinchi bosqich: faylni tekshirish (katalog hali o'zgarmaydi)
+	report, err := h.adminUseCase.PreviewCatalog(ctx, userID, fileBytes, doc.FileName, opts)
+	if status != nil {
+		h.finishImportProgress(message.Chat.ID, status.MessageID, report)
+	}
+	if err != nil {
+		log.Printf("Preview catalog error: %v", err)
+		if errors.Is(err, entity.ErrUnsupportedFormat) {
+			h.sendMessage(message.Chat.ID, fmt.Sprintf("❌ Bu fayl turi qo'llab-quvvatlanmaydi: %v\n\nFaylni Excel yoki LibreOffice da ochib, .xlsx (yoki .ods) sifatida saqlang va qayta yuboring.", err))
+			return
+		}
+		h.sendMessage(message.Chat.ID, fmt.Sprintf("❌ Faylni o'qishda xatolik: %v", err))
+		return
+	}
+
+	h.storePendingImport(userID, message.Chat.ID, opts, report)
+
+	msg := tgbotapi.NewMessage(message.Chat.ID, buildImportReportText(report, opts))
+	msg.ReplyMarkup = buildImportReportButtons(report)
+	if _, err := h.bot.Send(msg); err != nil {
+		log.Printf("Import hisobotini yuborishda xatolik: %v", err)
+	}
+}
+
+// importProgressInterval holat xabarini tahrirlash oralig'i (Telegram tez-tez tahrirlashni cheklaydi)
+const importProgressInterval = 3 * time.Second
+
+// importProgress katta fayl o'qilayotganda "⏳ Fayl yuklanmoqda" xabarini yangilab turish
+func (h *BotHandler) importProgress(chatID int64, messageID int) func(entity.ImportProgress) {
+	last := time.Now()
+	return func(p entity.ImportProgress) {
+		if time.Since(last) < importProgressInterval {
+			return
+		}
+		last = time.Now()
+
+		text := fmt.Sprintf("⏳ Fayl o'qilmoqda: %d qator, %d ta mahsulot", p.Rows, p.Products)
+		if p.Sheet != "" {
+			text += fmt.Sprintf(" (sheet: %s)", p.Sheet)
+		}
+		if _, err := h.bot.Send(tgbotapi.NewEditMessageText(chatID, messageID, text)); err != nil {
+			log.Printf("Import holatini yangilashda xatolik: %v", err)
+		}
+	}
+}
+
+// finishImportProgress holat xabarini yakuniy natija bilan almashtirish (xato bo'lsa xabar o'chiriladi)
+func (h *BotHandler) finishImportProgress(chatID int64, messageID int, report *entity.ImportReport) {
+	if report == nil {
+		if _, err := h.bot.Request(tgbotapi.NewDeleteMessage(chatID, messageID)); err != nil {
+			log.Printf("Import holati xabarini o'chirishda xatolik: %v", err)
+		}
+		return
+	}
+	text := fmt.Sprintf("✅ Fayl o'qildi: %d qator, %d ta mahsulot", report.TotalRows, len(report.Products))
+	if _, err := h.bot.Send(tgbotapi.NewEditMessageText(chatID, messageID, text)); err != nil {
+		log.Printf("Import holatini yangilashda xatolik: %v", err)
+	}
+}
+
+// formatFileSize fayl hajmi: "20MB", "512KB"
+func formatFileSize(size int64) string {
+	value, unit := float64(size), " bayt"
+	switch {
+	case size >= 1<<20:
+		value, unit = value/(1<<20), "MB"
+	case size >= 1<<10:
+		value, unit = value/(1<<10), "KB"
+	}
+	return strings.TrimSuffix(fmt.Sprintf("%.1f", value), ".0") + unit
+}
+
+// pendingImport tekshirilgan, lekin hali tasdiqlanmagan katalog importi (hisobotning o'zi importReports da)
+type pendingImport struct {
+	ChatID   int64
+	Options  entity.ImportOptions
+	ReportID string
+}
+
+// storePendingImport import hisobotini xotiraga, unga havolani state store ga yozish.
+// Adminning oldingi tasdiqlanmagan importi almashtiriladi.
+func (h *BotHandler) storePendingImport(userID, chatID int64, opts entity.ImportOptions, report *entity.ImportReport) {
+	h.importMu.Lock()
+	defer h.importMu.Unlock()
+
+	var previous pendingImport
+	if h.loadState(statePendingImport, stateKey(userID), &previous) {
+		delete(h.importReports, previous.ReportID)
+	}
+	id := uuid.New().String()
+	h.importReports[id] = report
+	h.saveState(statePendingImport, stateKey(userID), userID, pendingImport{
+		ChatID:   chatID,
+		Options:  opts,
+		ReportID: id,
+	})
+}
+
+// importSectionPreviewLimit xabarda ko'rsatiladigan bo'lim sarlavhalari soni
+const importSectionPreviewLimit = 15
+
+// importIssuePreviewLimit xabarda ko'rsatiladigan muammoli qatorlar soni (qolgani .xlsx hisobotda)
+const importIssuePreviewLimit = 10
+
+// buildImportReportText import tekshiruvi natijasi
+func buildImportReportText(report *entity.ImportReport, opts entity.ImportOptions) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "🔎 Import tekshiruvi: %s\n\n", report.Source)
+	fmt.Fprintf(&b, "📄 Ko'rib chiqilgan qatorlar: %d\n", report.TotalRows)
+	fmt.Fprintf(&b, "✅ Qabul qilinadi: %d ta mahsulot\n", len(report.Products))
+	fmt.Fprintf(&b, "⏭️ O'tkazib yuboriladi: %d qator\n", report.CountIssues(entity.ImportIssueSkipped))
+	fmt.Fprintf(&b, "0️⃣ Nol narxli: %d qator\n", report.CountIssues(entity.ImportIssueZeroPrice))
+	fmt.Fprintf(&b, "♻️ Takroriy nomlar: %d\n", report.CountIssues(entity.ImportIssueDuplicate))
+	fmt.Fprintf(&b, "❗ Shubhali narxlar: %d\n", report.CountIssues(entity.ImportIssueSuspiciousPrice))
+	if ranges := report.CountIssues(entity.ImportIssuePriceRange); ranges > 0 {
+		fmt.Fprintf(&b, "↔️ Narx oraliqlari: %d (pastki chegara olindi)\n", ranges)
+	}
+	withImage := 0
+	for _, product := range report.Products {
+		if product.Image != "" {
+			withImage++
+		}
+	}
+	if withImage > 0 {
+		fmt.Fprintf(&b, "🖼️ Rasmli mahsulotlar: %d\n", withImage)
+	}
+	if images := report.CountIssues(entity.ImportIssueImage); images > 0 {
+		fmt.Fprintf(&b, "🖼️ Rasm havolasi emas: %d qator (rasmsiz qo'shiladi)\n", images)
+	}
+
+	if len(report.Products) > 0 {
+		fmt.Fprintf(&b, "💱 Valyutalar: %s\n", importCurrencySummary(report.Products))
+	}
+
+	if len(report.Shortages) > 0 {
+		b.WriteString("\n⚠️ Ombordagi son ochiq buyurtmalar bronidan kam:\n")
+		for i, s := range report.Shortages {
+			if i == importIssuePreviewLimit {
+				fmt.Fprintf(&b, "... va yana %d ta (to'liq ro'yxat .xlsx hisobotda)\n", len(report.Shortages)-i)
+				break
+			}
+			fmt.Fprintf(&b, "• %s: omborda %d ta, bron qilingan %d ta\n", s.Name, s.Stock, s.Reserved)
+		}
+	}
+
+	if len(report.Numbers) > 0 {
+		b.WriteString("\n🔢 Raqam formati:\n")
+		for _, format := range report.Numbers {
+			b.WriteString("• ")
+			if format.Sheet != "" {
+				fmt.Fprintf(&b, "%s: ", format.Sheet)
+			}
+			b.WriteString(numberFormatText(format, opts))
+			b.WriteString("\n")
+		}
+	}
+
+	if len(report.Profiles) > 0 {
+		b.WriteString("\n🧾 Import profili:\n")
+		for _, match := range report.Profiles {
+			b.WriteString("• ")
+			if match.Sheet != "" {
+				fmt.Fprintf(&b, "%s: ", match.Sheet)
+			}
+			fmt.Fprintf(&b, "%s (%s)\n", match.Profile, profileMatchSource(match))
+		}
+	}
+
+	if len(report.Sections) > 0 {
+		b.WriteString("\n📑 Bo'lim sarlavhalari:\n")
+		for i, section := range report.Sections {
+			if i == importSectionPreviewLimit {
+				fmt.Fprintf(&b, "... va yana %d ta (to'liq ro'yxat .xlsx hisobotda)\n", len(report.Sections)-i)
+				break
+			}
+			indent := "• "
+			if section.Level == entity.SectionGroup {
+				indent = "    ◦ "
+			}
+			fmt.Fprintf(&b, "%s%s — %d ta mahsulot (%s)\n", indent, section.Title, section.Products, section.Location())
+		}
+	}
+
+	if len(report.UnstyledSheets) > 0 {
+		fmt.Fprintf(&b, "\nℹ️ Katta sheetlarda (%s) bo'lim sarlavhalari faqat joylashuvdan aniqlandi: birlashtirilgan kataklar va qalin shrift tekshirilmadi\n",
+			strings.Join(report.UnstyledSheets, ", "))
+	}
+
+	fmt.Fprintf(&b, "\n🔁 Rejim: %s\n", importModeLabel(opts))
+	if len(opts.ExcludeSheets) > 0 {
+		fmt.Fprintf(&b, "⏭️ O'tkazib yuborilgan sheetlar: %s\n", strings.Join(opts.ExcludeSheets, ", "))
+	}
+
+	if len(report.Guesses) > 0 {
+		b.WriteString("\n🧠 Taxmin qilingan ustunlar:\n")
+		for _, g := range report.Guesses {
+			field := "narx"
+			if g.Field == "name" {
+				field = "nom"
+			}
+			if g.Sheet != "" {
+				fmt.Fprintf(&b, "• %s: %s → %s ustun (%s)\n", g.Sheet, field, g.ColumnName(), g.Reason)
+			} else {
+				fmt.Fprintf(&b, "• %s → %s ustun (%s)\n", field, g.ColumnName(), g.Reason)
+			}
+		}
+	}
+
+	if len(report.Issues) > 0 {
+		b.WriteString("\n⚠️ Muammoli qatorlar:\n")
+		for i, issue := range report.Issues {
+			if i == importIssuePreviewLimit {
+				fmt.Fprintf(&b, "... va yana %d ta (to'liq ro'yxat .xlsx hisobotda)\n", len(report.Issues)-i)
+				break
+			}
+			name := issue.Name
+			if name == "" {
+				name = "—"
+			}
+			fmt.Fprintf(&b, "• %s: %s — %s\n", issue.Location(), name, issue.Reason)
+		}
+	}
+
+	if len(report.Products) == 0 {
+		b.WriteString("\n❌ Yaroqli mahsulot topilmadi, katalog o'zgartirilmaydi. Faylni tuzatib qayta yuboring.")
+	} else {
+		b.WriteString("\nKatalogni shu mahsulotlar bilan yangilaymizmi?")
+	}
+	return b.String()
+}
+
+// numberFormatText sheet narxlari qanday o'qilgani: "1.299,50 (vergul - o'nlik), aniqlandi; masalan 1.299,00 → 1299"
+func numberFormatText(format entity.NumberFormat, opts entity.ImportOptions) string {
+	source := "fayldan aniqlanmadi"
+	switch {
+	case opts.NumberLocale != entity.NumberLocaleAuto:
+		source = "izohdan"
+	case format.Detected:
+		source = "fayldan aniqlandi"
+	}
+
+	parts := []string{fmt.Sprintf("%s, %s", format.Locale.Describe(), source)}
+	if format.Example != "" {
+		parts = append(parts, "masalan "+format.Example)
+	}
+	if format.Ambiguous > 0 {
+		parts = append(parts, fmt.Sprintf("%d ta noaniq (1,299 kabi)", format.Ambiguous))
+	}
+	if format.Scaled > 0 {
+		parts = append(parts, fmt.Sprintf("%d ta k/mln qisqartma", format.Scaled))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// importCurrencySummary qabul qilingan narxlar valyutalari: "USD: 120, UZS: 30"
+func importCurrencySummary(products []entity.Product) string {
+	counts := make(map[entity.Currency]int)
+	for _, p := range products {
+		counts[p.PriceCurrency()]++
+	}
+	parts := make([]string, 0, len(counts))
+	for currency, count := range counts {
+		parts = append(parts, fmt.Sprintf("%s: %d", currency, count))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ", ")
+}
+
+// buildImportReportButtons tasdiqlash/bekor qilish va hisobotni yuklab olish tugmalari
+func buildImportReportButtons(report *entity.ImportReport) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	if len(report.Products) > 0 {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("✅ Tasdiqlash", "import_confirm"),
+			tgbotapi.NewInlineKeyboardButtonData("❌ Bekor qilish", "import_cancel"),
+		))
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+		tgbotapi.NewInlineKeyboardButtonData("📥 To'liq hisobot (.xlsx)", "import_report"),
+	))
+	return tgbotapi.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+// handleImportCallback import tasdiqlash, bekor qilish va hisobot tugmalari
+func (h *BotHandler) handleImportCallback(ctx context.Context, cq *tgbotapi.CallbackQuery) {
+	userID := cq.From.ID
+	chatID := cq.Message.Chat.ID
+
+	isAdmin, _ := h.adminUseCase.IsAdmin(ctx, userID)
+	if !isAdmin {
+		h.sendMessage(chatID, "❌ Bu bo'lim faqat adminlar uchun.")
+		return
+	}
+
+	if cq.Data == "import_report" {
+		h.sendImportReport(ctx, userID, chatID)
+		return
+	}
+
+	// Tasdiqlash yoki bekor qilishda kutilayotgan import bir marta olinadi
+	h.importMu.Lock()
+	var pending pendingImport
+	var report *entity.ImportReport
+	if h.loadState(statePendingImport, stateKey(userID), &pending) {
+		h.deleteState(statePendingImport, stateKey(userID))
+		report = h.importReports[pending.ReportID]
+		delete(h.importReports, pending.ReportID)
+	}
+	h.importMu.Unlock()
+
+	// Eski xabardagi tugmalarni olib tashlash
+	remove := tgbotapi.NewEditMessageReplyMarkup(chatID, cq.Message.MessageID, tgbotapi.InlineKeyboardMarkup{InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{}})
+	if _, err := h.bot.Request(remove); err != nil {
+		log.Printf("Import tugmalarini olib tashlashda xatolik: %v", err)
+	}
+
+	if report == nil {
+		h.sendMessage(chatID, "❌ Kutilayotgan import topilmadi yoki muddati o'tgan. Faylni qayta yuboring.")
+		return
+	}
+
+	if cq.Data == "import_cancel" {
+		h.sendMessage(chatID, "👌 Import bekor qilindi, katalog o'zgarmadi.")
+		return
+	}
+
+	result, err := h.adminUseCase.ApplyCatalog(ctx, userID, report, pending.Options)
+	if err != nil {
+		log.Printf("Upload catalog error: %v", err)
+		h.sendMessage(chatID, fmt.Sprintf("❌ Katalogni yangilashda xatolik: %v", err))
+		return
+	}
+
+	successMsg := fmt.Sprintf(`✅ Katalog muvaffaqiyatli yangilandi!
+
+📦 Yuklangan mahsulotlar: %d ta
+📄 Fayl: %s
+
+Endi men ushbu mahsulotlar bilan mijozlarga xizmat ko'rsataman!
+
+/catalog - Katalog haqida ma'lumot
+/products - Barcha mahsulotlar`, result.Total, report.Source)
+
+	if result.Mode == entity.ImportModeMerge {
+		successMsg += fmt.Sprintf("\n\n🔁 Birlashtirish: ➕ %d yangi, ✏️ %d yangilandi, ▫️ %d o'zgarmadi", result.Added, result.Updated, result.Unchanged)
+		if result.Removed > 0 {
+			successMsg += fmt.Sprintf(", 🗑 %d o'chirildi", result.Removed)
+		}
+		if result.Discontinued > 0 {
+			successMsg += fmt.Sprintf(", ⏸️ %d sotuvdan olindi", result.Discontinued)
+		}
+	}
+	if rejected := report.Rejected(); rejected > 0 {
+		successMsg += fmt.Sprintf("\n\n⏭️ Katalogga kirmagan qatorlar: %d", rejected)
+	}
+	if len(pending.Options.ExcludeSheets) > 0 {
+		successMsg += fmt.Sprintf("\n⏭️ O'tkazib yuborilgan sheetlar: %s", strings.Join(pending.Options.ExcludeSheets, ", "))
+	}
+
+	h.sendMessage(chatID, successMsg)
+	go h.notifyPriceDrops(context.WithoutCancel(ctx))
+}
+
+// importModeLabel import rejimi tavsifi
+func importModeLabel(opts entity.ImportOptions) string {
+	if opts.Mode != entity.ImportModeMerge {
+		return "katalog to'liq almashtiriladi"
 	}
-
-	// Fayl turini tekshirish
-	if !strings.HasSuffix(doc.FileName, ".xlsx") && !strings.HasSuffix(doc.FileName, ".xls") {
-		h.sendMessage(message.Chat.ID, "❌ Faqat Excel fayllari (.xlsx, .xls) qabul qilinadi!")
-		return
+	switch opts.Missing {
+	case entity.MissingDiscontinue:
+		return "birlashtirish (SKU/nom bo'yicha), faylda yo'qlar sotuvdan olinadi"
+	case entity.MissingKeep:
+		return "birlashtirish (SKU/nom bo'yicha), faylda yo'qlar o'zgarmaydi"
+	default:
+		return "birlashtirish (SKU/nom bo'yicha), faylda yo'qlar o'chiriladi"
 	}
+}
 
-	h.sendMessage(message.Chat.ID, "⏳ Fayl yuklanmoqda va qayta ishlanmoqda...")
-
-	// Faylni yuklash
-	fileBytes, err := h.downloadFile(doc.FileID)
-	if err != nil {
-		log.Printf("File download error: %v", err)
-		h.sendMessage(message.Chat.ID, "❌ Faylni yuklashda xatolik yuz berdi.")
+// sendImportReport kutilayotgan import hisobotini .xlsx fayl qilib yuborish
+func (h *BotHandler) sendImportReport(ctx context.Context, userID, chatID int64) {
+	h.importMu.RLock()
+	var pending pendingImport
+	var report *entity.ImportReport
+	if h.loadState(statePendingImport, stateKey(userID), &pending) {
+		report = h.importReports[pending.ReportID]
+	}
+	h.importMu.RUnlock()
+	if report == nil {
+		h.sendMessage(chatID, "❌ Hisobot topilmadi yoki muddati o'tgan. Faylni qayta yuboring.")
 		return
 	}
 
-	// Katalogni yangilash
-	count, err := h.adminUseCase.UploadCatalog(ctx, userID, fileBytes, doc.FileName)
+	data, err := h.adminUseCase.ExportImportReport(ctx, report)
 	if err != nil {
-		log.Printf("Upload catalog error: %v", err)
-		h.sendMessage(message.Chat.ID, fmt.Sprintf("❌ Katalogni yangilashda xatolik: %v", err))
+		log.Printf("Import report export error: %v", err)
+		h.sendMessage(chatID, "❌ Hisobotni tayyorlab bo'lmadi.")
 		return
 	}
 
-	successMsg := fmt.Sprintf(`✅ Katalog muvaffaqiyatli yangilandi!
-
-📦 Yuklangan mahsulotlar: %d ta
-📄 Fayl: %s
-
-Endi men ushbu mahsulotlar bilan mijozlarga xizmat ko'rsataman!
+	base := strings.TrimSuffix(report.Source, filepath.Ext(report.Source))
+	doc := tgbotapi.NewDocument(chatID, tgbotapi.FileBytes{
+		Name:  fmt.Sprintf("import_%s_%s.xlsx", nonEmpty(base, "katalog"), report.CreatedAt.Format("20060102_1504")),
+		Bytes: data,
+	})
+	doc.Caption = "📥 Import hisoboti: muammoli qatorlar va qabul qilingan mahsulotlar"
+	if _, err := h.bot.Send(doc); err != nil {
+		log.Printf("Import hisobotini yuborishda xatolik: %v", err)
+		h.sendMessage(chatID, "❌ Faylni yuborib bo'lmadi.")
+	}
+}
 
-/catalog - Katalog haqida ma'lumot
-/products - Barcha mahsulotlar`, count, doc.FileName)
+// isCatalogFile fayl katalog sifatida o'qilishi mumkinmi (formatning o'zi parserda aniqlanadi)
+func isCatalogFile(filename, mimeType string) bool {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".xlsx", ".xlsm", ".xls", ".xlsb", ".ods", ".csv", ".tsv", ".tab", ".json", ".txt", "":
+		return true
+	}
+	return strings.HasPrefix(mimeType, "text/") || strings.Contains(mimeType, "json") ||
+		strings.Contains(mimeType, "spreadsheet") || strings.Contains(mimeType, "excel")
+}
 
-	h.sendMessage(message.Chat.ID, successMsg)
+// parseImportOptions fayl izohidan import sozlamalarini o'qish.
+// Format (har bir sozlama alohida qatorda):
+//
+//	exclude: Sheet3, Izoh
+//	mode: merge              (yoki replace)
+//	missing: discontinue     (yoki keep / delete; merge rejimini yoqadi)
+//	currency: UZS            (valyutasi ko'rsatilmagan narxlar uchun; default USD)
+//	number: eu               (1.299,50 - vergul o'nlik; en - 1,299.50; default fayldan aniqlanadi)
+func parseImportOptions(caption string) entity.ImportOptions {
+	var opts entity.ImportOptions
+	for _, line := range strings.Split(caption, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "exclude", "skip", "chiqarish":
+			for _, name := range strings.Split(value, ",") {
+				if name = strings.TrimSpace(name); name != "" {
+					opts.ExcludeSheets = append(opts.ExcludeSheets, name)
+				}
+			}
+		case "mode", "rejim":
+			switch strings.ToLower(strings.TrimSpace(value)) {
+			case "merge", "upsert", "birlashtirish":
+				opts.Mode = entity.ImportModeMerge
+			case "replace", "almashtirish":
+				opts.Mode = entity.ImportModeReplace
+			}
+		case "missing", "yo'qlar":
+			switch strings.ToLower(strings.TrimSpace(value)) {
+			case "discontinue", "discontinued", "to'xtatish":
+				opts.Missing = entity.MissingDiscontinue
+			case "keep", "qoldirish":
+				opts.Missing = entity.MissingKeep
+			case "delete", "o'chirish":
+				opts.Missing = entity.MissingDelete
+			default:
+				continue
+			}
+			if opts.Mode == "" {
+				opts.Mode = entity.ImportModeMerge
+			}
+		case "currency", "valyuta":
+			if currency, ok := entity.ParseCurrency(value); ok {
+				opts.Currency = currency
+			}
+		case "number", "numbers", "locale", "raqam":
+			if locale, ok := entity.ParseNumberLocale(value); ok {
+				opts.NumberLocale = locale
+			}
+		case "profile", "profil":
+			opts.Profile = strings.TrimSpace(value)
+		}
+	}
+	return opts
 }
 
 // downloadFile Telegram dan faylni yuklash
@@ -730,7 +2662,15 @@ func (h *BotHandler) downloadFile(fileID string) ([]byte, error) {
 	}
 	defer resp.Body.Close()
 
-	return io.ReadAll(resp.Body)
+	// Telegram hajmni ko'rsatmagan bo'lishi mumkin - chegaradan oshgani o'qilmaydi
+	data, err := io.ReadAll(io.LimitReader(resp.Body, h.maxUploadSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > h.maxUploadSize {
+		return nil, fmt.Errorf("file is larger than %d bytes", h.maxUploadSize)
+	}
+	return data, nil
 }
 
 // handleTextMessage text xabarlarni qayta ishlash
@@ -822,6 +2762,62 @@ func (h *BotHandler) handleClearCommand(ctx context.Context, message *tgbotapi.M
 	h.sendMessage(message.Chat.ID, "✅ Chat tarixi tozalandi! Yangi suhbat boshlashingiz mumkin.")
 }
 
+// handleMyDataCommand foydalanuvchi haqida saqlangan ma'lumotlarni fayl qilib yuborish
+func (h *BotHandler) handleMyDataCommand(ctx context.Context, message *tgbotapi.Message) {
+	if !message.Chat.IsPrivate() {
+		h.sendMessage(message.Chat.ID, "🔒 Bu komanda faqat bot bilan shaxsiy chatda ishlaydi.")
+		return
+	}
+
+	userID := message.From.ID
+	data, err := h.privacyUseCase.ExportUserData(ctx, userID)
+	if err != nil {
+		log.Printf("Foydalanuvchi ma'lumotlarini eksport qilishda xatolik: %v", err)
+		h.sendMessage(message.Chat.ID, "❌ Ma'lumotlarni tayyorlab bo'lmadi. Keyinroq urinib ko'ring.")
+		return
+	}
+
+	doc := tgbotapi.NewDocument(message.Chat.ID, tgbotapi.FileBytes{
+		Name:  fmt.Sprintf("mydata_%d.json", userID),
+		Bytes: data,
+	})
+	doc.Caption = "📄 Siz haqingizda saqlangan barcha ma'lumotlar (yozishmalar, buyurtmalar, feedback). O'chirish uchun: /forgetme"
+	if _, err := h.bot.Send(doc); err != nil {
+		log.Printf("mydata faylini yuborishda xatolik: %v", err)
+		h.sendMessage(message.Chat.ID, "❌ Faylni yuborib bo'lmadi.")
+	}
+}
+
+// handleForgetMeCommand ma'lumotlarni o'chirishdan oldin tasdiq so'rash
+func (h *BotHandler) handleForgetMeCommand(ctx context.Context, message *tgbotapi.Message) {
+	if !message.Chat.IsPrivate() {
+		h.sendMessage(message.Chat.ID, "🔒 Bu komanda faqat bot bilan shaxsiy chatda ishlaydi.")
+		return
+	}
+
+	msg := tgbotapi.NewMessage(message.Chat.ID, "⚠️ Siz haqingizdagi barcha ma'lumotlar (yozishmalar, buyurtmalar, ism, telefon, manzil, feedback) butunlay o'chiriladi. Bu amalni qaytarib bo'lmaydi. Davom etamizmi?")
+	msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🗑 Ha, o'chirish", "forgetme_yes"),
+			tgbotapi.NewInlineKeyboardButtonData("Bekor qilish", "forgetme_no"),
+		),
+	)
+	h.bot.Send(msg)
+}
+
+// handleForgetMeConfirm tasdiqdan keyin ma'lumotlarni o'chirish
+func (h *BotHandler) handleForgetMeConfirm(ctx context.Context, userID, chatID int64) {
+	report, err := h.privacyUseCase.ForgetUser(ctx, userID)
+	if err != nil {
+		log.Printf("Foydalanuvchi ma'lumotlarini o'chirishda xatolik: %v", err)
+		h.sendMessage(chatID, "❌ Ma'lumotlarni to'liq o'chirib bo'lmadi. Iltimos, keyinroq qayta urinib ko'ring.")
+		return
+	}
+
+	h.sendMessage(chatID, fmt.Sprintf("✅ Ma'lumotlaringiz o'chirildi: %d ta xabar, %d ta buyurtma, %d ta narx obunasi, %d ta sessiya/feedback yozuvi.",
+		report.Messages, report.Orders, report.Alerts, report.State))
+}
+
 // handleHistoryCommand tarixni ko'rsatish
 func (h *BotHandler) handleHistoryCommand(ctx context.Context, message *tgbotapi.Message) {
 	history, err := h.chatUseCase.GetHistory(ctx, message.From.ID)
@@ -851,53 +2847,41 @@ func (h *BotHandler) handleHistoryCommand(ctx context.Context, message *tgbotapi
 func (h *BotHandler) isAwaitingPassword(userID int64) bool {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
-	return h.awaitingPassword[userID]
+	return h.hasState(stateAwaitingPassword, stateKey(userID))
 }
 
 // setAwaitingPassword parol kutish rejimini o'rnatish
 func (h *BotHandler) setAwaitingPassword(userID int64, awaiting bool) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
-	if awaiting {
-		h.awaitingPassword[userID] = true
-	} else {
-		delete(h.awaitingPassword, userID)
-	}
+	h.setFlag(stateAwaitingPassword, userID, awaiting)
 }
 
 // isAwaitingAdminMessage adminga xabar kutilayotganini tekshirish
 func (h *BotHandler) isAwaitingAdminMessage(userID int64) bool {
 	h.userMsgMu.RLock()
 	defer h.userMsgMu.RUnlock()
-	return h.awaitingAdminMsg[userID]
+	return h.hasState(stateAwaitingAdminMsg, stateKey(userID))
 }
 
 // setAwaitingAdminMessage adminga xabar kutilishini boshqarish
 func (h *BotHandler) setAwaitingAdminMessage(userID int64, awaiting bool) {
 	h.userMsgMu.Lock()
 	defer h.userMsgMu.Unlock()
-	if awaiting {
-		h.awaitingAdminMsg[userID] = true
-	} else {
-		delete(h.awaitingAdminMsg, userID)
-	}
+	h.setFlag(stateAwaitingAdminMsg, userID, awaiting)
 }
 
 // Shop mode helpers
 func (h *BotHandler) setShopMode(userID int64, on bool) {
 	h.shopMu.Lock()
 	defer h.shopMu.Unlock()
-	if on {
-		h.shopMode[userID] = true
-	} else {
-		delete(h.shopMode, userID)
-	}
+	h.setFlag(stateShopMode, userID, on)
 }
 
 func (h *BotHandler) isInShopMode(userID int64) bool {
 	h.shopMu.RLock()
 	defer h.shopMu.RUnlock()
-	return h.shopMode[userID]
+	return h.hasState(stateShopMode, stateKey(userID))
 }
 
 // sendMessage oddiy xabar yuborish
@@ -960,7 +2944,7 @@ func (h *BotHandler) handleAIProductSearch(ctx context.Context, userID int64, us
 
 	prompt := fmt.Sprintf(`Foydalanuvchi so'rovi: "%s"
 Quyida do'kon katalogi (nomlari va narxlar). Faqat shu ro'yxatdan eng mos 6 ta mahsulotni tanla.
-- Har bir satr: "Nom - $narx"
+- Har bir satr: "Nom - narx" (narxni katalogdagi valyutasi bilan yoz)
 - Faqat aniq katalog nomini va narxini yoz (o'zgartirma)
 - So'rovga mos kelmaydigan kategoriyalarni yozma
 - Oxirida jami summa yoki izoh yozma
@@ -1027,16 +3011,17 @@ func (h *BotHandler) sendConfigFeedbackPrompt(chatID int64) {
 func (h *BotHandler) saveFeedback(userID int64, info feedbackInfo) {
 	h.feedbackMu.Lock()
 	defer h.feedbackMu.Unlock()
-	h.feedbacks[userID] = info
+	h.saveState(stateFeedback, stateKey(userID), userID, info)
 }
 
 // Feedback ma'lumotini olish va o'chirish
 func (h *BotHandler) popFeedback(userID int64) (feedbackInfo, bool) {
 	h.feedbackMu.Lock()
 	defer h.feedbackMu.Unlock()
-	info, ok := h.feedbacks[userID]
+	var info feedbackInfo
+	ok := h.loadState(stateFeedback, stateKey(userID), &info)
 	if ok {
-		delete(h.feedbacks, userID)
+		h.deleteState(stateFeedback, stateKey(userID))
 	}
 	return info, ok
 }
@@ -1045,7 +3030,8 @@ func (h *BotHandler) popFeedback(userID int64) (feedbackInfo, bool) {
 func (h *BotHandler) getFeedback(userID int64) (feedbackInfo, bool) {
 	h.feedbackMu.RLock()
 	defer h.feedbackMu.RUnlock()
-	info, ok := h.feedbacks[userID]
+	var info feedbackInfo
+	ok := h.loadState(stateFeedback, stateKey(userID), &info)
 	return info, ok
 }
 
@@ -1083,6 +3069,43 @@ func (h *BotHandler) handleCallback(ctx context.Context, cq *tgbotapi.CallbackQu
 		return
 	}
 
+	// Buyurtma holatini o'zgartirish (2-guruh yoki admin)
+	if strings.HasPrefix(data, "ord_st:") {
+		h.handleOrderStatusCallback(ctx, cq)
+		return
+	}
+
+	// Audit log sahifalari va eksport
+	if strings.HasPrefix(data, "audit_page:") || data == "audit_export" {
+		isAdmin, _ := h.adminUseCase.IsAdmin(ctx, userID)
+		if !isAdmin {
+			h.sendMessage(chatID, "❌ Bu bo'lim faqat adminlar uchun.")
+			return
+		}
+		if data == "audit_export" {
+			h.sendAuditExport(ctx, userID, chatID)
+			return
+		}
+		offset, err := strconv.Atoi(strings.TrimPrefix(data, "audit_page:"))
+		if err != nil || offset < 0 {
+			offset = 0
+		}
+		h.sendAuditPage(ctx, userID, chatID, cq.Message.MessageID, offset)
+		return
+	}
+
+	// Katalog importini tasdiqlash / bekor qilish / hisobot
+	if data == "import_confirm" || data == "import_cancel" || data == "import_report" {
+		h.handleImportCallback(ctx, cq)
+		return
+	}
+
+	// Narx tushishi obunalari
+	if data == "alert_menu" || strings.HasPrefix(data, "alert_add:") || strings.HasPrefix(data, "alert_del:") {
+		h.handleAlertCallback(ctx, cq)
+		return
+	}
+
 	// Admin foydalanuvchi yozishmalari callbacki
 	if strings.HasPrefix(data, "admin_msgs_user:") {
 		isAdmin, _ := h.adminUseCase.IsAdmin(ctx, userID)
@@ -1095,6 +3118,10 @@ func (h *BotHandler) handleCallback(ctx context.Context, cq *tgbotapi.CallbackQu
 	}
 
 	switch data {
+	case "forgetme_yes":
+		h.handleForgetMeConfirm(ctx, userID, chatID)
+	case "forgetme_no":
+		h.sendMessage(chatID, "👌 Bekor qilindi, ma'lumotlaringiz o'zgarmadi.")
 	case "cfg_fb_yes":
 		h.sendMessage(chatID, "👍 Rahmat! Talabni qabul qildik, tez orada admin javob beradi.")
 		if info, ok := h.popFeedback(userID); ok {
@@ -1209,13 +3236,14 @@ func (h *BotHandler) handleCallback(ctx context.Context, cq *tgbotapi.CallbackQu
 }
 
 // startConfigSession yangi konfiguratsiya sessiyasini yaratish
-func (h *BotHandler) startConfigSession(userID int64) {
+func (h *BotHandler) startConfigSession(userID, chatID int64) {
 	h.configMu.Lock()
-	h.configSessions[userID] = &configSession{
+	h.saveState(stateConfigSession, stateKey(userID), userID, configSession{
 		Stage:      configStageNeedType,
+		ChatID:     chatID,
 		StartedAt:  time.Now(),
 		LastUpdate: time.Now(),
-	}
+	})
 	h.configMu.Unlock()
 }
 
@@ -1223,8 +3251,7 @@ func (h *BotHandler) startConfigSession(userID int64) {
 func (h *BotHandler) hasConfigSession(userID int64) bool {
 	h.configMu.RLock()
 	defer h.configMu.RUnlock()
-	_, ok := h.configSessions[userID]
-	return ok
+	return h.hasState(stateConfigSession, stateKey(userID))
 }
 
 // nonEmpty bo'sh bo'lmagan qiymatni qaytarish
@@ -1239,15 +3266,16 @@ func nonEmpty(val, fallback string) string {
 func (h *BotHandler) setPendingChange(userID int64, cr changeRequest) {
 	h.changeMu.Lock()
 	defer h.changeMu.Unlock()
-	h.pendingChange[userID] = cr
+	h.saveState(statePendingChange, stateKey(userID), userID, cr)
 }
 
 func (h *BotHandler) popPendingChange(userID int64) (changeRequest, bool) {
 	h.changeMu.Lock()
 	defer h.changeMu.Unlock()
-	cr, ok := h.pendingChange[userID]
+	var cr changeRequest
+	ok := h.loadState(statePendingChange, stateKey(userID), &cr)
 	if ok {
-		delete(h.pendingChange, userID)
+		h.deleteState(statePendingChange, stateKey(userID))
 	}
 	return cr, ok
 }
@@ -1255,8 +3283,7 @@ func (h *BotHandler) popPendingChange(userID int64) (changeRequest, bool) {
 func (h *BotHandler) hasPendingChange(userID int64) bool {
 	h.changeMu.RLock()
 	defer h.changeMu.RUnlock()
-	_, ok := h.pendingChange[userID]
-	return ok
+	return h.hasState(statePendingChange, stateKey(userID))
 }
 
 // Komponentni almashtirish uchun yuborilgan matnni qayta ishlash
@@ -1278,20 +3305,10 @@ func (h *BotHandler) handleChangeRequest(ctx context.Context, userID int64, user
 		spec.CPU = newValue
 	case "gpu":
 		spec.GPU = newValue
-	case "ram":
-		spec.CPU = spec.CPU // no-op, just explicit
-		spec.Budget = spec.Budget
-		spec.Storage = spec.Storage
-		spec.GPU = spec.GPU
-		spec.PCType = spec.PCType
-		spec.CPU = spec.CPU
-		spec.Storage = spec.Storage
-		spec.Budget = spec.Budget
 	case "ssd":
 		spec.Storage = newValue
-	case "other":
-		// Ustiga qo'shimcha talablarga yozamiz
-		spec.Storage = spec.Storage
+	default:
+		// "ram" va "other" uchun spec o'zgarmaydi - talab promptga qo'shimcha sifatida yoziladi
 	}
 
 	prompt := fmt.Sprintf("Mijoz konfiguratsiyani o'zgartirmoqchi. Talablar: maqsad=%s, budjet=%s, CPU=%s, xotira=%s, GPU=%s. Mijozning qo'shimcha talabi: %s. Shu asosida yangi konfiguratsiya tuzib, narxlarni ko'rsating.",
@@ -1368,32 +3385,46 @@ func (h *BotHandler) handleUserMessageForAdmin(ctx context.Context, userID int64
 func (h *BotHandler) startOrderSession(userID int64, info pendingApproval) {
 	h.orderMu.Lock()
 	defer h.orderMu.Unlock()
-	h.orderSessions[userID] = &orderSession{
+	h.saveState(stateOrderSession, stateKey(userID), userID, orderSession{
 		Stage:     orderStageNeedName,
+		ChatID:    info.UserChat,
 		Summary:   info.Summary,
 		ConfigTxt: info.Config,
 		Username:  info.Username,
-	}
+	})
 }
 
 func (h *BotHandler) clearOrderSession(userID int64) {
 	h.orderMu.Lock()
 	defer h.orderMu.Unlock()
-	delete(h.orderSessions, userID)
+	h.deleteState(stateOrderSession, stateKey(userID))
 }
 
 func (h *BotHandler) hasOrderSession(userID int64) bool {
 	h.orderMu.RLock()
 	defer h.orderMu.RUnlock()
-	_, ok := h.orderSessions[userID]
-	return ok
+	return h.hasState(stateOrderSession, stateKey(userID))
+}
+
+func (h *BotHandler) getOrderSession(userID int64) (*orderSession, bool) {
+	h.orderMu.RLock()
+	defer h.orderMu.RUnlock()
+	var session orderSession
+	if !h.loadState(stateOrderSession, stateKey(userID), &session) {
+		return nil, false
+	}
+	return &session, true
+}
+
+func (h *BotHandler) saveOrderSession(userID int64, session *orderSession) {
+	h.orderMu.Lock()
+	defer h.orderMu.Unlock()
+	h.saveState(stateOrderSession, stateKey(userID), userID, session)
 }
 
 // Order flow
 func (h *BotHandler) handleOrderFlow(ctx context.Context, userID int64, username, text string, chatID int64, msg *tgbotapi.Message) {
-	h.orderMu.Lock()
-	session, ok := h.orderSessions[userID]
-	h.orderMu.Unlock()
+	session, ok := h.getOrderSession(userID)
 	if !ok {
 		return
 	}
@@ -1406,9 +3437,7 @@ func (h *BotHandler) handleOrderFlow(ctx context.Context, userID int64, username
 			return
 		}
 		session.Stage = orderStageNeedPhone
-		h.orderMu.Lock()
-		h.orderSessions[userID] = session
-		h.orderMu.Unlock()
+		h.saveOrderSession(userID, session)
 		h.sendPhoneRequest(chatID)
 		return
 	case orderStageNeedPhone:
@@ -1424,9 +3453,7 @@ func (h *BotHandler) handleOrderFlow(ctx context.Context, userID int64, username
 		}
 		session.Phone = phone
 		session.Stage = orderStageNeedLocation
-		h.orderMu.Lock()
-		h.orderSessions[userID] = session
-		h.orderMu.Unlock()
+		h.saveOrderSession(userID, session)
 		h.sendLocationRequest(chatID)
 		return
 	case orderStageNeedLocation:
@@ -1441,22 +3468,11 @@ func (h *BotHandler) handleOrderFlow(ctx context.Context, userID int64, username
 		}
 		session.Location = locText
 		session.Stage = orderStageNeedDeliveryChoice
-		h.orderMu.Lock()
-		h.orderSessions[userID] = session
-		h.orderMu.Unlock()
+		h.saveOrderSession(userID, session)
 		h.sendDeliveryChoice(chatID)
 		return
-	case orderStageNeedDeliveryChoice:
-		// Kutamiz (callbacklar bilan)
-		h.orderMu.Lock()
-		h.orderSessions[userID] = session
-		h.orderMu.Unlock()
-		return
-	case orderStageNeedDeliveryConfirm:
+	case orderStageNeedDeliveryChoice, orderStageNeedDeliveryConfirm:
 		// Kutamiz (callbacklar bilan)
-		h.orderMu.Lock()
-		h.orderSessions[userID] = session
-		h.orderMu.Unlock()
 		return
 	}
 }
@@ -1464,15 +3480,16 @@ func (h *BotHandler) handleOrderFlow(ctx context.Context, userID int64, username
 // Delivery bosqichlari callbacklari
 func (h *BotHandler) handleDeliveryChoice(ctx context.Context, userID int64, choice string, chatID int64) {
 	h.orderMu.Lock()
-	session, ok := h.orderSessions[userID]
+	var session orderSession
+	ok := h.loadState(stateOrderSession, stateKey(userID), &session)
 	if ok {
 		if choice == "pickup" {
 			session.Delivery = "pickup"
-			h.orderSessions[userID] = session
+			h.saveState(stateOrderSession, stateKey(userID), userID, session)
 		} else if choice == "courier" {
 			session.Delivery = "courier"
 			session.Stage = orderStageNeedDeliveryConfirm
-			h.orderSessions[userID] = session
+			h.saveState(stateOrderSession, stateKey(userID), userID, session)
 		}
 	}
 	h.orderMu.Unlock()
@@ -1484,7 +3501,7 @@ func (h *BotHandler) handleDeliveryChoice(ctx context.Context, userID int64, cho
 
 	if choice == "pickup" {
 		h.sendMessage(chatID, "✅ Rahmat! Buyurtmangiz 24 soat ichida tayyor bo'ladi, ertaga olib ketishingiz mumkin.")
-		h.sendOrderToGroup2(userID, session, "Olib ketish", "")
+		h.placeOrder(ctx, userID, chatID, &session, entity.DeliveryPickup, "")
 		if err := h.sendSticker(chatID, orderDoneStickerID); err != nil {
 			h.sendMessage(chatID, "⚠️ Stiker yuborishda xatolik yuz berdi, lekin buyurtma qabul qilindi.")
 		}
@@ -1495,9 +3512,7 @@ func (h *BotHandler) handleDeliveryChoice(ctx context.Context, userID int64, cho
 }
 
 func (h *BotHandler) handleDeliveryConfirm(ctx context.Context, userID int64, agree bool, chatID int64) {
-	h.orderMu.Lock()
-	session, ok := h.orderSessions[userID]
-	h.orderMu.Unlock()
+	session, ok := h.getOrderSession(userID)
 	if !ok {
 		h.sendMessage(chatID, "Buyurtma ma'lumotlari topilmadi. /configuratsiya ni qayta bosing.")
 		return
@@ -1505,7 +3520,7 @@ func (h *BotHandler) handleDeliveryConfirm(ctx context.Context, userID int64, ag
 
 	if !agree {
 		h.sendMessage(chatID, "Unda buyurtmani olib ketish punktidan olib keting.")
-		h.sendOrderToGroup2(userID, session, "Olib ketish", "Dostavka narxiga rozilik bermadi")
+		h.placeOrder(ctx, userID, chatID, session, entity.DeliveryPickup, "Dostavka narxiga rozilik bermadi")
 		if err := h.sendSticker(chatID, orderDoneStickerID); err != nil {
 			h.sendMessage(chatID, "⚠️ Stiker yuborishda xatolik yuz berdi, lekin buyurtma qabul qilindi.")
 		}
@@ -1516,9 +3531,7 @@ func (h *BotHandler) handleDeliveryConfirm(ctx context.Context, userID int64, ag
 	// Ha bo'lsa
 	h.sendMessage(chatID, "✅ Qabul qilindi. Buyurtmangiz rasmiylashtirilmoqda.")
 
-	if h.group2ChatID != 0 {
-		h.sendOrderToGroup2(userID, session, "Dostavka (100k)", "Rozilik berildi")
-	}
+	h.placeOrder(ctx, userID, chatID, session, entity.DeliveryCourier, "Rozilik berildi")
 	if err := h.sendSticker(chatID, orderDoneStickerID); err != nil {
 		h.sendMessage(chatID, "⚠️ Stiker yuborishda xatolik yuz berdi, lekin buyurtma qabul qilindi.")
 	}
@@ -1568,24 +3581,255 @@ func (h *BotHandler) sendDeliveryConfirm(chatID int64) {
 	h.bot.Send(msg)
 }
 
+// placeOrder buyurtmani saqlash va 2-guruhga yuborish.
+// Saqlashda xatolik bo'lsa ham guruhga yuboriladi, buyurtma yo'qolmasligi uchun.
+func (h *BotHandler) placeOrder(ctx context.Context, userID, chatID int64, session *orderSession, delivery entity.DeliveryMethod, note string) {
+	summary := nonEmpty(session.ConfigTxt, session.Summary)
+
+	items, err := h.orderUseCase.MatchCatalogItems(ctx, summary)
+	if err != nil {
+		log.Printf("Buyurtma mahsulotlarini aniqlashda xatolik: %v", err)
+	}
+
+	draft := entity.Order{
+		UserID:         userID,
+		ChatID:         chatID,
+		Username:       session.Username,
+		CustomerName:   session.Name,
+		Phone:          session.Phone,
+		Location:       session.Location,
+		DeliveryMethod: delivery,
+		Note:           note,
+		Summary:        summary,
+		Items:          items,
+	}
+
+	order, err := h.orderUseCase.PlaceOrder(ctx, draft)
+	if err != nil {
+		log.Printf("Buyurtmani saqlashda xatolik: %v", err)
+		draft.Status = entity.OrderStatusNew
+		h.sendOrderToGroup2(ctx, &draft)
+		return
+	}
+
+	h.sendMessage(chatID, fmt.Sprintf("🧾 Buyurtma raqami: #%s\nHolatini /orders orqali kuzatishingiz mumkin.", shortOrderID(order.ID)))
+	h.sendOrderToGroup2(ctx, order)
+}
+
 // Send order summary to group 2
-func (h *BotHandler) sendOrderToGroup2(userID int64, session *orderSession, delivery string, note string) {
+func (h *BotHandler) sendOrderToGroup2(ctx context.Context, order *entity.Order) {
 	if h.group2ChatID == 0 {
 		return
 	}
-	summary := nonEmpty(session.ConfigTxt, session.Summary)
-	orderText := fmt.Sprintf(
-		"🧾 Yangi buyurtma\nUser ID: %d (@%s)\nIsm: %s\nTelefon: %s\nLokatsiya: %s\nYetkazish: %s\nIzoh: %s\n\n%s",
-		userID,
-		nonEmpty(session.Username, "nomalum"),
-		nonEmpty(session.Name, "ko'rsatilmagan"),
-		nonEmpty(session.Phone, "ko'rsatilmagan"),
-		nonEmpty(session.Location, "ko'rsatilmagan"),
+
+	text := buildOrderText(order, "🧾 Yangi buyurtma", h.rates(ctx))
+	if issues, err := h.orderUseCase.CheckCompatibility(ctx, order.Items); err == nil && len(issues) > 0 {
+		text += "\n\n⚠️ Moslik tekshiruvi:\n• " + strings.Join(issues, "\n• ")
+	}
+
+	msg := tgbotapi.NewMessage(h.group2ChatID, text)
+	if order.ID != "" {
+		msg.ReplyMarkup = buildOrderStatusButtons(order)
+	}
+	if _, err := h.bot.Send(msg); err != nil {
+		log.Printf("Buyurtmani guruhga yuborishda xatolik: %v", err)
+	}
+}
+
+// handleOrderStatusCallback 2-guruhdagi holat tugmalari ("ord_st:<id>:<status>")
+func (h *BotHandler) handleOrderStatusCallback(ctx context.Context, cq *tgbotapi.CallbackQuery) {
+	chatID := cq.Message.Chat.ID
+	isAdmin, _ := h.adminUseCase.IsAdmin(ctx, cq.From.ID)
+	if !isAdmin && (h.group2ChatID == 0 || chatID != h.group2ChatID) {
+		h.sendMessage(chatID, "❌ Buyurtma holatini faqat adminlar o'zgartira oladi.")
+		return
+	}
+
+	parts := strings.Split(strings.TrimPrefix(cq.Data, "ord_st:"), ":")
+	if len(parts) != 2 {
+		h.sendMessage(chatID, "❌ Buyurtma ma'lumotini o'qib bo'lmadi.")
+		return
+	}
+
+	order, err := h.orderUseCase.UpdateStatus(ctx, parts[0], entity.OrderStatus(parts[1]))
+	var shortage *usecase.StockShortageError
+	if errors.As(err, &shortage) {
+		h.sendMessage(chatID, buildStockShortageText(shortage.Shortages))
+		return
+	}
+	if err != nil {
+		log.Printf("Buyurtma holatini o'zgartirishda xatolik: %v", err)
+		h.sendMessage(chatID, "❌ Buyurtma holatini o'zgartirib bo'lmadi (topilmadi yoki holat eskirgan).")
+		return
+	}
+
+	edit := tgbotapi.NewEditMessageText(chatID, cq.Message.MessageID, buildOrderText(order, "🧾 Buyurtma", h.rates(ctx)))
+	markup := buildOrderStatusButtons(order)
+	edit.ReplyMarkup = &markup
+	if _, err := h.bot.Send(edit); err != nil {
+		log.Printf("Buyurtma xabarini yangilashda xatolik: %v", err)
+	}
+
+	if order.ChatID != 0 {
+		h.sendMessage(order.ChatID, fmt.Sprintf("📦 Buyurtma #%s holati: %s", shortOrderID(order.ID), orderStatusLabel(order.Status)))
+	}
+}
+
+// buildStockShortageText buyurtmani tasdiqlashga ombor yetmagan mahsulotlar
+func buildStockShortageText(shortages []entity.StockShortage) string {
+	var b strings.Builder
+	b.WriteString("❌ Omborda yetarli emas, buyurtma tasdiqlanmadi:\n")
+	for _, s := range shortages {
+		fmt.Fprintf(&b, "• %s: kerak %d ta, bo'sh %d ta (omborda %d, boshqa buyurtmalarda bron %d)\n",
+			s.Name, s.Required, max(s.Stock-s.Reserved, 0), s.Stock, s.Reserved)
+	}
+	b.WriteString("\nMijoz bilan kelishib, buyurtmani bekor qiling yoki omborni yangilang.")
+	return b.String()
+}
+
+// handleOrdersCommand foydalanuvchi buyurtmalari; admin uchun "/orders all" yoki "/orders <holat>"
+func (h *BotHandler) handleOrdersCommand(ctx context.Context, message *tgbotapi.Message) {
+	userID := message.From.ID
+	arg := strings.ToLower(strings.TrimSpace(message.CommandArguments()))
+
+	var orders []entity.Order
+	var err error
+	title := "📦 Buyurtmalaringiz:"
+
+	isAdmin, _ := h.adminUseCase.IsAdmin(ctx, userID)
+	if isAdmin && arg != "" {
+		status := entity.OrderStatus(arg)
+		if arg == "all" {
+			status = ""
+		}
+		orders, err = h.orderUseCase.ListOrders(ctx, status, 20)
+		title = fmt.Sprintf("📦 Buyurtmalar (%s):", nonEmpty(string(status), "barchasi"))
+	} else {
+		orders, err = h.orderUseCase.ListUserOrders(ctx, userID)
+	}
+	if err != nil {
+		log.Printf("Buyurtmalarni yuklashda xatolik: %v", err)
+		h.sendMessage(message.Chat.ID, "❌ Buyurtmalarni yuklab bo'lmadi.")
+		return
+	}
+	if len(orders) == 0 {
+		h.sendMessage(message.Chat.ID, "Buyurtmalar topilmadi.")
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString(title + "\n\n")
+	for _, o := range orders {
+		entry := fmt.Sprintf("#%s • %s • %s\n   %s\n",
+			shortOrderID(o.ID),
+			o.CreatedAt.Format("2006-01-02 15:04"),
+			orderStatusLabel(o.Status),
+			orderItemsLine(o),
+		)
+		if sb.Len()+len(entry) > 3900 {
+			sb.WriteString("…")
+			break
+		}
+		sb.WriteString(entry)
+	}
+
+	h.sendMessage(message.Chat.ID, sb.String())
+}
+
+func buildOrderText(order *entity.Order, title string, rates entity.ExchangeRates) string {
+	delivery := "Olib ketish"
+	if order.DeliveryMethod == entity.DeliveryCourier {
+		delivery = "Dostavka (100k)"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(title)
+	if order.ID != "" {
+		sb.WriteString(fmt.Sprintf(" #%s", shortOrderID(order.ID)))
+	}
+	sb.WriteString(fmt.Sprintf("\nHolat: %s\nUser ID: %d (@%s)\nIsm: %s\nTelefon: %s\nLokatsiya: %s\nYetkazish: %s\nIzoh: %s\n",
+		orderStatusLabel(order.Status),
+		order.UserID,
+		nonEmpty(order.Username, "nomalum"),
+		nonEmpty(order.CustomerName, "ko'rsatilmagan"),
+		nonEmpty(order.Phone, "ko'rsatilmagan"),
+		nonEmpty(order.Location, "ko'rsatilmagan"),
 		delivery,
-		nonEmpty(note, "-"),
-		summary,
-	)
-	h.sendMessage(h.group2ChatID, orderText)
+		nonEmpty(order.Note, "-"),
+	))
+
+	if len(order.Items) > 0 {
+		sb.WriteString("\n🛒 Mahsulotlar:\n")
+		for i, item := range order.Items {
+			sb.WriteString(fmt.Sprintf("%d) %s x%d - %s\n", i+1, item.Name, item.Quantity,
+				entity.FormatMoney(item.Price*float64(item.Quantity), item.Currency)))
+		}
+		sb.WriteString("Jami: " + orderTotalText(order, rates) + "\n")
+	}
+
+	sb.WriteString("\n" + truncateString(order.Summary, 2500))
+	return sb.String()
+}
+
+func buildOrderStatusButtons(order *entity.Order) tgbotapi.InlineKeyboardMarkup {
+	row := []tgbotapi.InlineKeyboardButton{}
+	for _, next := range order.Status.NextStatuses() {
+		row = append(row, tgbotapi.NewInlineKeyboardButtonData(
+			orderStatusLabel(next),
+			fmt.Sprintf("ord_st:%s:%s", order.ID, next),
+		))
+	}
+
+	rows := [][]tgbotapi.InlineKeyboardButton{}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+	return tgbotapi.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+func orderStatusLabel(status entity.OrderStatus) string {
+	switch status {
+	case entity.OrderStatusNew:
+		return "🆕 Yangi"
+	case entity.OrderStatusConfirmed:
+		return "✅ Tasdiqlandi"
+	case entity.OrderStatusReady:
+		return "📦 Tayyor"
+	case entity.OrderStatusShipped:
+		return "🚚 Yo'lda"
+	case entity.OrderStatusDelivered:
+		return "🎉 Topshirildi"
+	case entity.OrderStatusCancelled:
+		return "❌ Bekor qilindi"
+	default:
+		return string(status)
+	}
+}
+
+// orderTotalText jami summa so'm va dollarda (kurs yo'qligidan hisoblanmagan bo'lsa izoh)
+func orderTotalText(order *entity.Order, rates entity.ExchangeRates) string {
+	if order.Total == 0 && len(order.Items) > 0 {
+		return "hisoblanmadi (valyuta kursi kiritilmagan)"
+	}
+	return rates.FormatDual(order.Total, order.Currency)
+}
+
+func orderItemsLine(order entity.Order) string {
+	if len(order.Items) == 0 {
+		return truncateString(strings.ReplaceAll(order.Summary, "\n", " "), 120)
+	}
+	names := make([]string, 0, len(order.Items))
+	for _, item := range order.Items {
+		names = append(names, item.Name)
+	}
+	return truncateString(fmt.Sprintf("%s — %s", strings.Join(names, ", "), entity.FormatMoney(order.Total, order.Currency)), 200)
+}
+
+func shortOrderID(id string) string {
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
 }
 
 // Sticker helper
@@ -1602,13 +3846,13 @@ func (h *BotHandler) sendSticker(chatID int64, stickerID string) error {
 func (h *BotHandler) wasConfigReminded(chatID int64) bool {
 	h.reminderMu.RLock()
 	defer h.reminderMu.RUnlock()
-	return h.configReminded[chatID]
+	return h.hasState(stateConfigReminded, stateKey(chatID))
 }
 
 func (h *BotHandler) markConfigReminded(chatID int64) {
 	h.reminderMu.Lock()
 	defer h.reminderMu.Unlock()
-	h.configReminded[chatID] = true
+	h.saveState(stateConfigReminded, stateKey(chatID), chatID, true)
 }
 
 // Komponent almashtirish tugmalari
@@ -1673,13 +3917,14 @@ func isLikelyConfigResponse(text string) bool {
 func (h *BotHandler) saveGroupThread(messageID int, info groupThreadInfo) {
 	h.groupMu.Lock()
 	defer h.groupMu.Unlock()
-	h.groupThreads[messageID] = info
+	h.saveState(stateGroupThread, strconv.Itoa(messageID), info.UserID, info)
 }
 
 func (h *BotHandler) getGroupThread(messageID int) (groupThreadInfo, bool) {
 	h.groupMu.RLock()
 	defer h.groupMu.RUnlock()
-	info, ok := h.groupThreads[messageID]
+	var info groupThreadInfo
+	ok := h.loadState(stateGroupThread, strconv.Itoa(messageID), &info)
 	return info, ok
 }
 
@@ -1687,15 +3932,16 @@ func (h *BotHandler) getGroupThread(messageID int) (groupThreadInfo, bool) {
 func (h *BotHandler) savePendingApproval(userID int64, info pendingApproval) {
 	h.approvalMu.Lock()
 	defer h.approvalMu.Unlock()
-	h.pendingApprove[userID] = info
+	h.saveState(statePendingApproval, stateKey(userID), userID, info)
 }
 
 func (h *BotHandler) popPendingApproval(userID int64) (pendingApproval, bool) {
 	h.approvalMu.Lock()
 	defer h.approvalMu.Unlock()
-	info, ok := h.pendingApprove[userID]
+	var info pendingApproval
+	ok := h.loadState(statePendingApproval, stateKey(userID), &info)
 	if ok {
-		delete(h.pendingApprove, userID)
+		h.deleteState(statePendingApproval, stateKey(userID))
 	}
 	return info, ok
 }
@@ -1704,15 +3950,16 @@ func (h *BotHandler) popPendingApproval(userID int64) (pendingApproval, bool) {
 func (h *BotHandler) saveAdminApproval(messageID int, req adminApprovalRequest) {
 	h.adminApprovalMu.Lock()
 	defer h.adminApprovalMu.Unlock()
-	h.adminApprovals[messageID] = req
+	h.saveState(stateAdminApproval, strconv.Itoa(messageID), req.Target.UserID, req)
 }
 
 func (h *BotHandler) popAdminApproval(messageID int) (adminApprovalRequest, bool) {
 	h.adminApprovalMu.Lock()
 	defer h.adminApprovalMu.Unlock()
-	req, ok := h.adminApprovals[messageID]
+	var req adminApprovalRequest
+	ok := h.loadState(stateAdminApproval, strconv.Itoa(messageID), &req)
 	if ok {
-		delete(h.adminApprovals, messageID)
+		h.deleteState(stateAdminApproval, strconv.Itoa(messageID))
 	}
 	return req, ok
 }
@@ -1823,6 +4070,83 @@ func (h *BotHandler) handleAdminApprovalCallback(reqID int, allowOrder bool, cha
 	return true
 }
 
+// handleFindCommand yozishmalar bo'yicha qidiruv (admin uchun): /find <matn>
+func (h *BotHandler) handleFindCommand(ctx context.Context, message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+	isAdmin, _ := h.adminUseCase.IsAdmin(ctx, message.From.ID)
+	if !isAdmin {
+		h.sendMessage(chatID, "❌ Bu komanda faqat adminlar uchun.")
+		return
+	}
+
+	query := strings.TrimSpace(message.CommandArguments())
+	if query == "" {
+		h.sendMessage(chatID, "Foydalanish: /find <matn>\nMasalan: /find RTX 4070 Ti Super")
+		return
+	}
+
+	matches, err := h.chatUseCase.SearchMessages(ctx, query, 50)
+	if err != nil {
+		log.Printf("Yozishmalarni qidirishda xatolik: %v", err)
+		h.sendMessage(chatID, "❌ Qidiruvda xatolik yuz berdi.")
+		return
+	}
+	if len(matches) == 0 {
+		h.sendMessage(chatID, fmt.Sprintf("🔎 \"%s\" bo'yicha hech narsa topilmadi.", query))
+		return
+	}
+
+	text, users := buildFindResultsText(query, matches, 3900)
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ReplyMarkup = buildUserButtons(users)
+	if _, err := h.bot.Send(msg); err != nil {
+		log.Printf("Qidiruv natijasini yuborishda xatolik: %v", err)
+	}
+}
+
+// buildFindResultsText natijalarni foydalanuvchi bo'yicha guruhlash (har biriga 3 tagacha parcha)
+func buildFindResultsText(query string, matches []entity.MessageMatch, maxLen int) (string, []adminUserSummary) {
+	var order []int64
+	grouped := make(map[int64][]entity.MessageMatch)
+	for _, m := range matches {
+		if _, ok := grouped[m.Message.UserID]; !ok {
+			order = append(order, m.Message.UserID)
+		}
+		grouped[m.Message.UserID] = append(grouped[m.Message.UserID], m)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("🔎 \"%s\": %d ta moslik, %d ta foydalanuvchi\n\n", query, len(matches), len(order)))
+
+	var users []adminUserSummary
+	for _, userID := range order {
+		list := grouped[userID]
+		first := list[0].Message
+
+		var entry strings.Builder
+		entry.WriteString(fmt.Sprintf("👤 @%s (%d) — %d ta\n", nonEmpty(first.Username, "nomalum"), userID, len(list)))
+		for i, m := range list {
+			if i == 3 {
+				entry.WriteString("   …\n")
+				break
+			}
+			snippet := nonEmpty(m.Snippet, truncateString(m.Message.Text, 120))
+			entry.WriteString(fmt.Sprintf("   🕒 %s: %s\n", m.Message.Timestamp.Format("02 Jan 15:04"), snippet))
+		}
+		entry.WriteString("\n")
+
+		if maxLen > 0 && sb.Len()+entry.Len() > maxLen {
+			sb.WriteString("…")
+			break
+		}
+		sb.WriteString(entry.String())
+		users = append(users, adminUserSummary{UserID: userID, Username: first.Username, LastAt: first.Timestamp})
+	}
+
+	sb.WriteString("To'liq yozishmani ochish uchun foydalanuvchini tanlang.")
+	return sb.String(), users
+}
+
 func buildAdminMessagesDigest(msgs []entity.Message, maxLen int) string {
 	var sb strings.Builder
 	sb.WriteString("🗂 Oxirgi yozishmalar:\n\n")
@@ -1961,13 +4285,13 @@ func buildUserConversationDigest(msgs []entity.Message, maxLen int) string {
 	return sb.String()
 }
 
-func buildProductPreview(products []entity.Product, limit int) string {
+func buildProductPreview(products []entity.Product, limit int, rates entity.ExchangeRates) string {
 	if limit > 0 && len(products) > limit {
 		products = products[:limit]
 	}
 	var sb strings.Builder
 	for i, p := range products {
-		sb.WriteString(fmt.Sprintf("%d) %s - $%.2f", i+1, p.Name, p.Price))
+		sb.WriteString(fmt.Sprintf("%d) %s - %s", i+1, p.Name, rates.FormatDual(p.Price, p.PriceCurrency())))
 		if p.Stock > 0 {
 			sb.WriteString(fmt.Sprintf(" (Ombor: %d)", p.Stock))
 		}
@@ -2159,11 +4483,25 @@ func (h *BotHandler) getHelpMessage() string {
 /clear - Chat tarixini tozalash
 /history - Chat tarixini ko'rish
 /configuratsiya - PC yig'ish uchun bosqichma-bosqich sozlash
+/orders - Buyurtmalaringiz va ularning holati
+/alerts - Narxi kuzatilayotgan mahsulotlar
+/mydata - Siz haqingizda saqlangan ma'lumotlar (fayl)
+/forgetme - Barcha ma'lumotlaringizni o'chirish
 
 🔐 Admin:
 /admin - Admin panelga kirish
 /logout - Admin paneldan chiqish
 /catalog - Katalog haqida ma'lumot (admin)
+/export\_catalog - Katalogni Excel faylga yuklab olish (admin)
+/rate - Valyuta kurslari: /rate USD 12650, /rate refresh (admin)
+/taxonomy - Kategoriya qoidalari: add, del, name, test, export, reset (admin)
+/recategorize - Katalogni qoidalar bo'yicha qayta kategoriyalash (admin)
+/profile - Import profillari: save, del (admin)
+/versions, /diff, /rollback - Katalog versiyalari (admin)
+/pricehistory <nom> - Mahsulot narxlari tarixi (admin)
+/audit - Admin harakatlari logi (admin)
+/orders all|new|confirmed - Buyurtmalar ro'yxati (admin)
+/find <matn> - Yozishmalar bo'yicha qidiruv (admin)
 /products - Barcha mahsulotlar
 
 *Qanday foydalanish:*
@@ -18,3 +18,30 @@ type AdminAction struct {
 	Details   string
 	Timestamp time.Time
 }
+
+// AuditFilter admin harakatlari logini filtrlash parametrlari
+type AuditFilter struct {
+	UserID int64     // 0 bo'lsa barcha adminlar
+	Action string    // bo'sh bo'lsa barcha harakatlar
+	From   time.Time // nol bo'lsa boshidan
+	To     time.Time // nol bo'lsa hozirgacha
+	Limit  int       // 0 bo'lsa cheklovsiz
+	Offset int
+}
+
+// Match harakat filtrga mos kelishini tekshirish
+func (f AuditFilter) Match(action AdminAction) bool {
+	if f.UserID != 0 && action.UserID != f.UserID {
+		return false
+	}
+	if f.Action != "" && action.Action != f.Action {
+		return false
+	}
+	if !f.From.IsZero() && action.Timestamp.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && !action.Timestamp.Before(f.To) {
+		return false
+	}
+	return true
+}
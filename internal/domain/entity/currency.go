@@ -0,0 +1,184 @@
+package entity
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Currency narx valyutasi (ISO 4217 kodi)
+type Currency string
+
+const (
+	CurrencyUZS Currency = "UZS"
+	CurrencyUSD Currency = "USD"
+	CurrencyEUR Currency = "EUR"
+	CurrencyRUB Currency = "RUB"
+)
+
+// DefaultCurrency valyutasi ko'rsatilmagan narxlar uchun (eski kataloglar dollarda edi)
+const DefaultCurrency = CurrencyUSD
+
+// currencyAliases valyuta nomlari va belgilari (kichik harfda)
+var currencyAliases = map[string]Currency{
+	"uzs": CurrencyUZS, "so'm": CurrencyUZS, "soʻm": CurrencyUZS, "so‘m": CurrencyUZS, "so`m": CurrencyUZS,
+	"som": CurrencyUZS, "soum": CurrencyUZS, "sum": CurrencyUZS, "сум": CurrencyUZS, "сўм": CurrencyUZS, "сом": CurrencyUZS,
+	"usd": CurrencyUSD, "$": CurrencyUSD, "dollar": CurrencyUSD, "dollars": CurrencyUSD, "доллар": CurrencyUSD, "у.е": CurrencyUSD,
+	"eur": CurrencyEUR, "€": CurrencyEUR, "euro": CurrencyEUR, "yevro": CurrencyEUR, "евро": CurrencyEUR,
+	"rub": CurrencyRUB, "₽": CurrencyRUB, "руб": CurrencyRUB, "рубль": CurrencyRUB, "rubl": CurrencyRUB, "р": CurrencyRUB,
+}
+
+// ParseCurrency valyuta kodi, nomi yoki belgisini aniqlash ("usd", "$", "so'm", "сум"...)
+func ParseCurrency(s string) (Currency, bool) {
+	s = strings.Trim(strings.ToLower(strings.TrimSpace(s)), ".")
+	if c, ok := currencyAliases[s]; ok {
+		return c, true
+	}
+	return "", false
+}
+
+// Symbol narx yonida yoziladigan belgi
+func (c Currency) Symbol() string {
+	switch c {
+	case CurrencyUSD:
+		return "$"
+	case CurrencyEUR:
+		return "€"
+	case CurrencyRUB:
+		return "₽"
+	case CurrencyUZS:
+		return "so'm"
+	default:
+		return string(c)
+	}
+}
+
+// PriceCurrency mahsulot narxi valyutasi (bo'sh bo'lsa DefaultCurrency)
+func (p Product) PriceCurrency() Currency {
+	if p.Currency == "" {
+		return DefaultCurrency
+	}
+	return p.Currency
+}
+
+// Qo'lda kiritilgan kurslar manbasi (fon yangilanishi ularni bosib ketmaydi)
+const (
+	RateSourceAdmin = "admin" // /rate komandasi
+	RateSourceFile  = "file"  // kurslar fayli
+)
+
+// ExchangeRate valyuta kursi: 1 birlik necha so'm
+type ExchangeRate struct {
+	Currency  Currency
+	Rate      float64
+	Source    string // RateSourceAdmin, RateSourceFile yoki kurs manbai nomi ("cbu")
+	UpdatedAt time.Time
+}
+
+// IsManual kurs admin tomonidan kiritilgan
+func (r ExchangeRate) IsManual() bool {
+	return r.Source == RateSourceAdmin || r.Source == RateSourceFile
+}
+
+// ExchangeRates valyuta -> 1 birlik necha so'm (UZS har doim 1)
+type ExchangeRates map[Currency]float64
+
+// NewExchangeRates kurslar ro'yxatidan jadval yaratish
+func NewExchangeRates(list []ExchangeRate) ExchangeRates {
+	rates := ExchangeRates{CurrencyUZS: 1}
+	for _, r := range list {
+		if r.Rate > 0 {
+			rates[r.Currency] = r.Rate
+		}
+	}
+	return rates
+}
+
+// Convert summani bir valyutadan boshqasiga o'tkazish (kurs bo'lmasa false)
+func (r ExchangeRates) Convert(amount float64, from, to Currency) (float64, bool) {
+	if from == to {
+		return amount, true
+	}
+	fromRate, ok := r.rate(from)
+	if !ok {
+		return 0, false
+	}
+	toRate, ok := r.rate(to)
+	if !ok {
+		return 0, false
+	}
+	return amount * fromRate / toRate, true
+}
+
+func (r ExchangeRates) rate(c Currency) (float64, bool) {
+	if c == CurrencyUZS {
+		return 1, true
+	}
+	rate, ok := r[c]
+	return rate, ok && rate > 0
+}
+
+// FormatDual narxni asl valyutada va yonida so'm (so'mdagi narx uchun dollar) bilan yozish.
+// Kurs bo'lmasa faqat asl narx: "$199.90 (≈ 2 528 735 so'm)"
+func (r ExchangeRates) FormatDual(amount float64, c Currency) string {
+	if c == "" {
+		c = DefaultCurrency
+	}
+	primary := FormatMoney(amount, c)
+
+	second := CurrencyUZS
+	if c == CurrencyUZS {
+		second = CurrencyUSD
+	}
+	converted, ok := r.Convert(amount, c, second)
+	if !ok {
+		return primary
+	}
+	return fmt.Sprintf("%s (≈ %s)", primary, FormatMoney(converted, second))
+}
+
+// FormatMoney narxni valyuta belgisi bilan yozish: "$199.90", "2 500 000 so'm"
+func FormatMoney(amount float64, c Currency) string {
+	switch c {
+	case CurrencyUZS:
+		return groupThousands(int64(math.Round(amount))) + " so'm"
+	case CurrencyUSD, "":
+		return fmt.Sprintf("$%.2f", amount)
+	case CurrencyEUR, CurrencyRUB:
+		return fmt.Sprintf("%.2f %s", amount, c.Symbol())
+	default:
+		return fmt.Sprintf("%.2f %s", amount, c)
+	}
+}
+
+// ParseAmount kurs yoki summani o'qish: "12650", "12 650", "12,650", "12650.55", "12 650,55".
+// Nuqtasiz yagona vergul undan keyin aynan 3 raqam bo'lsa minglik ajratuvchi, aks holda kasr.
+func ParseAmount(s string) (float64, error) {
+	s = strings.NewReplacer(" ", "", "\u00a0", "").Replace(strings.TrimSpace(s))
+	if strings.Count(s, ",") == 1 && !strings.Contains(s, ".") && len(s)-strings.Index(s, ",") != 4 {
+		s = strings.Replace(s, ",", ".", 1)
+	} else {
+		s = strings.ReplaceAll(s, ",", "")
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+// groupThousands butun sonni minglik bo'laklarga ajratish: 2500000 -> "2 500 000"
+func groupThousands(n int64) string {
+	sign := ""
+	if n < 0 {
+		sign = "-"
+		n = -n
+	}
+	digits := strconv.FormatInt(n, 10)
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteRune(d)
+	}
+	return sign + b.String()
+}
@@ -0,0 +1,127 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+)
+
+// HardwareAttributes mahsulot nomi va xususiyatlaridan olingan texnik atributlar.
+// Noma'lum maydonlar nol qiymatda qoladi.
+type HardwareAttributes struct {
+	Socket           string // "AM5", "LGA1700" (protsessor va ona plata)
+	Chipset          string // "B650", "Z790" (ona plata)
+	MemoryType       string // "DDR4", "DDR5"
+	MemorySpeedMHz   int    // 6000
+	MemoryGB         int    // operativ xotira to'plami hajmi (2x16 -> 32)
+	VRAMGB           int    // videokarta xotirasi
+	StorageGB        int    // disk hajmi (1 TB = 1000 GB)
+	StorageInterface string // "NVMe", "SATA"
+	PSUWatts         int    // quvvat bloki
+	PSUEfficiency    string // "80+ Gold"
+	FormFactor       string // "ATX", "mATX", "Mini-ITX", "E-ATX"
+	RefreshRateHz    int    // monitor
+}
+
+// IsZero birorta atribut aniqlanmagan
+func (a HardwareAttributes) IsZero() bool {
+	return a == HardwareAttributes{}
+}
+
+// Summary atributlarning qisqa ko'rinishi: "AM5, B650, DDR5-6000, 32GB"
+func (a HardwareAttributes) Summary() string {
+	var parts []string
+	add := func(ok bool, format string, args ...any) {
+		if ok {
+			parts = append(parts, fmt.Sprintf(format, args...))
+		}
+	}
+
+	add(a.Socket != "", "%s", a.Socket)
+	add(a.Chipset != "", "%s", a.Chipset)
+	switch {
+	case a.MemoryType != "" && a.MemorySpeedMHz > 0:
+		parts = append(parts, fmt.Sprintf("%s-%d", a.MemoryType, a.MemorySpeedMHz))
+	case a.MemoryType != "":
+		parts = append(parts, a.MemoryType)
+	case a.MemorySpeedMHz > 0:
+		parts = append(parts, fmt.Sprintf("%d MHz", a.MemorySpeedMHz))
+	}
+	add(a.MemoryGB > 0, "%dGB RAM", a.MemoryGB)
+	add(a.VRAMGB > 0, "%dGB VRAM", a.VRAMGB)
+	if a.StorageGB > 0 {
+		size := fmt.Sprintf("%dGB", a.StorageGB)
+		if a.StorageGB >= 1000 && a.StorageGB%1000 == 0 {
+			size = fmt.Sprintf("%dTB", a.StorageGB/1000)
+		}
+		parts = append(parts, size)
+	}
+	add(a.StorageInterface != "", "%s", a.StorageInterface)
+	add(a.PSUWatts > 0, "%dW", a.PSUWatts)
+	add(a.PSUEfficiency != "", "%s", a.PSUEfficiency)
+	add(a.FormFactor != "", "%s", a.FormFactor)
+	add(a.RefreshRateHz > 0, "%dHz", a.RefreshRateHz)
+
+	return strings.Join(parts, ", ")
+}
+
+// Matches mahsulot filtrda ko'rsatilgan atributlarga zid emasmi.
+// Filtrning faqat to'ldirilgan maydonlari tekshiriladi; mahsulotda noma'lum maydon zid hisoblanmaydi.
+func (a HardwareAttributes) Matches(filter HardwareAttributes) bool {
+	differs := func(want, have string) bool {
+		return want != "" && have != "" && !strings.EqualFold(want, have)
+	}
+	differsInt := func(want, have int) bool {
+		return want > 0 && have > 0 && want != have
+	}
+
+	switch {
+	case differs(filter.Socket, a.Socket),
+		differs(filter.Chipset, a.Chipset),
+		differs(filter.MemoryType, a.MemoryType),
+		differs(filter.StorageInterface, a.StorageInterface),
+		differs(filter.PSUEfficiency, a.PSUEfficiency),
+		differs(filter.FormFactor, a.FormFactor),
+		differsInt(filter.MemorySpeedMHz, a.MemorySpeedMHz),
+		differsInt(filter.MemoryGB, a.MemoryGB),
+		differsInt(filter.VRAMGB, a.VRAMGB),
+		differsInt(filter.StorageGB, a.StorageGB),
+		differsInt(filter.PSUWatts, a.PSUWatts),
+		differsInt(filter.RefreshRateHz, a.RefreshRateHz):
+		return false
+	}
+	return true
+}
+
+// CompatibilityIssues mahsulotlar to'plamidagi mos kelmasliklar (protsessor va ona plata
+// socketi, ona plata va operativ xotira turi). Atributi noma'lum mahsulotlar tekshirilmaydi.
+func CompatibilityIssues(products []Product) []string {
+	var cpus, boards, rams []Product
+	for _, p := range products {
+		attrs := p.Attributes
+		switch {
+		case attrs.Chipset != "":
+			boards = append(boards, p)
+		case attrs.Socket != "":
+			cpus = append(cpus, p)
+		case attrs.MemoryType != "" && attrs.MemoryGB > 0:
+			rams = append(rams, p)
+		}
+	}
+
+	var issues []string
+	for _, board := range boards {
+		for _, cpu := range cpus {
+			if board.Attributes.Socket != "" && cpu.Attributes.Socket != board.Attributes.Socket {
+				issues = append(issues, fmt.Sprintf("%s (%s) va %s (%s): socket mos emas",
+					cpu.Name, cpu.Attributes.Socket, board.Name, board.Attributes.Socket))
+			}
+		}
+		for _, ram := range rams {
+			if board.Attributes.MemoryType != "" && ram.Attributes.MemoryType != board.Attributes.MemoryType {
+				issues = append(issues, fmt.Sprintf("%s (%s) va %s (%s): xotira turi mos emas",
+					ram.Name, ram.Attributes.MemoryType, board.Name, board.Attributes.MemoryType))
+			}
+		}
+	}
+	return issues
+}
@@ -0,0 +1,238 @@
+package entity
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Atributlarni aniqlash uchun andozalar (matn kichik harfda)
+var (
+	reSocket      = regexp.MustCompile(`(?:^|[^a-z0-9])(am[45]|lga\s?-?\s?(\d{4})|tr4|strx4|str5)(?:[^0-9]|$)`)
+	reChipset     = regexp.MustCompile(`(?:^|[^a-z0-9])([abhxzw][3-8][1-9]0)(e?)([mi]?)(?:[^a-z0-9]|$)`)
+	reRyzen       = regexp.MustCompile(`ryzen\s*(?:threadripper\s*)?(?:[3579]\s*)?(?:pro\s*)?(\d)\d{3}`)
+	reIntelCore   = regexp.MustCompile(`(?:^|[^a-z0-9])i[3579][\s-]*(\d{4,5})`)
+	reCoreUltra   = regexp.MustCompile(`ultra\s*[3579]\s*2\d{2}`)
+	reMemoryType  = regexp.MustCompile(`(?:^|[^a-z])(?:lp)?ddr([345])`)
+	reMemorySpeed = regexp.MustCompile(`ddr[345][\s-]*(\d{4})|(\d{4})\s*(?:mhz|mt/s|мгц)`)
+	reMemoryKit   = regexp.MustCompile(`(\d)\s*[x×х]\s*(\d{1,3})\s*(?:gb|гб|g)(?:[^a-z]|$)`)
+	reGigabytes   = regexp.MustCompile(`(\d{1,3})\s*(?:gb|гб|g)(?:[^a-z]|$)`)
+	reStorageSize = regexp.MustCompile(`(\d+(?:[.,]\d+)?)\s*(tb|тб|gb|гб)`)
+	reWatts       = regexp.MustCompile(`(\d{3,4})\s*(?:w|вт|watt)(?:[^a-z]|$)`)
+	reEfficiency  = regexp.MustCompile(`(?:80\s*\+?\s*(?:plus)?\s*)?(white|bronze|silver|gold|platinum|titanium)`)
+	reFormFactor  = regexp.MustCompile(`(?:^|[^a-z])(e-?atx|micro[\s-]?atx|m-?atx|mini[\s-]?itx|itx|atx)(?:[^a-z]|$)`)
+	reRefreshRate = regexp.MustCompile(`(\d{2,3})\s*(?:hz|гц)`)
+	reRadeonRX    = regexp.MustCompile(`(?:^|[^a-z])rx\s?-?\d{3,4}|arc\s?[ab]\d{3}`)
+)
+
+// chipsetSockets ona plata chipseti -> socket
+var chipsetSockets = map[string]string{
+	"A320": "AM4", "B350": "AM4", "X370": "AM4", "B450": "AM4", "X470": "AM4", "A520": "AM4", "B550": "AM4", "X570": "AM4",
+	"A620": "AM5", "B650": "AM5", "X670": "AM5", "B840": "AM5", "B850": "AM5", "X870": "AM5",
+	"H310": "LGA1151", "B360": "LGA1151", "B365": "LGA1151", "H370": "LGA1151", "Z370": "LGA1151", "Z390": "LGA1151",
+	"H410": "LGA1200", "B460": "LGA1200", "H470": "LGA1200", "Z490": "LGA1200", "H510": "LGA1200", "B560": "LGA1200", "H570": "LGA1200", "Z590": "LGA1200",
+	"H610": "LGA1700", "B660": "LGA1700", "H670": "LGA1700", "Z690": "LGA1700", "B760": "LGA1700", "Z790": "LGA1700",
+	"H810": "LGA1851", "B860": "LGA1851", "Z890": "LGA1851",
+	"W790": "LGA4677",
+}
+
+// socketMemory faqat bitta xotira turini qo'llaydigan socketlar
+var socketMemory = map[string]string{
+	"AM4": "DDR4", "AM5": "DDR5", "LGA1151": "DDR4", "LGA1200": "DDR4", "LGA1851": "DDR5",
+}
+
+// ExtractAttributes mahsulot nomi, kategoriyasi va xususiyat ustunlaridan texnik atributlarni aniqlash.
+// Hajm kabi umumiy qiymatlar ("16GB") mahsulot turiga qarab talqin qilinadi: videokartada VRAM,
+// diskda hajm, operativ xotirada to'plam hajmi.
+func ExtractAttributes(name, category string, specs map[string]string) HardwareAttributes {
+	text := attributeText(name, specs)
+	cat := strings.ToLower(category)
+	var a HardwareAttributes
+
+	gpu := containsAnyOf(cat, "gpu", "video", "видеокарт") || reRadeonRX.MatchString(text) ||
+		containsAnyOf(text, "rtx", "gtx", "radeon", "geforce", "gddr", "videokarta", "видеокарт")
+	storage := !gpu && (containsAnyOf(cat, "storage", "ssd", "hdd", "disk", "накопител") ||
+		containsAnyOf(text, "ssd", "hdd", "nvme", "hard drive", "жесткий диск", "накопитель"))
+
+	// Ona plata: chipset, undan socket, "m"/"i" qo'shimchasidan form-faktor
+	if !gpu && !storage {
+		if m := reChipset.FindStringSubmatch(text); m != nil {
+			chipset := strings.ToUpper(m[1])
+			if socket, ok := chipsetSockets[chipset]; ok {
+				a.Chipset = chipset + strings.ToUpper(m[2])
+				a.Socket = socket
+				switch m[3] {
+				case "m":
+					a.FormFactor = "mATX"
+				case "i":
+					a.FormFactor = "Mini-ITX"
+				}
+			}
+		}
+	}
+
+	if m := reSocket.FindStringSubmatch(text); m != nil && !gpu {
+		socket := strings.ToUpper(m[1])
+		if m[2] != "" {
+			socket = "LGA" + m[2]
+		}
+		a.Socket = socket
+	}
+	if a.Socket == "" && !gpu {
+		a.Socket = cpuSocket(text)
+	}
+
+	if m := reMemoryType.FindStringSubmatch(text); m != nil {
+		a.MemoryType = "DDR" + m[1]
+	} else if a.Chipset != "" {
+		a.MemoryType = socketMemory[a.Socket]
+	}
+	if m := reMemorySpeed.FindStringSubmatch(text); m != nil && !gpu {
+		speed := atoi(m[1] + m[2])
+		if speed >= 1333 && speed <= 12000 {
+			a.MemorySpeedMHz = speed
+		}
+	}
+
+	switch {
+	case gpu:
+		if m := reGigabytes.FindStringSubmatch(text); m != nil {
+			if gb := atoi(m[1]); gb > 0 && gb <= 48 {
+				a.VRAMGB = gb
+			}
+		}
+	case storage:
+		if m := reStorageSize.FindStringSubmatch(text); m != nil {
+			size, _ := strconv.ParseFloat(strings.Replace(m[1], ",", ".", 1), 64)
+			if m[2] == "tb" || m[2] == "тб" {
+				size *= 1000
+			}
+			a.StorageGB = int(size)
+		}
+		switch {
+		case strings.Contains(text, "nvme"):
+			a.StorageInterface = "NVMe"
+		case strings.Contains(text, "sata"):
+			a.StorageInterface = "SATA"
+		}
+	case a.MemoryType != "" && a.Chipset == "":
+		if m := reMemoryKit.FindStringSubmatch(text); m != nil {
+			a.MemoryGB = atoi(m[1]) * atoi(m[2])
+		} else if m := reGigabytes.FindStringSubmatch(text); m != nil {
+			a.MemoryGB = atoi(m[1])
+		}
+	}
+
+	// Quvvat bloki: watt va 80+ sertifikati
+	psu := containsAnyOf(cat, "psu", "power", "блок питания", "quvvat") ||
+		containsAnyOf(text, "psu", "power supply", "блок питания", "quvvat bloki", "80+", "80 plus")
+	if psu && !gpu {
+		if m := reWatts.FindStringSubmatch(text); m != nil {
+			if w := atoi(m[1]); w >= 200 && w <= 3000 {
+				a.PSUWatts = w
+			}
+		}
+		if m := reEfficiency.FindStringSubmatch(text); m != nil {
+			a.PSUEfficiency = "80+ " + strings.ToUpper(m[1][:1]) + m[1][1:]
+		} else if containsAnyOf(text, "80+", "80 plus") {
+			a.PSUEfficiency = "80+"
+		}
+	}
+
+	if !psu {
+		if m := reFormFactor.FindStringSubmatch(text); m != nil {
+			a.FormFactor = normalizeFormFactor(m[1])
+		}
+	}
+
+	if m := reRefreshRate.FindStringSubmatch(text); m != nil {
+		if hz := atoi(m[1]); hz >= 50 && hz <= 600 {
+			a.RefreshRateHz = hz
+		}
+	}
+
+	return a
+}
+
+// FillAttributes atributi aniqlanmagan mahsulotlar uchun atributlarni hisoblash
+func FillAttributes(products []Product) {
+	for i := range products {
+		if products[i].Attributes.IsZero() {
+			products[i].Attributes = ExtractAttributes(products[i].Name, products[i].Category, products[i].Specs)
+		}
+	}
+}
+
+// attributeText nom va xususiyatlar ("Socket AM5") bitta kichik harfli matnda
+func attributeText(name string, specs map[string]string) string {
+	keys := make([]string, 0, len(specs))
+	for key := range specs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := []string{name}
+	for _, key := range keys {
+		parts = append(parts, key+" "+specs[key])
+	}
+	return strings.ToLower(strings.Join(parts, " | "))
+}
+
+// cpuSocket protsessor modelidan socket: Ryzen 5000 -> AM4, Ryzen 7000+ -> AM5, Core i5-12400 -> LGA1700
+func cpuSocket(text string) string {
+	if m := reRyzen.FindStringSubmatch(text); m != nil {
+		switch m[1] {
+		case "1", "2", "3", "4", "5":
+			return "AM4"
+		case "7", "8", "9":
+			return "AM5"
+		}
+	}
+	if reCoreUltra.MatchString(text) {
+		return "LGA1851"
+	}
+	if m := reIntelCore.FindStringSubmatch(text); m != nil {
+		gen := atoi(m[1][:1])
+		if len(m[1]) == 5 {
+			gen = atoi(m[1][:2])
+		}
+		switch {
+		case gen >= 12 && gen <= 14:
+			return "LGA1700"
+		case gen == 10 || gen == 11:
+			return "LGA1200"
+		case gen >= 6 && gen <= 9:
+			return "LGA1151"
+		}
+	}
+	return ""
+}
+
+func normalizeFormFactor(s string) string {
+	s = strings.NewReplacer(" ", "", "-", "").Replace(s)
+	switch s {
+	case "eatx":
+		return "E-ATX"
+	case "microatx", "matx":
+		return "mATX"
+	case "miniitx", "itx":
+		return "Mini-ITX"
+	default:
+		return "ATX"
+	}
+}
+
+func containsAnyOf(s string, keywords ...string) bool {
+	for _, keyword := range keywords {
+		if strings.Contains(s, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
@@ -0,0 +1,258 @@
+package entity
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Mahsulot maydonlari (import profili va ustunlarni aniqlashda)
+const (
+	FieldName        = "name"
+	FieldPrice       = "price"
+	FieldCategory    = "category"
+	FieldDescription = "description"
+	FieldStock       = "stock"
+	FieldSKU         = "sku"
+	FieldCurrency    = "currency"
+	FieldImage       = "image"
+)
+
+// ProductFields import ustunlari bog'lanadigan maydonlar
+var ProductFields = []string{FieldName, FieldPrice, FieldCategory, FieldDescription, FieldStock, FieldSKU, FieldCurrency, FieldImage}
+
+// Profil ustuni maqsadlari: maydon nomi, "spec:Kalit" yoki "skip"
+const (
+	ColumnSpecPrefix = "spec:"
+	ColumnSkip       = "skip"
+)
+
+// ImportProfileNone izohdagi "profile: none" - profil ishlatilmaydi (avtomatik aniqlash ham)
+const ImportProfileNone = "none"
+
+// ImportProfile yetkazib beruvchi fayli uchun ustunlar xaritasi
+type ImportProfile struct {
+	Name      string
+	Columns   map[string]string // NormalizeHeader(sarlavha) -> maydon, "spec:Kalit" yoki "skip"
+	Currency  Currency          // narx valyutasi (katakda, ustunda va izohda bo'lmasa)
+	HeaderRow int               // sarlavha qatori (1 dan; 0 - birinchi to'la qator)
+	DataRow   int               // birinchi mahsulot qatori (1 dan; 0 - sarlavhadan keyingi qator)
+	UpdatedBy int64
+	UpdatedAt time.Time
+}
+
+// NormalizeHeader sarlavhani solishtirish uchun: kichik harf, ortiqcha bo'shliqlarsiz
+func NormalizeHeader(header string) string {
+	return strings.Join(strings.Fields(strings.ToLower(header)), " ")
+}
+
+// ColumnTarget sarlavha qaysi maydonga bog'langan (profilda bo'lmasa false)
+func (p *ImportProfile) ColumnTarget(header string) (string, bool) {
+	target, ok := p.Columns[NormalizeHeader(header)]
+	return target, ok
+}
+
+// FieldHeader maydonga bog'langan sarlavha (bo'lmasa bo'sh)
+func (p *ImportProfile) FieldHeader(field string) string {
+	for header, target := range p.Columns {
+		if target == field {
+			return header
+		}
+	}
+	return ""
+}
+
+// MatchHeader qator shu profil sarlavhasiga mosmi. Nom va narx sarlavhalari (profilda
+// bo'lsa) albatta bo'lishi, qolganlarning kamida yarmi topilishi kerak. Ikkinchi qiymat -
+// topilgan sarlavhalar soni (bir nechta profil mos kelsa eng ko'pi tanlanadi).
+func (p *ImportProfile) MatchHeader(row []string) (bool, int) {
+	present := make(map[string]bool, len(row))
+	for _, cell := range row {
+		if header := NormalizeHeader(cell); header != "" {
+			present[header] = true
+		}
+	}
+
+	found := 0
+	for header, target := range p.Columns {
+		if present[header] {
+			found++
+		} else if target == FieldName || target == FieldPrice {
+			return false, 0
+		}
+	}
+	if found == 0 || found*2 < len(p.Columns) {
+		return false, found
+	}
+	return true, found
+}
+
+// Validate profil to'g'riligini tekshirish
+func (p *ImportProfile) Validate() error {
+	if strings.TrimSpace(p.Name) == "" {
+		return fmt.Errorf("profile name is empty")
+	}
+	if strings.EqualFold(p.Name, ImportProfileNone) {
+		return fmt.Errorf("profile name %q is reserved", p.Name)
+	}
+	if len(p.Columns) == 0 {
+		return fmt.Errorf("profile has no columns")
+	}
+
+	used := make(map[string]string)
+	for header, target := range p.Columns {
+		if err := validateColumnTarget(target); err != nil {
+			return fmt.Errorf("column %q: %w", header, err)
+		}
+		if isProductField(target) {
+			if other, ok := used[target]; ok {
+				return fmt.Errorf("columns %q and %q both map to %s", other, header, target)
+			}
+			used[target] = header
+		}
+	}
+	if _, ok := used[FieldName]; !ok {
+		return fmt.Errorf("profile has no %s column", FieldName)
+	}
+	if _, ok := used[FieldPrice]; !ok {
+		return fmt.Errorf("profile has no %s column", FieldPrice)
+	}
+	if p.HeaderRow < 0 || p.DataRow < 0 || (p.DataRow > 0 && p.DataRow <= p.HeaderRow) {
+		return fmt.Errorf("invalid header/data rows: %d/%d", p.HeaderRow, p.DataRow)
+	}
+	return nil
+}
+
+func validateColumnTarget(target string) error {
+	switch {
+	case isProductField(target), target == ColumnSkip:
+		return nil
+	case strings.HasPrefix(target, ColumnSpecPrefix) && strings.TrimSpace(strings.TrimPrefix(target, ColumnSpecPrefix)) != "":
+		return nil
+	}
+	return fmt.Errorf("unknown target %q (use %s, %s<Key> or %s)", target, strings.Join(ProductFields, ", "), ColumnSpecPrefix, ColumnSkip)
+}
+
+func isProductField(target string) bool {
+	for _, field := range ProductFields {
+		if target == field {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseImportProfile profil matnini o'qish. Har qatorda "Sarlavha = maqsad" yoki sozlama:
+//
+//	Наименование = name
+//	Цена (у.е.) = price
+//	Сокет = spec:Socket
+//	Примечание = skip
+//	currency: USD
+//	header: 3
+//	data: 4
+func ParseImportProfile(name, text string) (ImportProfile, error) {
+	profile := ImportProfile{Name: strings.TrimSpace(name), Columns: make(map[string]string)}
+
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if header, target, ok := strings.Cut(line, "="); ok {
+			header = NormalizeHeader(header)
+			target = strings.TrimSpace(target)
+			if header == "" {
+				return profile, fmt.Errorf("empty header in line %q", line)
+			}
+			if !strings.HasPrefix(strings.ToLower(target), ColumnSpecPrefix) {
+				target = strings.ToLower(target)
+			} else {
+				target = ColumnSpecPrefix + strings.TrimSpace(target[len(ColumnSpecPrefix):])
+			}
+			profile.Columns[header] = target
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return profile, fmt.Errorf("invalid line %q (expected \"Header = field\" or \"key: value\")", line)
+		}
+		value = strings.TrimSpace(value)
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "currency", "valyuta":
+			currency, ok := ParseCurrency(value)
+			if !ok {
+				return profile, fmt.Errorf("unknown currency %q", value)
+			}
+			profile.Currency = currency
+		case "header", "sarlavha":
+			row, err := strconv.Atoi(value)
+			if err != nil {
+				return profile, fmt.Errorf("invalid header row %q", value)
+			}
+			profile.HeaderRow = row
+		case "data", "start":
+			row, err := strconv.Atoi(value)
+			if err != nil {
+				return profile, fmt.Errorf("invalid data row %q", value)
+			}
+			profile.DataRow = row
+		default:
+			return profile, fmt.Errorf("unknown setting %q", key)
+		}
+	}
+
+	return profile, profile.Validate()
+}
+
+// Format profilni ParseImportProfile o'qiydigan matnga yozish (maydonlar birinchi)
+func (p *ImportProfile) Format() string {
+	headers := make([]string, 0, len(p.Columns))
+	for header := range p.Columns {
+		headers = append(headers, header)
+	}
+	rank := func(target string) int {
+		for i, field := range ProductFields {
+			if target == field {
+				return i
+			}
+		}
+		if target == ColumnSkip {
+			return len(ProductFields) + 1
+		}
+		return len(ProductFields)
+	}
+	sort.Slice(headers, func(i, j int) bool {
+		ri, rj := rank(p.Columns[headers[i]]), rank(p.Columns[headers[j]])
+		if ri != rj {
+			return ri < rj
+		}
+		return headers[i] < headers[j]
+	})
+
+	var lines []string
+	for _, header := range headers {
+		lines = append(lines, fmt.Sprintf("%s = %s", header, p.Columns[header]))
+	}
+	if p.Currency != "" {
+		lines = append(lines, fmt.Sprintf("currency: %s", p.Currency))
+	}
+	if p.HeaderRow > 0 {
+		lines = append(lines, fmt.Sprintf("header: %d", p.HeaderRow))
+	}
+	if p.DataRow > 0 {
+		lines = append(lines, fmt.Sprintf("data: %d", p.DataRow))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ProfileMatch sheet ga qaysi import profili qo'llangani
+type ProfileMatch struct {
+	Sheet    string
+	Profile  string
+	Detected bool // sarlavhadan avtomatik aniqlandi (false - izohda ko'rsatilgan)
+}
@@ -0,0 +1,150 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrUnsupportedFormat fayl formati yoki uning varianti o'qilmaydi (Excel 95, parolli fayl,
+// .xls nomli HTML jadval va h.k.). Parser xatolari shu xatoni o'rab, sababini qo'shadi.
+var ErrUnsupportedFormat = errors.New("unsupported file format")
+
+// ImportIssueKind import paytida qatorda topilgan muammo turi
+type ImportIssueKind string
+
+const (
+	ImportIssueSkipped         ImportIssueKind = "skipped"          // qator o'tkazib yuborildi
+	ImportIssueZeroPrice       ImportIssueKind = "zero_price"       // narx nol, qator o'tkazib yuborildi
+	ImportIssueDuplicate       ImportIssueKind = "duplicate"        // nom takrorlangan (qator qabul qilinadi)
+	ImportIssueSuspiciousPrice ImportIssueKind = "suspicious_price" // narx boshqalardan keskin farq qiladi (qabul qilinadi)
+	ImportIssuePriceRange      ImportIssueKind = "price_range"      // narx oralig'i, pastki chegara olindi (qabul qilinadi)
+	ImportIssueImage           ImportIssueKind = "image"            // rasm ustunidagi qiymat havola emas (qator rasmsiz qabul qilinadi)
+)
+
+// ImportIssue bitta qator bo'yicha muammo
+type ImportIssue struct {
+	Kind   ImportIssueKind
+	Sheet  string // CSV/JSON uchun bo'sh
+	Row    int    // 1 dan boshlanadi (JSON da element tartib raqami)
+	Name   string
+	Value  string // muammoli qiymat (masalan, narx matni)
+	Reason string
+}
+
+// Location "Sheet!12" yoki "12" ko'rinishidagi joy
+func (i ImportIssue) Location() string {
+	if i.Sheet == "" {
+		return fmt.Sprintf("%d", i.Row)
+	}
+	return fmt.Sprintf("%s!%d", i.Sheet, i.Row)
+}
+
+// ColumnGuess header bo'yicha emas, taxmin bilan tanlangan ustun
+type ColumnGuess struct {
+	Sheet  string
+	Field  string // "name" yoki "price"
+	Column int    // 0 dan boshlanadi
+	Reason string
+}
+
+// ColumnName ustunning Excel dagi nomi (A, B, ..., AA)
+func (g ColumnGuess) ColumnName() string {
+	name := ""
+	for n := g.Column + 1; n > 0; n = (n - 1) / 26 {
+		name = string(rune('A'+(n-1)%26)) + name
+	}
+	return name
+}
+
+// NumberFormat sheet (CSV/JSON da butun fayl) narxlari qanday o'qilgani
+type NumberFormat struct {
+	Sheet     string
+	Locale    NumberLocale // NumberLocaleEN, NumberLocaleEU yoki aniqlanmasa NumberLocaleAuto
+	Detected  bool         // fayldagi qiymatlardan aniqlandi (false - admin izohi yoki default)
+	Ambiguous int          // "1,299" kabi ikki xil o'qilishi mumkin bo'lgan narxlar
+	Scaled    int          // "850k", "1.2 mln" qisqartmali narxlar
+	Ranges    int          // "120-130$" oraliqlari
+	Example   string       // ajratuvchili birinchi narx: "1.299,00 → 1299"
+}
+
+// SectionLevel bo'lim sarlavhasi darajasi
+type SectionLevel string
+
+const (
+	SectionCategory SectionLevel = "category" // pastdagi qatorlar kategoriyasi
+	SectionGroup    SectionLevel = "group"    // kategoriya ichidagi guruh (masalan, brend) - xususiyat sifatida yoziladi
+)
+
+// ImportSection narx varag'idagi bo'lim sarlavhasi qatori (birlashtirilgan katak,
+// yagona to'la katak yoki qalin shrift): keyingi sarlavhagacha qatorlarga qo'llanadi
+type ImportSection struct {
+	Sheet    string
+	Row      int // 1 dan boshlanadi
+	Title    string
+	Level    SectionLevel
+	Products int // shu bo'limga tushgan mahsulotlar
+}
+
+// Location "Sheet!12" yoki "12" ko'rinishidagi joy
+func (s ImportSection) Location() string {
+	if s.Sheet == "" {
+		return fmt.Sprintf("%d", s.Row)
+	}
+	return fmt.Sprintf("%s!%d", s.Sheet, s.Row)
+}
+
+// ImportProgress katalog faylini o'qish jarayoni
+type ImportProgress struct {
+	Sheet    string // joriy sheet
+	Rows     int    // shu paytgacha o'qilgan qatorlar (barcha sheetlar)
+	Products int    // shu paytgacha qabul qilingan mahsulotlar
+}
+
+// ImportReport katalog faylini tekshirish natijasi (katalog hali yangilanmagan)
+type ImportReport struct {
+	Source    string
+	Format    string
+	Sheets    []string // o'qilgan sheetlar
+	TotalRows int      // ko'rib chiqilgan bo'sh bo'lmagan qatorlar
+	Products  []Product
+	Issues    []ImportIssue
+	Guesses   []ColumnGuess
+	Numbers   []NumberFormat // har bir sheet uchun raqam formati
+	Profiles  []ProfileMatch // import profili qo'llangan sheetlar
+	Sections  []ImportSection
+	// UnstyledSheets qatorma-qator o'qilgan katta sheetlar: ularda bo'lim sarlavhalari faqat
+	// joylashuvdan aniqlandi (birlashtirilgan kataklar va qalin shrift tekshirilmadi)
+	UnstyledSheets []string
+	Images         []ProductImage  `json:"-"` // faylga joylangan rasmlar (ApplyCatalog saqlaydi, mahsulotlar Image ID orqali bog'lanadi)
+	Shortages      []StockShortage // ombordagi son ochiq bronlardan kam mahsulotlar (PreviewCatalog to'ldiradi)
+	CreatedAt      time.Time
+}
+
+// CountIssues berilgan turdagi muammolar soni
+func (r *ImportReport) CountIssues(kind ImportIssueKind) int {
+	count := 0
+	for _, issue := range r.Issues {
+		if issue.Kind == kind {
+			count++
+		}
+	}
+	return count
+}
+
+// Rejected katalogga kirmaydigan qatorlar soni (o'tkazib yuborilgan va nol narxli)
+func (r *ImportReport) Rejected() int {
+	return r.CountIssues(ImportIssueSkipped) + r.CountIssues(ImportIssueZeroPrice)
+}
+
+// ImportResult tasdiqlangan importdan keyingi katalog holati
+type ImportResult struct {
+	Mode         ImportMode
+	Version      int // saqlangan katalog versiyasi
+	Total        int // katalogdagi mahsulotlar (to'xtatilganlar bilan)
+	Added        int
+	Updated      int
+	Unchanged    int
+	Removed      int
+	Discontinued int
+}
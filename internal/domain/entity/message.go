@@ -12,6 +12,12 @@ type Message struct {
 	Timestamp time.Time
 }
 
+// MessageMatch qidiruv natijasi (xabar va topilgan joy atrofidagi parcha)
+type MessageMatch struct {
+	Message Message
+	Snippet string // moslik «» ichida belgilanadi
+}
+
 // ChatContext suhbat kontekstini saqlash uchun
 type ChatContext struct {
 	UserID   int64
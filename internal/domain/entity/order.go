@@ -0,0 +1,80 @@
+package entity
+
+import "time"
+
+// OrderStatus buyurtma holati
+type OrderStatus string
+
+const (
+	OrderStatusNew       OrderStatus = "new"       // Yangi, admin ko'rmagan
+	OrderStatusConfirmed OrderStatus = "confirmed" // Admin tasdiqladi
+	OrderStatusReady     OrderStatus = "ready"     // Olib ketishga tayyor
+	OrderStatusShipped   OrderStatus = "shipped"   // Yetkazib berishga chiqdi
+	OrderStatusDelivered OrderStatus = "delivered" // Mijozga topshirildi
+	OrderStatusCancelled OrderStatus = "cancelled" // Bekor qilindi
+)
+
+// orderTransitions ruxsat etilgan holat o'tishlari
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderStatusNew:       {OrderStatusConfirmed, OrderStatusCancelled},
+	OrderStatusConfirmed: {OrderStatusReady, OrderStatusShipped, OrderStatusCancelled},
+	OrderStatusReady:     {OrderStatusDelivered, OrderStatusCancelled},
+	OrderStatusShipped:   {OrderStatusDelivered, OrderStatusCancelled},
+}
+
+// NextStatuses joriy holatdan o'tish mumkin bo'lgan holatlar
+func (s OrderStatus) NextStatuses() []OrderStatus {
+	return orderTransitions[s]
+}
+
+// CanTransitionTo holatni next ga o'zgartirish mumkinligini tekshirish
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	for _, allowed := range orderTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsFinal yakuniy holat (delivered yoki cancelled)
+func (s OrderStatus) IsFinal() bool {
+	return s == OrderStatusDelivered || s == OrderStatusCancelled
+}
+
+// DeliveryMethod yetkazish usuli
+type DeliveryMethod string
+
+const (
+	DeliveryPickup  DeliveryMethod = "pickup"  // Olib ketish
+	DeliveryCourier DeliveryMethod = "courier" // Dostavka
+)
+
+// OrderItem buyurtmadagi katalog mahsuloti
+type OrderItem struct {
+	ProductID string
+	Name      string
+	Price     float64
+	Currency  Currency // bo'sh bo'lsa DefaultCurrency
+	Quantity  int
+}
+
+// Order mijoz buyurtmasi
+type Order struct {
+	ID             string
+	UserID         int64
+	ChatID         int64
+	Username       string
+	CustomerName   string
+	Phone          string
+	Location       string
+	DeliveryMethod DeliveryMethod
+	Note           string
+	Summary        string // So'rov yoki konfiguratsiya matni
+	Items          []OrderItem
+	Total          float64
+	Currency       Currency // Total valyutasi (bo'sh bo'lsa DefaultCurrency)
+	Status         OrderStatus
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
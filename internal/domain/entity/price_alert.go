@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+// PricePoint mahsulot narxi katalogning bir versiyasida. Tarix yangi mahsulotlar va
+// narxi (yoki valyutasi) o'zgarganlar uchun yoziladi.
+type PricePoint struct {
+	Key        string // PriceKey: replace importda ID o'zgarsa ham tarix bog'lanib qoladi
+	ProductID  string
+	Name       string
+	Price      float64
+	Currency   Currency
+	Version    int // katalog versiyasi (0 - versiyasiz o'zgarish)
+	RecordedAt time.Time
+}
+
+// PriceKey mahsulotni katalog yuklashlari orasida tanish kaliti: artikul, u bo'lmasa normallashtirilgan nom
+func PriceKey(p Product) string {
+	if sku := NormalizeSKU(p.SKU); sku != "" {
+		return "sku:" + sku
+	}
+	return "name:" + strings.ToLower(strings.Join(strings.Fields(p.Name), " "))
+}
+
+// PriceAlert mijozning "narx tushsa xabar ber" obunasi: mahsulot narxi Threshold dan
+// pastga tushsa xabar beriladi va chegara yangi narxga tushiriladi (keyingi arzonlashish ham kuzatiladi)
+type PriceAlert struct {
+	ID          string
+	UserID      int64
+	ChatID      int64
+	ProductID   string
+	Key         string // PriceKey
+	ProductName string
+	Threshold   float64
+	Currency    Currency // Threshold valyutasi (obuna paytidagi narx valyutasi)
+	CreatedAt   time.Time
+	NotifiedAt  time.Time // oxirgi xabar (bo'sh - hali xabar berilmagan)
+}
+
+// PriceDrop obunachiga yuboriladigan narx tushishi
+type PriceDrop struct {
+	Alert    PriceAlert // chegara - oldingi narx
+	Product  Product
+	NewPrice float64 // Alert.Currency da
+}
@@ -1,18 +1,44 @@
 package entity
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // Product mahsulot entity
 type Product struct {
-	ID          string
-	Name        string
-	Category    string
-	Price       float64
-	Description string
-	Stock       int
-	Specs       map[string]string // Texnik xususiyatlar
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID           string
+	SKU          string // Artikul / mahsulot kodi (bo'sh bo'lishi mumkin)
+	Name         string
+	Category     string
+	CategoryAuto bool // Kategoriya faylda yo'q edi, taksonomiya qoidalari bilan aniqlandi
+	Price        float64
+	Currency     Currency // Narx valyutasi (bo'sh bo'lsa DefaultCurrency)
+	Description  string
+	Stock        int
+	StockTracked bool               // Stock fayldan olingan (ombor ustuni to'ldirilgan); false bo'lsa ombor hisobi yuritilmaydi
+	Specs        map[string]string  // Texnik xususiyatlar
+	Attributes   HardwareAttributes // Nom va xususiyatlardan aniqlangan atributlar (ExtractAttributes)
+	Discontinued bool               // Sotuvdan olingan (merge importda faylda yo'q edi)
+	Image        string             // Rasm: havola (http/https) yoki ProductImage ID si; bo'sh bo'lsa rasm yo'q
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// NormalizeSKU artikulni solishtirish uchun bir xil ko'rinishga keltirish
+func NormalizeSKU(sku string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(sku), ""))
+}
+
+// ActiveProducts sotuvdagi (to'xtatilmagan) mahsulotlar
+func ActiveProducts(products []Product) []Product {
+	active := make([]Product, 0, len(products))
+	for _, p := range products {
+		if !p.Discontinued {
+			active = append(active, p)
+		}
+	}
+	return active
 }
 
 // ProductCatalog mahsulotlar katalogi
@@ -21,3 +47,103 @@ type ProductCatalog struct {
 	UpdatedAt time.Time
 	Source    string // Excel fayl nomi
 }
+
+// ImportMode katalogni yangilash usuli
+type ImportMode string
+
+const (
+	ImportModeReplace ImportMode = "replace" // katalog to'liq almashtiriladi (default)
+	ImportModeMerge   ImportMode = "merge"   // SKU yoki nom bo'yicha birlashtirish, ID lar saqlanadi
+)
+
+// MissingPolicy merge rejimida faylda bo'lmagan mahsulotlar bilan nima qilinadi
+type MissingPolicy string
+
+const (
+	MissingDelete      MissingPolicy = "delete"      // o'chiriladi (default)
+	MissingDiscontinue MissingPolicy = "discontinue" // sotuvdan olingan deb belgilanadi
+	MissingKeep        MissingPolicy = "keep"        // o'zgarishsiz qoladi
+)
+
+// ImportOptions katalog importi sozlamalari (admin fayl izohida beradi)
+type ImportOptions struct {
+	ExcludeSheets []string      // o'qilmaydigan sheet nomlari (katta-kichik harf farqsiz)
+	Mode          ImportMode    // bo'sh bo'lsa ImportModeReplace
+	Missing       MissingPolicy // faqat merge rejimida; bo'sh bo'lsa MissingDelete
+	Currency      Currency      // katak va ustun sarlavhasida valyuta bo'lmasa; bo'sh bo'lsa DefaultCurrency
+	NumberLocale  NumberLocale  // narxlardagi ajratuvchilar; bo'sh bo'lsa fayldan aniqlanadi
+	Profile       string        // import profili nomi; bo'sh bo'lsa sarlavhadan aniqlanadi, ImportProfileNone - ishlatilmaydi
+
+	// Progress katta fayl o'qilayotganda vaqti-vaqti bilan chaqiriladi (nil bo'lsa chaqirilmaydi)
+	Progress func(ImportProgress) `json:"-"`
+}
+
+// NumberLocale narxlardagi o'nlik va minglik ajratuvchilar
+type NumberLocale string
+
+const (
+	NumberLocaleAuto NumberLocale = ""   // fayldagi qiymatlardan aniqlanadi
+	NumberLocaleEN   NumberLocale = "en" // 1,299.50 - nuqta o'nlik, vergul minglik
+	NumberLocaleEU   NumberLocale = "eu" // 1.299,50 - vergul o'nlik, nuqta minglik
+)
+
+// ParseNumberLocale admin yozgan qiymatni aniqlash ("eu", "comma", "vergul", "en", "dot", "auto"...)
+func ParseNumberLocale(s string) (NumberLocale, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "auto", "avto":
+		return NumberLocaleAuto, true
+	case "en", "us", "uk", "dot", ".", "nuqta", "1,299.50":
+		return NumberLocaleEN, true
+	case "eu", "ru", "uz", "de", "comma", ",", "vergul", "1.299,50", "1 299,50":
+		return NumberLocaleEU, true
+	}
+	return "", false
+}
+
+// Describe ajratuvchilar izohi: "1,299.50 (nuqta - o'nlik)"
+func (l NumberLocale) Describe() string {
+	switch l {
+	case NumberLocaleEU:
+		return "1.299,50 (vergul - o'nlik)"
+	case NumberLocaleEN:
+		return "1,299.50 (nuqta - o'nlik)"
+	default:
+		return "avtomatik (1,299 va 1.299 - minglik)"
+	}
+}
+
+// IsSheetExcluded sheet chiqarib tashlanganmi
+func (o ImportOptions) IsSheetExcluded(sheet string) bool {
+	sheet = strings.TrimSpace(sheet)
+	for _, name := range o.ExcludeSheets {
+		if strings.EqualFold(strings.TrimSpace(name), sheet) {
+			return true
+		}
+	}
+	return false
+}
+
+// CatalogVersion yuklangan katalogning raqamlangan nusxasi
+type CatalogVersion struct {
+	Version      int
+	Source       string // Excel fayl nomi
+	UploadedBy   int64  // Yuklagan admin ID si
+	CreatedAt    time.Time
+	ProductCount int
+	Products     []Product // Ro'yxat ko'rinishida bo'sh bo'lishi mumkin
+}
+
+// ProductChange bir mahsulotning ikki versiya orasidagi holati
+type ProductChange struct {
+	Before Product
+	After  Product
+}
+
+// CatalogDiff ikki katalog versiyasi orasidagi farq
+type CatalogDiff struct {
+	From    int
+	To      int
+	Added   []Product
+	Removed []Product
+	Changed []ProductChange
+}
@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ProductImage katalog fayliga joylangan mahsulot rasmi. ID - tarkibning sha256 xeshi,
+// shuning uchun bir xil rasm (masalan, bir nechta mahsulotda yoki qayta yuklashda) bir marta saqlanadi.
+// Product.Image rasm ID si yoki havola (IsImageURL) bo'ladi.
+type ProductImage struct {
+	ID        string
+	Data      []byte
+	Extension string // ".png", ".jpg"
+	FileID    string // Telegram ga birinchi yuborilgandan keyingi file_id (qayta yuklamaslik uchun)
+	CreatedAt time.Time
+}
+
+// NewProductImage rasm tarkibidan ProductImage yaratish
+func NewProductImage(data []byte, extension string) ProductImage {
+	sum := sha256.Sum256(data)
+	return ProductImage{
+		ID:        hex.EncodeToString(sum[:]),
+		Data:      data,
+		Extension: strings.ToLower(extension),
+		CreatedAt: time.Now(),
+	}
+}
+
+// IsPhotoExtension Telegram rasm sifatida ko'rsatadigan format (EMF/WMF va h.k. emas)
+func IsPhotoExtension(extension string) bool {
+	switch strings.ToLower(extension) {
+	case ".png", ".jpg", ".jpeg", ".gif", ".webp":
+		return true
+	}
+	return false
+}
+
+// IsImageURL qiymat rasm havolasimi (http/https) - Product.Image da rasm ID si bilan farqlash uchun
+func IsImageURL(value string) bool {
+	u, err := url.Parse(strings.TrimSpace(value))
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
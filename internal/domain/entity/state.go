@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+// StateEntry bot dialoglari holati (sessiyalar, guruh threadlari va h.k.).
+// Value JSON ko'rinishida saqlanadi, shuning uchun istalgan store da bir xil ishlaydi.
+type StateEntry struct {
+	Namespace string // masalan: "order_session", "group_thread"
+	Key       string // odatda userID yoki messageID
+	UserID    int64  // holat egasi (0 bo'lishi mumkin)
+	Value     []byte
+	UpdatedAt time.Time
+}
@@ -0,0 +1,55 @@
+package entity
+
+import "time"
+
+// StockReservation tasdiqlangan buyurtma uchun band qilingan mahsulotlar. Bron buyurtma bekor
+// qilinganda o'chiriladi, topshirilganda ombordagi sondan ayiriladi.
+type StockReservation struct {
+	OrderID   string
+	ProductID string
+	Key       string // PriceKey: replace importda ID o'zgarsa ham bron mahsulotga bog'lanib qoladi
+	Name      string
+	Quantity  int
+	CreatedAt time.Time
+}
+
+// StockShortage ombordagi son ochiq bronlar (yoki buyurtma) uchun yetmaydigan mahsulot
+type StockShortage struct {
+	Name     string
+	Stock    int // ombordagi son (bronlardan oldin)
+	Reserved int // boshqa buyurtmalar bron qilgani
+	Required int // tasdiqlanayotgan buyurtmaga kerak (import tekshiruvida 0)
+}
+
+// ReservedStock ochiq bronlar mahsulot kaliti (PriceKey) bo'yicha
+func ReservedStock(reservations []StockReservation) map[string]int {
+	reserved := make(map[string]int, len(reservations))
+	for _, r := range reservations {
+		reserved[r.Key] += r.Quantity
+	}
+	return reserved
+}
+
+// AvailableStock bronlardan keyin qolgan son (ombor hisobi yuritilmasa ok false)
+func AvailableStock(p Product, reserved map[string]int) (int, bool) {
+	if !p.StockTracked {
+		return 0, false
+	}
+	return p.Stock - reserved[PriceKey(p)], true
+}
+
+// InStockProducts mijozga taklif qilinadigan mahsulotlar: ombor hisobi yuritilmaydiganlar va
+// bronlardan keyin soni qolganlar. Qaytarilgan mahsulotlarda Stock bronlardan keyingi son.
+func InStockProducts(products []Product, reserved map[string]int) []Product {
+	available := make([]Product, 0, len(products))
+	for _, p := range products {
+		if stock, tracked := AvailableStock(p, reserved); tracked {
+			if stock <= 0 {
+				continue
+			}
+			p.Stock = stock
+		}
+		available = append(available, p)
+	}
+	return available
+}
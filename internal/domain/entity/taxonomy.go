@@ -0,0 +1,287 @@
+package entity
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// DefaultCategory hech bir qoidaga tushmagan mahsulotlar kategoriyasi
+const DefaultCategory = "Boshqa"
+
+// CategoryRule kategoriya aniqlash qoidasi. Naqsh turlari:
+//
+//	rtx, power supply  - butun so'z yoki ibora ("arc" so'zi "search" ichida topilmaydi)
+//	re:\brx\s?\d{3,4}  - regex (kichik harfli nomga qo'llanadi)
+//	attr:vram          - texnik atribut aniqlangan (socket, chipset, memory, vram, storage, psu, refresh)
+type CategoryRule struct {
+	ID       int
+	Category string   // kanonik nom ("GPU")
+	Patterns []string // biri mos kelsa qoida ishlaydi
+	Exclude  []string // biri mos kelsa qoida ishlamaydi (naqshlar bilan bir xil sintaksis)
+	Priority int      // kattasi birinchi tekshiriladi
+}
+
+// CategoryName kategoriyaning o'zbekcha va ruscha nomi
+type CategoryName struct {
+	Category string
+	Uz       string
+	Ru       string
+}
+
+// Label "GPU (Videokarta / Видеокарта)" ko'rinishidagi nom
+func (n CategoryName) Label() string {
+	var names []string
+	for _, name := range []string{n.Uz, n.Ru} {
+		if name != "" && !strings.EqualFold(name, n.Category) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return n.Category
+	}
+	return fmt.Sprintf("%s (%s)", n.Category, strings.Join(names, " / "))
+}
+
+// attributeConditions "attr:" naqshlari
+var attributeConditions = map[string]func(HardwareAttributes) bool{
+	"socket":  func(a HardwareAttributes) bool { return a.Socket != "" },
+	"chipset": func(a HardwareAttributes) bool { return a.Chipset != "" },
+	"memory":  func(a HardwareAttributes) bool { return a.MemoryGB > 0 },
+	"vram":    func(a HardwareAttributes) bool { return a.VRAMGB > 0 },
+	"storage": func(a HardwareAttributes) bool { return a.StorageGB > 0 || a.StorageInterface != "" },
+	"psu":     func(a HardwareAttributes) bool { return a.PSUWatts > 0 },
+	"refresh": func(a HardwareAttributes) bool { return a.RefreshRateHz > 0 },
+}
+
+// patternMatcher tayyorlangan naqsh
+type patternMatcher struct {
+	word string
+	re   *regexp.Regexp
+	attr func(HardwareAttributes) bool
+}
+
+func (m patternMatcher) match(words, lower string, attrs HardwareAttributes) bool {
+	switch {
+	case m.re != nil:
+		return m.re.MatchString(lower)
+	case m.attr != nil:
+		return m.attr(attrs)
+	default:
+		return strings.Contains(words, m.word)
+	}
+}
+
+// compilePattern naqshni tekshirib tayyorlash
+func compilePattern(pattern string) (patternMatcher, error) {
+	pattern = strings.TrimSpace(pattern)
+	switch {
+	case strings.HasPrefix(pattern, "re:"):
+		re, err := regexp.Compile(strings.TrimPrefix(pattern, "re:"))
+		if err != nil {
+			return patternMatcher{}, fmt.Errorf("invalid regex %q: %w", pattern, err)
+		}
+		return patternMatcher{re: re}, nil
+	case strings.HasPrefix(pattern, "attr:"):
+		cond, ok := attributeConditions[strings.ToLower(strings.TrimPrefix(pattern, "attr:"))]
+		if !ok {
+			return patternMatcher{}, fmt.Errorf("unknown attribute condition: %q", pattern)
+		}
+		return patternMatcher{attr: cond}, nil
+	}
+
+	word := categoryWords(pattern)
+	if strings.TrimSpace(word) == "" {
+		return patternMatcher{}, fmt.Errorf("empty pattern")
+	}
+	return patternMatcher{word: word}, nil
+}
+
+// categoryWords matnni so'zlarga ajratib " so'z1 so'z2 " ko'rinishiga keltirish
+func categoryWords(s string) string {
+	fields := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return " " + strings.Join(fields, " ") + " "
+}
+
+// compiledRule tayyorlangan qoida
+type compiledRule struct {
+	rule     CategoryRule
+	patterns []patternMatcher
+	exclude  []patternMatcher
+}
+
+// Taxonomy kategoriya qoidalari va nomlari (NewTaxonomy bilan yaratiladi)
+type Taxonomy struct {
+	Rules []CategoryRule // tekshirish tartibida
+	Names []CategoryName
+	rules []compiledRule
+}
+
+// NewTaxonomy qoidalarni tekshirib, ustuvorlik (keyin ID) bo'yicha tartiblash
+func NewTaxonomy(rules []CategoryRule, names []CategoryName) (*Taxonomy, error) {
+	sorted := append([]CategoryRule(nil), rules...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Priority != sorted[j].Priority {
+			return sorted[i].Priority > sorted[j].Priority
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	t := &Taxonomy{Rules: sorted, Names: names}
+	for _, rule := range sorted {
+		compiled, err := compileRule(rule)
+		if err != nil {
+			return nil, err
+		}
+		t.rules = append(t.rules, compiled)
+	}
+	return t, nil
+}
+
+// EffectiveTaxonomy saqlangan qoida va nomlardan taksonomiya: qoidalar saqlanmagan bo'lsa
+// standart qoidalar, nomlar esa standart nomlar ustiga yoziladi
+func EffectiveTaxonomy(rules []CategoryRule, names []CategoryName) (*Taxonomy, error) {
+	if len(rules) == 0 {
+		rules = DefaultCategoryRules()
+	}
+
+	merged := DefaultCategoryNames()
+	for _, name := range names {
+		replaced := false
+		for i := range merged {
+			if strings.EqualFold(merged[i].Category, name.Category) {
+				merged[i] = name
+				replaced = true
+			}
+		}
+		if !replaced {
+			merged = append(merged, name)
+		}
+	}
+	return NewTaxonomy(rules, merged)
+}
+
+// ValidateRule qoidani saqlashdan oldin tekshirish
+func ValidateRule(rule CategoryRule) error {
+	_, err := compileRule(rule)
+	return err
+}
+
+func compileRule(rule CategoryRule) (compiledRule, error) {
+	if strings.TrimSpace(rule.Category) == "" {
+		return compiledRule{}, fmt.Errorf("rule %d: category is empty", rule.ID)
+	}
+	if len(rule.Patterns) == 0 {
+		return compiledRule{}, fmt.Errorf("rule %d (%s): no patterns", rule.ID, rule.Category)
+	}
+
+	compiled := compiledRule{rule: rule}
+	for _, pattern := range rule.Patterns {
+		m, err := compilePattern(pattern)
+		if err != nil {
+			return compiledRule{}, fmt.Errorf("rule %d (%s): %w", rule.ID, rule.Category, err)
+		}
+		compiled.patterns = append(compiled.patterns, m)
+	}
+	for _, pattern := range rule.Exclude {
+		m, err := compilePattern(pattern)
+		if err != nil {
+			return compiledRule{}, fmt.Errorf("rule %d (%s): %w", rule.ID, rule.Category, err)
+		}
+		compiled.exclude = append(compiled.exclude, m)
+	}
+	return compiled, nil
+}
+
+// Categorize mahsulot nomidan kategoriya: birinchi mos qoida, bo'lmasa DefaultCategory
+func (t *Taxonomy) Categorize(name string) string {
+	if rule, ok := t.Match(name); ok {
+		return rule.Category
+	}
+	return DefaultCategory
+}
+
+// Match nomga mos birinchi qoida
+func (t *Taxonomy) Match(name string) (CategoryRule, bool) {
+	lower := strings.ToLower(name)
+	words := categoryWords(name)
+	attrs := ExtractAttributes(name, "", nil)
+
+	for _, rule := range t.rules {
+		if matchAny(rule.patterns, words, lower, attrs) && !matchAny(rule.exclude, words, lower, attrs) {
+			return rule.rule, true
+		}
+	}
+	return CategoryRule{}, false
+}
+
+func matchAny(matchers []patternMatcher, words, lower string, attrs HardwareAttributes) bool {
+	for _, m := range matchers {
+		if m.match(words, lower, attrs) {
+			return true
+		}
+	}
+	return false
+}
+
+// DisplayName kategoriya nomi tarjimalari bilan (nom kiritilmagan bo'lsa o'zi)
+func (t *Taxonomy) DisplayName(category string) string {
+	for _, name := range t.Names {
+		if strings.EqualFold(name.Category, category) {
+			return name.Label()
+		}
+	}
+	return category
+}
+
+// DefaultCategoryRules tayyor qoidalar (admin o'zgartirmaguncha ishlatiladi)
+func DefaultCategoryRules() []CategoryRule {
+	return []CategoryRule{
+		{ID: 1, Category: "Motherboard", Priority: 100, Patterns: []string{"attr:chipset"}, Exclude: []string{"case", "корпус", "korpus", "chassis"}},
+		{ID: 2, Category: "GPU", Priority: 100, Patterns: []string{"attr:vram"}},
+		{ID: 3, Category: "Storage", Priority: 100, Patterns: []string{"attr:storage"}},
+		{ID: 4, Category: "RAM", Priority: 100, Patterns: []string{"attr:memory"}},
+		{ID: 5, Category: "PSU", Priority: 100, Patterns: []string{"attr:psu"}},
+		{ID: 6, Category: "Monitor", Priority: 90, Patterns: []string{"monitor", "монитор", "display", "screen", "attr:refresh", "ips", "va panel", "curved", "ultrawide"}},
+		{ID: 7, Category: "Storage", Priority: 80, Patterns: []string{"ssd", "nvme", "hdd", "hard drive"}},
+		{ID: 8, Category: "RAM", Priority: 75, Patterns: []string{"ddr3", "ddr4", "ddr5", "ddr"}},
+		{ID: 9, Category: "CPU", Priority: 70, Patterns: []string{"intel", "amd", "ryzen", `re:core\s?i[3579]`, "core ultra", "processor", "процессор", "xeon"},
+			Exclude: []string{"radeon", "rtx", "gtx", "geforce", `re:\brx\s?-?\d{3,4}`}},
+		{ID: 10, Category: "GPU", Priority: 65, Patterns: []string{"rtx", "gtx", "radeon", `re:\brx\s?-?\d{3,4}`, "geforce", "nvidia", `re:\barc\s?[ab]\d{3}`, "inno3d"}},
+		{ID: 11, Category: "Motherboard", Priority: 60, Patterns: []string{"motherboard", "материнская плата", "ona plata", "lga1700", "lga1851", "am4", "am5"}},
+		{ID: 12, Category: "PSU", Priority: 55, Patterns: []string{"psu", "power supply", "блок питания", "quvvat bloki", "watt",
+			`re:\d{3,4}\s?w\b.*(80\+|bronze|silver|gold|platinum|titanium)`}},
+		{ID: 13, Category: "Case", Priority: 52, Patterns: []string{"case", "корпус", "chassis", "tower", "korpus"}, Exclude: []string{"tower cooler", "air cooler"}},
+		{ID: 14, Category: "Cooling", Priority: 50, Patterns: []string{"cooler", "cooling", "fan", "aio", "liquid", "кулер"}},
+		{ID: 15, Category: "Chair", Priority: 40, Patterns: []string{"chair", "стул", "кресло", "kreslo"}},
+		{ID: 16, Category: "Desk", Priority: 35, Patterns: []string{"desk", "стол", "stol"}},
+		{ID: 17, Category: "Keyboard", Priority: 30, Patterns: []string{"keyboard", "клавиатура", "klaviatura"}},
+		{ID: 18, Category: "Mouse", Priority: 25, Patterns: []string{"mouse", "мышь", "sichqoncha"}},
+		{ID: 19, Category: "Headset", Priority: 20, Patterns: []string{"headset", "headphone", "headphones", "наушники", "quloqchin"}},
+		{ID: 20, Category: "RAM", Priority: 10, Patterns: []string{"ram", "memory", "corsair vengeance", "kingston fury"}},
+	}
+}
+
+// DefaultCategoryNames tayyor kategoriya nomlari
+func DefaultCategoryNames() []CategoryName {
+	return []CategoryName{
+		{Category: "CPU", Uz: "Protsessor", Ru: "Процессор"},
+		{Category: "GPU", Uz: "Videokarta", Ru: "Видеокарта"},
+		{Category: "Motherboard", Uz: "Ona plata", Ru: "Материнская плата"},
+		{Category: "RAM", Uz: "Operativ xotira", Ru: "Оперативная память"},
+		{Category: "Storage", Uz: "Doimiy xotira (SSD/HDD)", Ru: "Накопитель"},
+		{Category: "PSU", Uz: "Quvvat bloki", Ru: "Блок питания"},
+		{Category: "Case", Uz: "Korpus", Ru: "Корпус"},
+		{Category: "Cooling", Uz: "Sovutish", Ru: "Охлаждение"},
+		{Category: "Monitor", Uz: "Monitor", Ru: "Монитор"},
+		{Category: "Chair", Uz: "Kreslo", Ru: "Кресло"},
+		{Category: "Desk", Uz: "Stol", Ru: "Стол"},
+		{Category: "Keyboard", Uz: "Klaviatura", Ru: "Клавиатура"},
+		{Category: "Mouse", Uz: "Sichqoncha", Ru: "Мышь"},
+		{Category: "Headset", Uz: "Quloqchin", Ru: "Наушники"},
+	}
+}
@@ -22,4 +22,7 @@ type AdminRepository interface {
 
 	// LogAction admin harakatini loglash
 	LogAction(ctx context.Context, action entity.AdminAction) error
+
+	// ListActions filtr bo'yicha harakatlarni olish (yangidan eskiga) va umumiy sonini qaytarish
+	ListActions(ctx context.Context, filter entity.AuditFilter) ([]entity.AdminAction, int, error)
 }
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+// CatalogParser katalog fayllarini (Excel, CSV/TSV, JSON) parse qilish uchun interface.
+// Format fayl kengaytmasi yoki tarkibiga qarab aniqlanadi.
+type CatalogParser interface {
+	// ParseProducts fayldan mahsulotlarni o'qish
+	ParseProducts(ctx context.Context, filePath string, opts entity.ImportOptions) ([]entity.Product, error)
+
+	// ParseProductsFromBytes byte array dan parse qilish
+	ParseProductsFromBytes(ctx context.Context, data []byte, filename string, opts entity.ImportOptions) ([]entity.Product, error)
+
+	// ParseCatalog faylni tekshirish: qabul qilingan mahsulotlar va qator darajasidagi hisobot.
+	// Yaroqli mahsulot bo'lmasa ham hisobot qaytadi (xatolik faqat fayl o'qilmasa).
+	ParseCatalog(ctx context.Context, data []byte, filename string, opts entity.ImportOptions) (*entity.ImportReport, error)
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+// CatalogVersionRepository katalog versiyalari tarixi bilan ishlash uchun interface
+type CatalogVersionRepository interface {
+	// SaveVersion katalogni yangi versiya sifatida saqlash, versiya raqamini qaytaradi
+	SaveVersion(ctx context.Context, version entity.CatalogVersion) (int, error)
+
+	// ListVersions barcha versiyalarni olish (mahsulotlarsiz, yangidan eskiga)
+	ListVersions(ctx context.Context) ([]entity.CatalogVersion, error)
+
+	// GetVersion versiyani mahsulotlari bilan olish
+	GetVersion(ctx context.Context, version int) (*entity.CatalogVersion, error)
+}
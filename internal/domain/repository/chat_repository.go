@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
 )
@@ -25,4 +26,10 @@ type ChatRepository interface {
 
 	// GetContext foydalanuvchi chat kontekstini olish
 	GetContext(ctx context.Context, userID int64) (*entity.ChatContext, error)
+
+	// Search xabarlar (foydalanuvchi matni va bot javobi) bo'yicha to'liq matnli qidiruv, yangidan eskiga
+	Search(ctx context.Context, query string, limit int) ([]entity.MessageMatch, error)
+
+	// PruneContexts before dan beri ishlatilmagan kontekstlarni xotiradan chiqarish
+	PruneContexts(ctx context.Context, before time.Time) (int, error)
 }
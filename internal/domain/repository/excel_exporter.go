@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+// ExcelExporter ma'lumotlarni Excel (.xlsx) faylga eksport qilish uchun interface
+type ExcelExporter interface {
+	// ExportAuditLog admin harakatlari logini .xlsx ga yozish
+	ExportAuditLog(ctx context.Context, actions []entity.AdminAction) ([]byte, error)
+
+	// ExportImportReport katalog import hisobotini .xlsx ga yozish
+	ExportImportReport(ctx context.Context, report *entity.ImportReport) ([]byte, error)
+
+	// ExportCatalog katalogni qayta yuklash mumkin bo'lgan .xlsx ga yozish (har kategoriya - alohida sheet)
+	ExportCatalog(ctx context.Context, products []entity.Product) ([]byte, error)
+}
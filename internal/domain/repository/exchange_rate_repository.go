@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+// ExchangeRateRepository valyuta kurslari jadvali bilan ishlash uchun interface
+type ExchangeRateRepository interface {
+	// SaveRates kurslarni saqlash (valyuta bo'yicha almashtiriladi, ro'yxatda yo'qlari qoladi)
+	SaveRates(ctx context.Context, rates []entity.ExchangeRate) error
+
+	// ListRates barcha saqlangan kurslar (valyuta kodi bo'yicha tartiblangan)
+	ListRates(ctx context.Context) ([]entity.ExchangeRate, error)
+}
+
+// RateSource tashqi kurs manbai (masalan, Markaziy bank). Ulanmasa kurslar faqat qo'lda kiritiladi.
+type RateSource interface {
+	// Name manba nomi (ExchangeRate.Source ga yoziladi)
+	Name() string
+
+	// FetchRates joriy kurslarni olish (1 birlik necha so'm)
+	FetchRates(ctx context.Context) ([]entity.ExchangeRate, error)
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+// ImportProfileRepository yetkazib beruvchilar import profillari bilan ishlash uchun interface
+type ImportProfileRepository interface {
+	// ListProfiles barcha profillar (nom bo'yicha tartiblangan)
+	ListProfiles(ctx context.Context) ([]entity.ImportProfile, error)
+
+	// GetProfile nom bo'yicha profil (katta-kichik harf farqsiz)
+	GetProfile(ctx context.Context, name string) (*entity.ImportProfile, error)
+
+	// SaveProfile profilni saqlash (shu nomli profil almashtiriladi)
+	SaveProfile(ctx context.Context, profile entity.ImportProfile) error
+
+	// DeleteProfile profilni o'chirish
+	DeleteProfile(ctx context.Context, name string) error
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+// OrderRepository buyurtmalar bilan ishlash uchun interface
+type OrderRepository interface {
+	// Save buyurtmani saqlash (yangi yoki mavjudini yangilash)
+	Save(ctx context.Context, order entity.Order) error
+
+	// GetByID ID bo'yicha buyurtmani olish
+	GetByID(ctx context.Context, id string) (*entity.Order, error)
+
+	// ListByUser foydalanuvchi buyurtmalari (yangidan eskiga)
+	ListByUser(ctx context.Context, userID int64) ([]entity.Order, error)
+
+	// ListByStatus holat bo'yicha buyurtmalar (status bo'sh bo'lsa barchasi, yangidan eskiga)
+	ListByStatus(ctx context.Context, status entity.OrderStatus, limit int) ([]entity.Order, error)
+
+	// DeleteByUser foydalanuvchining barcha buyurtmalarini o'chirish (o'chirilganlar sonini qaytaradi)
+	DeleteByUser(ctx context.Context, userID int64) (int, error)
+}
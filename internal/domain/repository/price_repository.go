@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+// PriceHistoryRepository mahsulot narxlari tarixi bilan ishlash uchun interface
+type PriceHistoryRepository interface {
+	// RecordPrices narxlarni tarixga yozish
+	RecordPrices(ctx context.Context, points []entity.PricePoint) error
+
+	// ListPrices kalit (entity.PriceKey) bo'yicha narxlar tarixi (yangidan eskiga, limit 0 - barchasi)
+	ListPrices(ctx context.Context, key string, limit int) ([]entity.PricePoint, error)
+}
+
+// PriceAlertRepository mijozlarning narx obunalari bilan ishlash uchun interface
+type PriceAlertRepository interface {
+	// SaveAlert obunani saqlash (yangi yoki mavjudini yangilash)
+	SaveAlert(ctx context.Context, alert entity.PriceAlert) error
+
+	// ListAlerts barcha obunalar
+	ListAlerts(ctx context.Context) ([]entity.PriceAlert, error)
+
+	// ListByUser foydalanuvchi obunalari (yangidan eskiga)
+	ListByUser(ctx context.Context, userID int64) ([]entity.PriceAlert, error)
+
+	// DeleteAlert obunani o'chirish
+	DeleteAlert(ctx context.Context, id string) error
+
+	// DeleteByUser foydalanuvchining barcha obunalarini o'chirish (o'chirilganlar sonini qaytaradi)
+	DeleteByUser(ctx context.Context, userID int64) (int, error)
+}
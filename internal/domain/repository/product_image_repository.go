@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+// ProductImageRepository katalog fayllariga joylangan mahsulot rasmlari bilan ishlash uchun interface
+type ProductImageRepository interface {
+	// SaveImages rasmlarni saqlash (shu ID li rasm bor bo'lsa o'zgarmaydi)
+	SaveImages(ctx context.Context, images []entity.ProductImage) error
+
+	// GetImage ID bo'yicha rasm
+	GetImage(ctx context.Context, id string) (*entity.ProductImage, error)
+
+	// SetFileID rasm Telegram ga yuborilgandan keyingi file_id ni saqlash
+	SetFileID(ctx context.Context, id, fileID string) error
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+// StateRepository dialog holatlarini saqlash uchun interface.
+// Bot qayta ishga tushganda yarim qolgan buyurtmalar va guruh javoblari yo'qolmasligi uchun.
+type StateRepository interface {
+	// Put holatni saqlash (mavjud bo'lsa almashtiriladi)
+	Put(ctx context.Context, entry entity.StateEntry) error
+
+	// Get holatni olish (topilmasa ok=false)
+	Get(ctx context.Context, namespace, key string) (*entity.StateEntry, bool, error)
+
+	// Delete holatni o'chirish
+	Delete(ctx context.Context, namespace, key string) error
+
+	// ListByUser foydalanuvchiga tegishli barcha holatlar
+	ListByUser(ctx context.Context, userID int64) ([]entity.StateEntry, error)
+
+	// DeleteByUser foydalanuvchiga tegishli barcha holatlarni o'chirish (o'chirilganlar sonini qaytaradi)
+	DeleteByUser(ctx context.Context, userID int64) (int, error)
+
+	// DeleteOlderThan before dan oldin yangilangan holatlarni o'chirish va ularni qaytarish
+	DeleteOlderThan(ctx context.Context, namespace string, before time.Time) ([]entity.StateEntry, error)
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+// StockReservationRepository buyurtmalar uchun ombor bronlari bilan ishlash uchun interface
+type StockReservationRepository interface {
+	// SaveReservations buyurtma bronlarini saqlash (buyurtmaning oldingi bronlari almashtiriladi)
+	SaveReservations(ctx context.Context, orderID string, reservations []entity.StockReservation) error
+
+	// ListReservations barcha ochiq bronlar
+	ListReservations(ctx context.Context) ([]entity.StockReservation, error)
+
+	// ListByOrder buyurtma bronlari
+	ListByOrder(ctx context.Context, orderID string) ([]entity.StockReservation, error)
+
+	// DeleteByOrder buyurtma bronlarini o'chirish
+	DeleteByOrder(ctx context.Context, orderID string) error
+
+	// CommitOrder buyurtma bronlarini ombordagi sondan (mahsulot kaliti bo'yicha) ayirib,
+	// bronlarni o'chirish - bitta tranzaksiyada
+	CommitOrder(ctx context.Context, orderID string) error
+}
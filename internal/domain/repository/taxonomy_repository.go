@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+// TaxonomyRepository kategoriya qoidalari va nomlari bilan ishlash uchun interface.
+// Qoidalar saqlanmagan bo'lsa standart qoidalar (entity.DefaultCategoryRules) ishlatiladi.
+type TaxonomyRepository interface {
+	// ListRules saqlangan qoidalar (ID bo'yicha tartiblangan, hech narsa saqlanmagan bo'lsa bo'sh)
+	ListRules(ctx context.Context) ([]entity.CategoryRule, error)
+
+	// SaveRule qoidani saqlash (ID 0 bo'lsa yangi qoida qo'shiladi) va uning ID sini qaytarish
+	SaveRule(ctx context.Context, rule entity.CategoryRule) (int, error)
+
+	// DeleteRule qoidani o'chirish
+	DeleteRule(ctx context.Context, id int) error
+
+	// ReplaceRules barcha qoidalarni almashtirish (ID lar saqlanadi)
+	ReplaceRules(ctx context.Context, rules []entity.CategoryRule) error
+
+	// ListCategoryNames kategoriyalarning o'zbekcha va ruscha nomlari
+	ListCategoryNames(ctx context.Context) ([]entity.CategoryName, error)
+
+	// SaveCategoryName kategoriya nomini saqlash (kategoriya bo'yicha almashtiriladi)
+	SaveCategoryName(ctx context.Context, name entity.CategoryName) error
+}
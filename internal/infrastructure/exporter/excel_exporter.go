@@ -0,0 +1,382 @@
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/repository"
+)
+
+type excelExporter struct{}
+
+// NewExcelExporter yangi Excel exporter yaratish
+func NewExcelExporter() repository.ExcelExporter {
+	return &excelExporter{}
+}
+
+// ExportAuditLog admin harakatlari logini .xlsx ga yozish
+func (e *excelExporter) ExportAuditLog(ctx context.Context, actions []entity.AdminAction) ([]byte, error) {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	sheet := "Audit"
+	if err := f.SetSheetName(f.GetSheetName(0), sheet); err != nil {
+		return nil, fmt.Errorf("failed to rename sheet: %w", err)
+	}
+
+	rows := [][]any{{"Vaqt", "Admin ID", "Harakat", "Tafsilot", "ID"}}
+	for _, a := range actions {
+		rows = append(rows, []any{
+			a.Timestamp.Format("2006-01-02 15:04:05"),
+			a.UserID,
+			a.Action,
+			a.Details,
+			a.ID,
+		})
+	}
+
+	if err := writeRows(f, sheet, rows); err != nil {
+		return nil, err
+	}
+	_ = f.SetColWidth(sheet, "A", "A", 20)
+	_ = f.SetColWidth(sheet, "C", "C", 18)
+	_ = f.SetColWidth(sheet, "D", "D", 60)
+
+	return toBytes(f)
+}
+
+// ExportImportReport katalog import hisobotini .xlsx ga yozish:
+// "Xulosa" (umumiy sonlar, import profili, bo'lim sarlavhalari va taxmin qilingan ustunlar), "Muammolar" va "Qabul qilingan" sheetlari
+func (e *excelExporter) ExportImportReport(ctx context.Context, report *entity.ImportReport) ([]byte, error) {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	summary := "Xulosa"
+	if err := f.SetSheetName(f.GetSheetName(0), summary); err != nil {
+		return nil, fmt.Errorf("failed to rename sheet: %w", err)
+	}
+
+	summaryRows := [][]any{
+		{"Ko'rsatkich", "Qiymat"},
+		{"Fayl", report.Source},
+		{"Format", report.Format},
+		{"Tekshirilgan vaqt", report.CreatedAt.Format("2006-01-02 15:04:05")},
+		{"Ko'rib chiqilgan qatorlar", report.TotalRows},
+		{"Qabul qilingan mahsulotlar", len(report.Products)},
+		{"O'tkazib yuborilgan qatorlar", report.CountIssues(entity.ImportIssueSkipped)},
+		{"Nol narxli qatorlar", report.CountIssues(entity.ImportIssueZeroPrice)},
+		{"Takroriy nomlar", report.CountIssues(entity.ImportIssueDuplicate)},
+		{"Shubhali narxlar", report.CountIssues(entity.ImportIssueSuspiciousPrice)},
+		{"Narx oraliqlari", report.CountIssues(entity.ImportIssuePriceRange)},
+		{"Rasm havolasi emas", report.CountIssues(entity.ImportIssueImage)},
+		{"Rasmli mahsulotlar", countWithImage(report.Products)},
+	}
+	if len(report.Numbers) > 0 {
+		summaryRows = append(summaryRows, []any{}, []any{"Raqam formati", ""})
+		for _, n := range report.Numbers {
+			detail := n.Locale.Describe()
+			if n.Detected {
+				detail += ", fayldan aniqlandi"
+			}
+			if n.Example != "" {
+				detail += "; masalan " + n.Example
+			}
+			detail += fmt.Sprintf("; noaniq: %d, qisqartma: %d, oraliq: %d", n.Ambiguous, n.Scaled, n.Ranges)
+			summaryRows = append(summaryRows, []any{nonEmptySheet(n.Sheet), detail})
+		}
+	}
+	if len(report.Profiles) > 0 {
+		summaryRows = append(summaryRows, []any{}, []any{"Import profili", ""})
+		for _, m := range report.Profiles {
+			detail := m.Profile + ", izohda ko'rsatilgan"
+			if m.Detected {
+				detail = m.Profile + ", sarlavhadan aniqlandi"
+			}
+			summaryRows = append(summaryRows, []any{nonEmptySheet(m.Sheet), detail})
+		}
+	}
+	if len(report.Sections) > 0 {
+		summaryRows = append(summaryRows, []any{}, []any{"Bo'lim sarlavhalari", ""})
+		for _, section := range report.Sections {
+			level := "kategoriya"
+			if section.Level == entity.SectionGroup {
+				level = "guruh"
+			}
+			summaryRows = append(summaryRows, []any{
+				section.Location(),
+				fmt.Sprintf("%s (%s) - %d ta mahsulot", section.Title, level, section.Products),
+			})
+		}
+	}
+	if len(report.UnstyledSheets) > 0 {
+		summaryRows = append(summaryRows, []any{
+			"Sarlavhalar faqat joylashuvdan",
+			strings.Join(report.UnstyledSheets, ", ") + " (katta sheet: birlashtirish va qalin shrift tekshirilmadi)",
+		})
+	}
+	if len(report.Shortages) > 0 {
+		summaryRows = append(summaryRows, []any{}, []any{"Ombor bronlardan kam", ""})
+		for _, s := range report.Shortages {
+			summaryRows = append(summaryRows, []any{s.Name, fmt.Sprintf("omborda %d ta, bron qilingan %d ta", s.Stock, s.Reserved)})
+		}
+	}
+	if len(report.Guesses) > 0 {
+		summaryRows = append(summaryRows, []any{}, []any{"Taxmin qilingan ustunlar", ""})
+		for _, g := range report.Guesses {
+			summaryRows = append(summaryRows, []any{
+				fmt.Sprintf("%s: %s", nonEmptySheet(g.Sheet), g.Field),
+				fmt.Sprintf("%s ustun - %s", g.ColumnName(), g.Reason),
+			})
+		}
+	}
+	if err := writeRows(f, summary, summaryRows); err != nil {
+		return nil, err
+	}
+	_ = f.SetColWidth(summary, "A", "A", 32)
+	_ = f.SetColWidth(summary, "B", "B", 70)
+
+	issues := "Muammolar"
+	if _, err := f.NewSheet(issues); err != nil {
+		return nil, fmt.Errorf("failed to create sheet: %w", err)
+	}
+	issueRows := [][]any{{"Sheet", "Qator", "Tur", "Nom", "Qiymat", "Sabab"}}
+	for _, issue := range report.Issues {
+		issueRows = append(issueRows, []any{
+			issue.Sheet,
+			issue.Row,
+			string(issue.Kind),
+			issue.Name,
+			issue.Value,
+			issue.Reason,
+		})
+	}
+	if err := writeRows(f, issues, issueRows); err != nil {
+		return nil, err
+	}
+	_ = f.SetColWidth(issues, "C", "C", 18)
+	_ = f.SetColWidth(issues, "D", "D", 40)
+	_ = f.SetColWidth(issues, "F", "F", 50)
+
+	accepted := "Qabul qilingan"
+	if _, err := f.NewSheet(accepted); err != nil {
+		return nil, fmt.Errorf("failed to create sheet: %w", err)
+	}
+	productRows := [][]any{{"Nom", "Kategoriya", "Narx", "Valyuta", "Ombor", "Tavsif"}}
+	for _, p := range report.Products {
+		productRows = append(productRows, []any{p.Name, p.Category, p.Price, string(p.PriceCurrency()), stockCell(p), p.Description})
+	}
+	if err := writeRows(f, accepted, productRows); err != nil {
+		return nil, err
+	}
+	_ = f.SetColWidth(accepted, "A", "A", 45)
+	_ = f.SetColWidth(accepted, "B", "B", 18)
+	_ = f.SetColWidth(accepted, "F", "F", 50)
+
+	return toBytes(f)
+}
+
+// Katalog eksporti sarlavhalari. Ular parserdagi headerKeywords kalit so'zlariga mos,
+// shuning uchun eksport qilingan faylni qayta yuklash aynan shu katalogni beradi.
+const (
+	catalogHeaderSKU         = "SKU"
+	catalogHeaderName        = "Nomi"
+	catalogHeaderCategory    = "Kategoriya"
+	catalogHeaderPrice       = "Narx"
+	catalogHeaderCurrency    = "Valyuta"
+	catalogHeaderDescription = "Tavsif"
+	catalogHeaderStock       = "Soni"
+	catalogHeaderImage       = "Rasm"
+)
+
+// ExportCatalog katalogni .xlsx ga yozish: har bir kategoriya alohida sheet,
+// asosiy ustunlardan keyin shu kategoriyadagi barcha Specs kalitlari (alifbo tartibida).
+// Rasm ustuniga faqat havolalar yoziladi (faylga joylangan rasmlar merge importda saqlanib qoladi).
+func (e *excelExporter) ExportCatalog(ctx context.Context, products []entity.Product) ([]byte, error) {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	byCategory := make(map[string][]entity.Product)
+	hasSKU, hasImage := false, false
+	for _, p := range products {
+		byCategory[p.Category] = append(byCategory[p.Category], p)
+		hasSKU = hasSKU || p.SKU != ""
+		hasImage = hasImage || entity.IsImageURL(p.Image)
+	}
+
+	categories := make([]string, 0, len(byCategory))
+	for category := range byCategory {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
+	defaultSheet := f.GetSheetName(0)
+	usedNames := make(map[string]bool)
+	for i, category := range categories {
+		sheet := catalogSheetName(category, usedNames)
+		if i == 0 {
+			if err := f.SetSheetName(defaultSheet, sheet); err != nil {
+				return nil, fmt.Errorf("failed to rename sheet: %w", err)
+			}
+		} else if _, err := f.NewSheet(sheet); err != nil {
+			return nil, fmt.Errorf("failed to create sheet %q: %w", sheet, err)
+		}
+
+		items := byCategory[category]
+		sort.Slice(items, func(a, b int) bool { return items[a].Name < items[b].Name })
+
+		specKeys := collectSpecKeys(items)
+		header := []any{}
+		if hasSKU {
+			header = append(header, catalogHeaderSKU)
+		}
+		header = append(header, catalogHeaderName, catalogHeaderCategory, catalogHeaderPrice, catalogHeaderCurrency, catalogHeaderDescription, catalogHeaderStock)
+		if hasImage {
+			header = append(header, catalogHeaderImage)
+		}
+		for _, key := range specKeys {
+			header = append(header, key)
+		}
+
+		rows := [][]any{header}
+		for _, p := range items {
+			row := []any{}
+			if hasSKU {
+				row = append(row, p.SKU)
+			}
+			row = append(row, p.Name, p.Category, p.Price, string(p.PriceCurrency()), p.Description, stockCell(p))
+			if hasImage {
+				image := ""
+				if entity.IsImageURL(p.Image) {
+					image = p.Image
+				}
+				row = append(row, image)
+			}
+			for _, key := range specKeys {
+				row = append(row, p.Specs[key])
+			}
+			rows = append(rows, row)
+		}
+
+		if err := writeRows(f, sheet, rows); err != nil {
+			return nil, err
+		}
+
+		nameCol := "A"
+		if hasSKU {
+			nameCol = "B"
+		}
+		_ = f.SetColWidth(sheet, nameCol, nameCol, 45)
+	}
+
+	return toBytes(f)
+}
+
+// stockCell ombor katagi: hisobi yuritilmaydigan mahsulotda bo'sh (qayta importda 0 bo'lib qolmasligi uchun)
+func stockCell(p entity.Product) any {
+	if !p.StockTracked {
+		return ""
+	}
+	return p.Stock
+}
+
+// countWithImage rasmi (havola yoki joylangan rasm) bor mahsulotlar soni
+func countWithImage(products []entity.Product) int {
+	count := 0
+	for _, p := range products {
+		if p.Image != "" {
+			count++
+		}
+	}
+	return count
+}
+
+// collectSpecKeys mahsulotlardagi barcha Specs kalitlari (alifbo tartibida)
+func collectSpecKeys(products []entity.Product) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, p := range products {
+		for key := range p.Specs {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// catalogSheetName kategoriyadan Excel talablariga mos, takrorlanmaydigan sheet nomi.
+// Nom qisqartirilsa ham kategoriyaning o'zi "Kategoriya" ustunida to'liq saqlanadi.
+func catalogSheetName(category string, used map[string]bool) string {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`:\/?*[]`, r) {
+			return '_'
+		}
+		return r
+	}, strings.TrimSpace(category))
+	name = strings.Trim(name, "'")
+	if name == "" {
+		name = "Boshqa"
+	}
+
+	base := truncateRunes(name, 31)
+	name = base
+	for n := 2; used[strings.ToLower(name)]; n++ {
+		suffix := fmt.Sprintf(" (%d)", n)
+		name = truncateRunes(base, 31-len(suffix)) + suffix
+	}
+	used[strings.ToLower(name)] = true
+	return name
+}
+
+func truncateRunes(s string, limit int) string {
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	return string(runes[:limit])
+}
+
+// nonEmptySheet CSV/JSON da sheet nomi bo'lmaydi
+func nonEmptySheet(sheet string) string {
+	if sheet == "" {
+		return "Fayl"
+	}
+	return sheet
+}
+
+// writeRows qatorlarni A1 dan boshlab yozish, birinchi qator header (qalin)
+func writeRows(f *excelize.File, sheet string, rows [][]any) error {
+	for i, row := range rows {
+		cell, err := excelize.CoordinatesToCellName(1, i+1)
+		if err != nil {
+			return err
+		}
+		if err := f.SetSheetRow(sheet, cell, &row); err != nil {
+			return fmt.Errorf("failed to write row %d: %w", i+1, err)
+		}
+	}
+
+	if len(rows) > 0 && len(rows[0]) > 0 {
+		style, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+		if err == nil {
+			lastCell, _ := excelize.CoordinatesToCellName(len(rows[0]), 1)
+			_ = f.SetCellStyle(sheet, "A1", lastCell, style)
+		}
+	}
+	return nil
+}
+
+func toBytes(f *excelize.File) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := f.Write(&buf); err != nil {
+		return nil, fmt.Errorf("failed to write excel: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,237 @@
+package parser
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/repository"
+)
+
+// Qo'llab-quvvatlanadigan katalog formatlari
+const (
+	formatXLSX = "xlsx"
+	formatXLS  = "xls"
+	formatODS  = "ods"
+	formatXLSB = "xlsb" // o'qilmaydi, faqat aniq xato uchun
+	formatHTML = "html" // .xls nomi bilan saqlangan HTML jadval (o'qilmaydi)
+	formatXMLS = "xmls" // Excel 2003 XML Spreadsheet (o'qilmaydi)
+	formatCSV  = "csv"
+	formatTSV  = "tsv"
+	formatJSON = "json"
+)
+
+type catalogParser struct {
+	taxonomyRepo repository.TaxonomyRepository
+	profileRepo  repository.ImportProfileRepository
+}
+
+// NewCatalogParser yangi katalog parser yaratish (Excel .xlsx/.xls, OpenDocument .ods, CSV/TSV, JSON).
+// Kategoriya ustuni bo'lmagan mahsulotlar taxonomyRepo qoidalari bilan kategoriyalanadi
+// (nil bo'lsa standart qoidalar). Sarlavhasi profileRepo dagi import profiliga mos
+// sheet lar shu profil bo'yicha o'qiladi (nil bo'lsa faqat kalit so'zlar).
+func NewCatalogParser(taxonomyRepo repository.TaxonomyRepository, profileRepo repository.ImportProfileRepository) repository.CatalogParser {
+	return &catalogParser{taxonomyRepo: taxonomyRepo, profileRepo: profileRepo}
+}
+
+// ParseProducts fayldan mahsulotlarni o'qish
+func (e *catalogParser) ParseProducts(ctx context.Context, filePath string, opts entity.ImportOptions) ([]entity.Product, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read catalog file: %w", err)
+	}
+
+	return e.ParseProductsFromBytes(ctx, data, filepath.Base(filePath), opts)
+}
+
+// ParseProductsFromBytes byte array dan parse qilish (format kengaytma yoki tarkibdan aniqlanadi)
+func (e *catalogParser) ParseProductsFromBytes(ctx context.Context, data []byte, filename string, opts entity.ImportOptions) ([]entity.Product, error) {
+	report, err := e.ParseCatalog(ctx, data, filename, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(report.Products) == 0 {
+		return nil, fmt.Errorf("no valid products found in %s (parsed %d rows, but all were invalid)", filename, report.TotalRows)
+	}
+	return report.Products, nil
+}
+
+// ParseCatalog faylni parse qilib, qabul qilingan mahsulotlar va muammoli qatorlar hisobotini qaytarish
+func (e *catalogParser) ParseCatalog(ctx context.Context, data []byte, filename string, opts entity.ImportOptions) (*entity.ImportReport, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("catalog file is empty")
+	}
+
+	taxonomy, err := e.taxonomy(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles, err := e.profiles(ctx, opts.Profile)
+	if err != nil {
+		return nil, err
+	}
+
+	format := detectFormat(filename, data)
+	c := newImportCollector(filename, format, opts, taxonomy)
+	c.profiles = profiles
+	c.forceProfile = opts.Profile != ""
+
+	switch format {
+	case formatXLSX:
+		err = e.parseXLSX(data, opts, c)
+	case formatXLS, formatODS:
+		var sheets []sheetData
+		if format == formatXLS {
+			sheets, err = readXLS(data)
+		} else {
+			sheets, err = readODS(data)
+		}
+		if err == nil {
+			err = e.parseWorkbook(sheets, opts, c)
+		}
+	case formatCSV, formatTSV:
+		err = e.parseDelimited(data, format, c)
+	case formatJSON:
+		err = e.parseJSON(data, c)
+	case formatXLSB:
+		return nil, fmt.Errorf("%w: %s is an Excel binary workbook (.xlsb), save it as .xlsx", entity.ErrUnsupportedFormat, filename)
+	case formatHTML:
+		return nil, fmt.Errorf("%w: %s is an HTML table, not a spreadsheet (open it in Excel and save as .xlsx)", entity.ErrUnsupportedFormat, filename)
+	case formatXMLS:
+		return nil, fmt.Errorf("%w: %s is an Excel 2003 XML spreadsheet, save it as .xlsx", entity.ErrUnsupportedFormat, filename)
+	default:
+		return nil, fmt.Errorf("%w: %s", entity.ErrUnsupportedFormat, filename)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c.finish()
+	log.Printf("📦 Total products parsed: %d (rows: %d, issues: %d)", len(c.report.Products), c.report.TotalRows, len(c.report.Issues))
+	return c.report, nil
+}
+
+// taxonomy joriy kategoriya qoidalari
+func (e *catalogParser) taxonomy(ctx context.Context) (*entity.Taxonomy, error) {
+	if e.taxonomyRepo == nil {
+		return entity.EffectiveTaxonomy(nil, nil)
+	}
+
+	rules, err := e.taxonomyRepo.ListRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load category rules: %w", err)
+	}
+	names, err := e.taxonomyRepo.ListCategoryNames(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load category names: %w", err)
+	}
+	return entity.EffectiveTaxonomy(rules, names)
+}
+
+// profiles sheet sarlavhalari solishtiriladigan import profillari: izohda nom berilgan
+// bo'lsa faqat o'sha profil, "none" bo'lsa hech biri, aks holda barchasi
+func (e *catalogParser) profiles(ctx context.Context, name string) ([]entity.ImportProfile, error) {
+	if strings.EqualFold(name, entity.ImportProfileNone) {
+		return nil, nil
+	}
+	if e.profileRepo == nil {
+		if name != "" {
+			return nil, fmt.Errorf("import profile %q not found", name)
+		}
+		return nil, nil
+	}
+
+	if name != "" {
+		profile, err := e.profileRepo.GetProfile(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load import profile: %w", err)
+		}
+		return []entity.ImportProfile{*profile}, nil
+	}
+
+	profiles, err := e.profileRepo.ListProfiles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load import profiles: %w", err)
+	}
+	return profiles, nil
+}
+
+// detectFormat formatni aniqlash: jadval fayllari tarkibidan (.xls nomli fayl aslida .xlsx,
+// HTML yoki matn bo'lishi mumkin), qolganlari kengaytmadan, kengaytma bo'lmasa tarkibdan
+func detectFormat(filename string, data []byte) string {
+	ext := strings.ToLower(filepath.Ext(filename))
+	switch ext {
+	case ".xlsx", ".xlsm", ".xls", ".ods":
+		if sniffed := sniffFormat(data); sniffed != "" {
+			return sniffed
+		}
+		return map[string]string{".xlsx": formatXLSX, ".xlsm": formatXLSX, ".xls": formatXLS, ".ods": formatODS}[ext]
+	case ".xlsb":
+		return formatXLSB
+	case ".csv":
+		return formatCSV
+	case ".tsv", ".tab":
+		return formatTSV
+	case ".json":
+		return formatJSON
+	}
+
+	return sniffFormat(data)
+}
+
+// odsMimetype .ods arxivining birinchi fayli ("mimetype") tarkibi
+var odsMimetype = []byte("application/vnd.oasis.opendocument.spreadsheet")
+
+// sniffFormat fayl tarkibidan formatni taxmin qilish
+func sniffFormat(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte("PK\x03\x04")):
+		// .ods yoki .xlsx - ZIP arxiv (.ods da "mimetype" birinchi, siqilmagan holda yoziladi)
+		if bytes.Contains(data[:min(len(data), 256)], odsMimetype) {
+			return formatODS
+		}
+		return formatXLSX
+	case bytes.HasPrefix(data, []byte{0xD0, 0xCF, 0x11, 0xE0}):
+		// .xls - OLE2 compound fayl
+		return formatXLS
+	}
+
+	trimmed := bytes.TrimLeft(bytes.TrimPrefix(data, utf8BOM), " \t\r\n")
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		return formatJSON
+	}
+	if len(trimmed) > 0 && trimmed[0] == '<' {
+		head := bytes.ToLower(trimmed[:min(len(trimmed), 2048)])
+		switch {
+		case bytes.Contains(head, []byte("urn:schemas-microsoft-com:office:spreadsheet")):
+			return formatXMLS
+		case bytes.Contains(head, []byte("<html")), bytes.Contains(head, []byte("<table")), bytes.HasPrefix(head, []byte("<!doctype html")):
+			return formatHTML
+		}
+	}
+
+	if isText(data) {
+		return formatCSV
+	}
+	return ""
+}
+
+// isText fayl boshida boshqaruv belgilar (binary) yo'qligini tekshirish
+func isText(data []byte) bool {
+	sample := data
+	if len(sample) > 4096 {
+		sample = sample[:4096]
+	}
+	for _, b := range sample {
+		if b == 0 || (b < 0x20 && b != '\t' && b != '\n' && b != '\r') {
+			return false
+		}
+	}
+	return true
+}
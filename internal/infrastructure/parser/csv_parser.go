@@ -0,0 +1,143 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+)
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// parseDelimited CSV/TSV faylni parse qilish.
+// Qatorlar Excel sheet kabi matritsaga o'qiladi va umumiy parseSheetRows dan o'tadi.
+func (e *catalogParser) parseDelimited(data []byte, format string, c *importCollector) error {
+	text, encoding, err := decodeText(data)
+	if err != nil {
+		return err
+	}
+
+	delimiter := '\t'
+	if format != formatTSV {
+		delimiter = detectDelimiter(text)
+	}
+	log.Printf("📄 CSV: encoding=%s, delimiter=%q", encoding, delimiter)
+
+	reader := csv.NewReader(strings.NewReader(text))
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	// csv.Reader bo'sh qatorlarni tashlab yuboradi; hisobotdagi qator raqamlari
+	// fayldagi satrlarga mos bo'lishi uchun ular bo'sh qator bilan to'ldiriladi
+	var rows [][]string
+	hasData := false
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read csv: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		for len(rows) < line-1 {
+			rows = append(rows, nil)
+		}
+		rows = append(rows, record)
+		hasData = hasData || !isEmptyRow(record)
+	}
+
+	if !hasData {
+		return fmt.Errorf("csv file is empty")
+	}
+
+	c.report.Sheets = append(c.report.Sheets, "")
+	e.parseSheetRows(rows, "", nil, c)
+	return nil
+}
+
+// decodeText matnni UTF-8 ga o'tkazish: BOM (UTF-8/UTF-16) yoki Windows-1251
+func decodeText(data []byte) (string, string, error) {
+	switch {
+	case bytes.HasPrefix(data, utf8BOM):
+		return string(data[len(utf8BOM):]), "utf-8", nil
+	case bytes.HasPrefix(data, []byte{0xFF, 0xFE}), bytes.HasPrefix(data, []byte{0xFE, 0xFF}):
+		decoded, err := unicode.UTF16(unicode.LittleEndian, unicode.ExpectBOM).NewDecoder().Bytes(data)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to decode utf-16: %w", err)
+		}
+		return string(decoded), "utf-16", nil
+	case utf8.Valid(data):
+		return string(data), "utf-8", nil
+	}
+
+	// 1C va boshqa ERP eksportlari odatda Windows-1251 da bo'ladi
+	decoded, err := charmap.Windows1251.NewDecoder().Bytes(data)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to decode windows-1251: %w", err)
+	}
+	return string(decoded), "windows-1251", nil
+}
+
+// detectDelimiter ajratuvchini aniqlash: birinchi qatorlarda eng barqaror uchraydigan belgi
+func detectDelimiter(text string) rune {
+	candidates := []rune{',', ';', '\t', '|'}
+
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		lines = append(lines, line)
+		if len(lines) == 10 {
+			break
+		}
+	}
+	if len(lines) == 0 {
+		return ','
+	}
+
+	best, bestLines, bestScore := ',', 0, 0
+	for _, candidate := range candidates {
+		// Ajratuvchi ko'proq qatorda uchragani olinadi, teng bo'lsa har bir qatorda kamroq
+		// uchragani eng ko'pi bo'lgan (qo'shtirnoq ichidagilar hisoblanmaydi). Bitta katakli
+		// qatorlar (hujjat va bo'lim sarlavhalari) minimumga ta'sir qilmaydi.
+		minCount, withDelimiter := -1, 0
+		for _, line := range lines {
+			count := countOutsideQuotes(line, candidate)
+			if count == 0 {
+				continue
+			}
+			withDelimiter++
+			if minCount == -1 || count < minCount {
+				minCount = count
+			}
+		}
+		if withDelimiter > bestLines || (withDelimiter == bestLines && minCount > bestScore) {
+			best, bestLines, bestScore = candidate, withDelimiter, minCount
+		}
+	}
+	return best
+}
+
+func countOutsideQuotes(line string, delimiter rune) int {
+	count := 0
+	inQuotes := false
+	for _, r := range line {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case r == delimiter && !inQuotes:
+			count++
+		}
+	}
+	return count
+}
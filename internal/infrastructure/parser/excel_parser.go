@@ -2,101 +2,302 @@ package parser
 
 import (
 	"bytes"
-	"context"
 	"fmt"
 	"log"
-	"strconv"
+	"regexp"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/google/uuid"
 	"github.com/xuri/excelize/v2"
 	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
-	"github.com/yourusername/telegram-ai-bot/internal/domain/repository"
 )
 
-type excelParser struct{}
-
-// NewExcelParser yangi Excel parser yaratish
-func NewExcelParser() repository.ExcelParser {
-	return &excelParser{}
+// sheetData xotiraga o'qilgan sheet (.xls va .ods): qatorlar matritsasi (GetRows ko'rinishida)
+// va bo'lim sarlavhalarini aniqlash uchun qatorlar ko'rinishi
+type sheetData struct {
+	name   string
+	rows   [][]string
+	styles map[int]rowStyle
 }
 
-// ParseProducts Excel fayldan mahsulotlarni o'qish
-func (e *excelParser) ParseProducts(ctx context.Context, filePath string) ([]entity.Product, error) {
-	f, err := excelize.OpenFile(filePath)
+// streamHeadRows .xlsx sheet boshidan xotiraga o'qiladigan to'la qatorlar soni: sarlavha, ustunlar,
+// raqam formati va bo'limlar shulardan aniqlanadi. Bundan katta sheetlarning qolgan qatorlari
+// excelize iteratori bilan birma-bir o'qiladi (xotira sheet hajmiga qarab o'smaydi).
+const streamHeadRows = 1000
+
+// parseXLSX .xlsx faylni parse qilish (barcha sheetlar)
+func (e *catalogParser) parseXLSX(data []byte, opts entity.ImportOptions, c *importCollector) error {
+	f, err := excelize.OpenReader(bytes.NewReader(data))
 	if err != nil {
-		return nil, fmt.Errorf("failed to open excel file: %w", err)
+		return fmt.Errorf("failed to open excel from bytes: %w", err)
 	}
 	defer f.Close()
 
-	return e.parseExcelFile(f)
+	sheets := f.GetSheetList()
+	if len(sheets) == 0 {
+		return fmt.Errorf("excel file has no sheets")
+	}
+
+	hasMedia := xlsxHasMedia(f)
+	totalRows := 0
+	for _, sheetName := range sheets {
+		if opts.IsSheetExcluded(sheetName) {
+			log.Printf("⏭️ Sheet '%s' excluded by admin", sheetName)
+			continue
+		}
+
+		rows, err := e.parseXLSXSheet(f, sheetName, hasMedia, c)
+		if err != nil {
+			return err
+		}
+		totalRows += rows
+	}
+
+	if totalRows == 0 {
+		return fmt.Errorf("excel file is empty")
+	}
+
+	return nil
 }
 
-// ParseProductsFromBytes byte array dan parse qilish
-func (e *excelParser) ParseProductsFromBytes(ctx context.Context, data []byte, filename string) ([]entity.Product, error) {
-	reader := bytes.NewReader(data)
-	f, err := excelize.OpenReader(reader)
+// parseXLSXSheet bitta .xlsx sheet ni parse qilish; o'qilgan qatorlar sonini qaytaradi.
+// Kichik sheetlar to'liq o'qiladi (birlashtirilgan kataklar va shriftlar bilan), kattalarida
+// faqat boshi xotirada turadi. Faylda rasmlar bo'lsa (hasMedia) ular qatorlarga bog'lanadi:
+// buning uchun excelize sheet ni baribir to'liq yuklaydi, shuning uchun katta sheet boshidagi
+// birlashtirish va shriftlar ham tekshiriladi. Rasmsiz katta sheetda kataklar ko'rinishi o'qilmaydi
+// (xotira sheet hajmiga qarab o'sardi) - bo'lim sarlavhalari joylashuvdan aniqlanadi va bu
+// ImportReport.UnstyledSheets da ko'rsatiladi.
+func (e *catalogParser) parseXLSXSheet(f *excelize.File, sheet string, hasMedia bool, c *importCollector) (int, error) {
+	iter, err := f.Rows(sheet)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open excel from bytes: %w", err)
+		return 0, fmt.Errorf("failed to get rows from sheet %q: %w", sheet, err)
+	}
+	defer iter.Close()
+
+	// Iterator har bir qator raqami uchun (bo'shlari ham) bir marta to'xtaydi - indeks qator raqamiga mos
+	var head [][]string
+	filled := 0
+	more := iter.Next()
+	for ; more && filled < streamHeadRows; more = iter.Next() {
+		row, err := iter.Columns()
+		if err != nil {
+			return 0, fmt.Errorf("failed to read row %d of sheet %q: %w", len(head)+1, sheet, err)
+		}
+		head = append(head, row)
+		if !isEmptyRow(row) {
+			filled++
+		}
 	}
-	defer f.Close()
+	if err := iter.Error(); err != nil {
+		return 0, fmt.Errorf("failed to read sheet %q: %w", sheet, err)
+	}
+
+	if filled == 0 {
+		log.Printf("⏭️ Sheet '%s' is empty", sheet)
+		return 0, nil
+	}
+	c.report.Sheets = append(c.report.Sheets, sheet)
+
+	var pictures map[int][]cellPicture
+	if hasMedia {
+		pictures = xlsxPictures(f, sheet)
+	}
+
+	if !more {
+		for isEmptyRow(head[len(head)-1]) {
+			head = head[:len(head)-1]
+		}
+		log.Printf("📄 Sheet '%s': %d rows", sheet, len(head))
+		if p := e.beginSheet(head, sheet, xlsxRowStyles(f, sheet, head), c); p != nil {
+			p.pictures = pictures
+			p.rows(head)
+		}
+		return len(head), nil
+	}
+
+	log.Printf("📄 Sheet '%s': more than %d rows, reading row by row", sheet, len(head))
+	var styles map[int]rowStyle
+	if hasMedia {
+		styles = xlsxRowStyles(f, sheet, head)
+	} else {
+		c.report.UnstyledSheets = append(c.report.UnstyledSheets, sheet)
+	}
+	p := e.beginSheet(head, sheet, styles, c)
+	p.pictures = pictures
+	for i := p.startRow; i < len(head); i++ {
+		p.row(i, head[i])
+	}
+	head = nil
+
+	i := p.head
+	for ; more; more = iter.Next() {
+		row, err := iter.Columns()
+		if err != nil {
+			return 0, fmt.Errorf("failed to read row %d of sheet %q: %w", i+1, sheet, err)
+		}
+		p.row(i, row)
+		i++
+	}
+	if err := iter.Error(); err != nil {
+		return 0, fmt.Errorf("failed to read sheet %q: %w", sheet, err)
+	}
+	p.finish()
 
-	return e.parseExcelFile(f)
+	log.Printf("📄 Sheet '%s': %d rows", sheet, i)
+	return i, nil
 }
 
-// parseExcelFile Excel faylni parse qilish
-func (e *excelParser) parseExcelFile(f *excelize.File) ([]entity.Product, error) {
-	// Birinchi sheet ni olish
-	sheets := f.GetSheetList()
+// parseWorkbook xotiraga o'qilgan jadval fayli sheetlarini parse qilish (barcha sheetlar)
+func (e *catalogParser) parseWorkbook(sheets []sheetData, opts entity.ImportOptions, c *importCollector) error {
 	if len(sheets) == 0 {
-		return nil, fmt.Errorf("excel file has no sheets")
+		return fmt.Errorf("excel file has no sheets")
 	}
 
-	sheetName := sheets[0]
-	rows, err := f.GetRows(sheetName)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get rows: %w", err)
+	totalRows := 0
+	for _, sheet := range sheets {
+		if opts.IsSheetExcluded(sheet.name) {
+			log.Printf("⏭️ Sheet '%s' excluded by admin", sheet.name)
+			continue
+		}
+
+		rows := sheet.rows
+		if len(rows) == 0 || (len(rows) == 1 && isEmptyRow(rows[0])) {
+			log.Printf("⏭️ Sheet '%s' is empty", sheet.name)
+			continue
+		}
+
+		log.Printf("📄 Sheet '%s': %d rows", sheet.name, len(rows))
+		totalRows += len(rows)
+		c.report.Sheets = append(c.report.Sheets, sheet.name)
+		e.parseSheetRows(rows, sheet.name, sheet.styles, c)
 	}
 
-	if len(rows) == 0 {
-		return nil, fmt.Errorf("excel file is empty")
+	if totalRows == 0 {
+		return fmt.Errorf("excel file is empty")
 	}
 
-	// Debug: Birinchi qatorni chop etish
-	if len(rows) > 0 {
-		log.Printf("📋 Excel first row: %v", rows[0])
-		log.Printf("📊 Total rows: %d", len(rows))
+	return nil
+}
+
+// genericSheetName "Sheet1", "Лист1" kabi standart nomlar (kategoriya emas)
+var genericSheetName = regexp.MustCompile(`(?i)^(sheet|лист|list|varaq|page|sahifa)\s*\d*$`)
+
+// sheetCategory sheet nomidan kategoriya (standart nom bo'lsa bo'sh)
+func sheetCategory(sheetName string) string {
+	name := strings.TrimSpace(sheetName)
+	if name == "" || genericSheetName.MatchString(name) {
+		return ""
+	}
+	return name
+}
+
+// parseSheetRows bitta sheet qatorlarini parse qilish.
+// Sheet nomi kategoriya bo'lsa, kategoriya ustuni yo'q qatorlar shu kategoriyaga tushadi.
+// Jadval ichidagi bo'lim sarlavhalari (styles - Excel dagi birlashtirish va shriftlar, CSV da nil)
+// sheet nomidan ustun turadi. rows indeksi fayldagi qator raqamiga mos (i -> i+1), bo'sh qatorlar ham saqlanadi.
+func (e *catalogParser) parseSheetRows(rows [][]string, sheet string, styles map[int]rowStyle, c *importCollector) {
+	if p := e.beginSheet(rows, sheet, styles, c); p != nil {
+		p.rows(rows)
+	}
+}
+
+// sheetParser bitta sheet ni qatorma-qator parse qilish holati. Sarlavha, ustunlar, jadval
+// formati, raqam formati va bo'lim sarlavhalari sheet boshidagi qatorlardan (head) aniqlanadi;
+// katta .xlsx sheetlarda qolgan qatorlar xotiraga yig'ilmasdan row ga birma-bir beriladi.
+type sheetParser struct {
+	e *catalogParser
+	c *importCollector
+
+	sheet    string
+	sheetCat string
+	head     int // beginSheet ko'rgan qatorlar soni
+
+	startRow  int
+	hasHeader bool
+	header    []string
+	columnMap map[string]int
+	specKeys  map[int]string
+	nameCol   int
+	priceCol  int
+
+	headerCurrency entity.Currency
+	isTableFormat  bool
+	locale         entity.NumberLocale
+	now            time.Time
+
+	sections map[int]entity.SectionLevel
+	section  *sectionState
+
+	pictures map[int][]cellPicture // .xlsx ga joylangan rasmlar qatorlar bo'yicha
+}
+
+// beginSheet sheet boshidagi qatorlardan jadval tuzilishini aniqlash (sheet bo'sh bo'lsa nil)
+func (e *catalogParser) beginSheet(rows [][]string, sheet string, styles map[int]rowStyle, c *importCollector) *sheetParser {
+	sheetCat := sheetCategory(sheet)
+
+	// Boshidagi bo'sh qatorlarni o'tkazib yuborish - header birinchi to'la qatorda
+	top := 0
+	for top < len(rows) && isEmptyRow(rows[top]) {
+		top++
 	}
+	if top == len(rows) {
+		return nil
+	}
+	first := rows[top]
+
+	// Debug: Birinchi qatorni chop etish
+	log.Printf("📋 Excel first row: %v", first)
 
-	if len(rows) == 0 {
-		return nil, fmt.Errorf("excel file has no data")
+	// Import profili: sarlavha va birinchi mahsulot qatori profildan olinadi
+	profile, profileRow, hasProfile := c.selectProfile(rows, top)
+	c.profileCurrency = ""
+	if hasProfile {
+		c.profileCurrency = profile.Currency
+		c.report.Profiles = append(c.report.Profiles, entity.ProfileMatch{Sheet: sheet, Profile: profile.Name, Detected: !c.forceProfile})
+		log.Printf("🧾 Sheet '%s': import profile '%s' (header row %d)", sheet, profile.Name, profileRow+1)
 	}
 
 	// Header qatori borligini tekshirish
 	// Agar birinchi qatorning 2-ustuni raqam bo'lsa, header yo'q
 	hasHeader := true
-	startRow := 1
+	startRow := top + 1
 
-	if len(rows) > 0 && len(rows[0]) > 1 {
-		// 2-ustunni tekshirish (narx bo'lishi kerak)
-		secondCol := strings.TrimSpace(rows[0][1])
-		if _, err := strconv.ParseFloat(strings.ReplaceAll(secondCol, ",", ""), 64); err == nil {
-			// Raqam! Demak bu header emas, data
+	if hasProfile {
+		first = rows[profileRow]
+		startRow = profileRow + 1
+		if profile.DataRow > 0 {
+			startRow = min(max(profile.DataRow-1, startRow), len(rows))
+		}
+	} else if lead := leadingTitles(rows, top, styles); lead > top {
+		// Ustun sarlavhasidan oldingi hujjat sarlavhalari ("Прайс-лист", sana) o'tkazib yuboriladi.
+		// Sarlavha qatori bo'lmasa ular bo'lim sarlavhalari - mahsulotlar bilan birga o'qiladi.
+		first = rows[lead]
+		startRow = lead + 1
+		if looksLikeData(first, c.locale) {
 			hasHeader = false
-			startRow = 0
-			log.Printf("🔍 No header detected - data starts from row 0")
+			startRow = top
+			log.Printf("🔍 No header detected - data starts from row %d", top)
+		} else {
+			log.Printf("⏭️ Skipped %d title rows above the header", lead-top)
 		}
+	} else if looksLikeData(first, c.locale) {
+		// 2-ustunda raqam - demak bu header emas, data
+		hasHeader = false
+		startRow = top
+		log.Printf("🔍 No header detected - data starts from row %d", top)
 	}
 
 	var columnMap map[string]int
+	var specKeys map[int]string
 
 	var header []string
 
 	if hasHeader {
 		// Header row dan column mapping yaratish
-		header = rows[0]
-		columnMap = e.mapColumns(header)
+		header = first
+		columnMap, specKeys = e.mapColumns(header, profile)
 		log.Printf("🗺️ Column mapping from header: %v", columnMap)
 	} else {
 		// Header yo'q - default mapping
@@ -104,9 +305,11 @@ func (e *excelParser) parseExcelFile(f *excelize.File) ([]entity.Product, error)
 			"name":  0, // 1-ustun: nom
 			"price": 1, // 2-ustun: narx
 		}
-		if len(rows[0]) > 2 {
+		if len(first) > 2 {
 			columnMap["category"] = 2 // 3-ustun: kategoriya (agar bor bo'lsa)
 		}
+		c.guess(sheet, "name", 0, "sarlavha qatori yo'q, 1-ustun nom deb olindi")
+		c.guess(sheet, "price", 1, "sarlavha qatori yo'q, 2-ustun narx deb olindi")
 		log.Printf("🗺️ Default column mapping (no header): %v", columnMap)
 	}
 
@@ -114,6 +317,10 @@ func (e *excelParser) parseExcelFile(f *excelize.File) ([]entity.Product, error)
 	nameCol := 0
 	if idx, ok := columnMap["name"]; ok {
 		nameCol = idx
+	} else {
+		columnMap["name"] = 0
+		c.guess(sheet, "name", 0, "nom ustuni topilmadi, 1-ustun olindi")
+		log.Printf("⚠️ No name column found, using column 0")
 	}
 
 	priceCol := -1
@@ -121,25 +328,27 @@ func (e *excelParser) parseExcelFile(f *excelize.File) ([]entity.Product, error)
 		priceCol = idx
 	}
 	if priceCol == -1 {
-		if guessed := e.detectPriceColumn(rows, startRow); guessed >= 0 {
+		if guessed := e.detectPriceColumn(rows, startRow, nameCol, columnMap); guessed >= 0 {
 			priceCol = guessed
-			columnMap["price"] = guessed
+			c.guess(sheet, "price", guessed, "narx ustuni sarlavhadan topilmadi, qiymatlariga qarab tanlandi")
 			log.Printf("🧠 Guessed price column: %d", guessed)
-		} else if len(rows[0]) > 1 {
+		} else if len(first) > 1 {
 			priceCol = 1
+			c.guess(sheet, "price", 1, "narx ustuni topilmadi, 2-ustun olindi")
 			log.Printf("⚠️ Price column not found, falling back to column 1")
 		} else {
 			priceCol = 0
+			c.guess(sheet, "price", 0, "narx ustuni topilmadi, 1-ustun olindi")
 			log.Printf("⚠️ Price column not found, using column 0")
 		}
+		columnMap["price"] = priceCol
 	}
 
-	categoryCol, hasCategory := columnMap["category"]
-	descriptionCol, hasDescription := columnMap["description"]
-	stockCol, hasStock := columnMap["stock"]
-
-	var products []entity.Product
-	now := time.Now()
+	// Narx sarlavhasidagi valyuta ("Narx, so'm", "Цена (USD)") - butun ustun uchun
+	var headerCurrency entity.Currency
+	if hasHeader {
+		headerCurrency = detectCurrency(cellAt(header, priceCol))
+	}
 
 	// Format aniqlash: Standart jadval (nom, narx, ...) yoki Side-by-side (nom1, narx1, nom2, narx2)
 	isTableFormat := true
@@ -154,186 +363,318 @@ func (e *excelParser) parseExcelFile(f *excelize.File) ([]entity.Product, error)
 		log.Printf("📊 Detected: SIDE-BY-SIDE format (Name1 | Price1 | Name2 | Price2 | ...)")
 	}
 
-	// Standart jadval formatini parse qilish
+	// Raqam formati (1,299.00 yoki 1.299,00) butun sheet narxlari bo'yicha tanlanadi
+	c.beginNumbers(sheet, priceSamples(rows[startRow:], priceCol, isTableFormat))
+	locale := c.numberLocale()
+	log.Printf("🔢 Number format for sheet '%s': %s", sheet, locale)
+
+	p := &sheetParser{
+		e:              e,
+		c:              c,
+		sheet:          sheet,
+		sheetCat:       sheetCat,
+		head:           len(rows),
+		startRow:       startRow,
+		hasHeader:      hasHeader,
+		header:         header,
+		columnMap:      columnMap,
+		specKeys:       specKeys,
+		nameCol:        nameCol,
+		priceCol:       priceCol,
+		headerCurrency: headerCurrency,
+		isTableFormat:  isTableFormat,
+		locale:         locale,
+		now:            time.Now(),
+		section:        newSectionState(),
+	}
+
+	// Bo'lim sarlavhalari ("Videokartalar", keyin "ASUS") keyingi sarlavhagacha qatorlarga qo'llanadi
 	if isTableFormat {
-		for i := startRow; i < len(rows); i++ {
-			row := rows[i]
+		p.sections, p.section.nested = sectionRows(rows, startRow, nameCol, priceCol, styles, locale)
+	}
+	return p
+}
 
-			// Bo'sh qatorlarni skip qilish
-			if len(row) == 0 || isEmptyRow(row) {
-				continue
-			}
+// rows xotiradagi barcha qatorlarni parse qilish (rows - beginSheet ga berilgan qatorlar)
+func (p *sheetParser) rows(rows [][]string) {
+	for i := p.startRow; i < len(rows); i++ {
+		p.row(i, rows[i])
+	}
+	p.finish()
+}
 
-			// Kamida 2 ta ustun bo'lishi kerak (nom va narx)
-			if len(row) <= nameCol || len(row) <= priceCol {
-				continue
-			}
+// row sheet ning i-qatorini (0 dan boshlanadi) parse qilish
+func (p *sheetParser) row(i int, row []string) {
+	p.c.advance(p.sheet)
 
-			nameStr := strings.TrimSpace(row[nameCol])
-			priceStr := strings.TrimSpace(row[priceCol])
+	// Bo'sh qatorlarni skip qilish
+	if len(row) == 0 || isEmptyRow(row) {
+		return
+	}
+	if p.isTableFormat {
+		p.tableRow(i, row)
+	} else {
+		p.sideBySideRow(i, row)
+	}
+}
 
-			// Bo'sh bo'lsa skip
-			if nameStr == "" || priceStr == "" {
-				continue
-			}
+// finish sheet oxiri
+func (p *sheetParser) finish() {
+	p.section.flush(p.c, p.sheet)
+}
 
-			// Narxni parse qilish
-			price, err := e.parsePrice(priceStr)
-			if err != nil || price == 0 {
-				log.Printf("⚠️ Row %d: Invalid price '%s' - skipping", i, priceStr)
-				continue
-			}
+// tableRow standart jadval formatidagi qator (Name | Price | ...)
+func (p *sheetParser) tableRow(i int, row []string) {
+	c, sheet := p.c, p.sheet
+	nameCol, priceCol, header := p.nameCol, p.priceCol, p.header
 
-			// Nom juda qisqa bo'lsa skip
-			if len(nameStr) < 3 {
-				continue
-			}
+	if i < p.head {
+		if level, ok := p.sections[i]; ok {
+			p.section.enter(c, sheet, i+1, sectionTitle(row), level)
+			return
+		}
+	} else {
+		// Katta sheet ning qatorma-qator o'qilgan qismi (shriftlar va birlashtirishlar ma'lum emas)
+		isSection := isSectionRow(row, rowStyle{}, false, nameCol, priceCol, p.locale)
+		title := ""
+		if isSection {
+			title = sectionTitle(row)
+		}
+		p.section.stream(c, sheet, i+1, title, isSection)
+		if isSection {
+			return
+		}
+	}
+	c.report.TotalRows++
 
-			// Mahsulot yaratish
-			product := entity.Product{
-				ID:        uuid.New().String(),
-				Name:      nameStr,
-				Price:     price,
-				Category:  "Boshqa",
-				CreatedAt: now,
-				UpdatedAt: now,
-				Specs:     make(map[string]string),
-			}
+	categoryCol, hasCategory := p.columnMap["category"]
+	descriptionCol, hasDescription := p.columnMap["description"]
+	stockCol, hasStock := p.columnMap["stock"]
+	skuCol, hasSKU := p.columnMap["sku"]
+	currencyCol, hasCurrency := p.columnMap["currency"]
+	imageCol, hasImage := p.columnMap[entity.FieldImage]
 
-			// Kategoriya - Excel dan yoki nomga qarab aniqlaymiz
-			if hasCategory && categoryCol < len(row) {
-				if category := strings.TrimSpace(row[categoryCol]); category != "" {
-					product.Category = category
-				} else {
-					product.Category = e.detectCategory(nameStr)
-				}
-			} else {
-				product.Category = e.detectCategory(nameStr)
-			}
+	nameStr := cellAt(row, nameCol)
+	priceStr := cellAt(row, priceCol)
 
-			// Tavsif
-			if hasDescription && descriptionCol < len(row) {
-				product.Description = strings.TrimSpace(row[descriptionCol])
-			}
+	// Nom va narxni tekshirish (yaroqsiz qator hisobotga yoziladi)
+	price, kind, reason := p.e.validateRow(nameStr, priceStr, p.locale)
+	if kind != "" {
+		c.reject(kind, sheet, i+1, nameStr, priceStr, reason)
+		return
+	}
 
-			// Ombordagi son
-			if hasStock && stockCol < len(row) {
-				if stockStr := strings.TrimSpace(row[stockCol]); stockStr != "" {
-					if stock, err := e.parsePrice(stockStr); err == nil {
-						product.Stock = int(stock)
-					}
-				}
-			}
+	var columnCurrency entity.Currency
+	if hasCurrency {
+		columnCurrency = detectCurrency(cellAt(row, currencyCol))
+	}
+
+	// Mahsulot yaratish
+	product := entity.Product{
+		ID:        uuid.New().String(),
+		Name:      nameStr,
+		Price:     price.Amount,
+		Currency:  c.priceCurrency(price.Currency, columnCurrency, p.headerCurrency),
+		Category:  "Boshqa",
+		CreatedAt: p.now,
+		UpdatedAt: p.now,
+		Specs:     make(map[string]string),
+	}
+
+	// Kategoriya - Excel dan, bo'lim sarlavhasidan, sheet nomidan yoki nomga qarab aniqlaymiz
+	category := ""
+	if hasCategory {
+		category = cellAt(row, categoryCol)
+	}
+	groupCat := p.sheetCat
+	if p.section.category != "" {
+		groupCat = p.section.category
+	}
+	product.Category, product.CategoryAuto = c.fallbackCategory(category, groupCat, nameStr)
+
+	// Tavsif
+	if hasDescription {
+		product.Description = cellAt(row, descriptionCol)
+	}
+
+	// Artikul
+	if hasSKU {
+		product.SKU = cellAt(row, skuCol)
+	}
 
-			// Qo'shimcha ustunlarni specs ga qo'shish (header bo'lsa nomlarini ishlatamiz)
-			usedCols := map[int]struct{}{nameCol: {}, priceCol: {}}
-			if hasCategory {
-				usedCols[categoryCol] = struct{}{}
+	// Ombordagi son
+	if hasStock {
+		if stockStr := cellAt(row, stockCol); stockStr != "" {
+			if stock, err := parsePrice(stockStr, p.locale); err == nil {
+				product.Stock = int(stock.Amount)
+				product.StockTracked = true
 			}
-			if hasDescription {
-				usedCols[descriptionCol] = struct{}{}
+		}
+	}
+
+	// Rasm: qatorga joylangan rasm (avval rasm ustunidagisi), bo'lmasa rasm ustunidagi havola
+	if image, ok := rowPicture(p.pictures[i], imageCol, hasImage); ok {
+		product.Image = c.addImage(image)
+	} else if hasImage {
+		product.Image = c.imageURL(cellAt(row, imageCol), sheet, i+1, nameStr)
+	}
+
+	// Qo'shimcha ustunlarni specs ga qo'shish (header bo'lsa nomlarini ishlatamiz)
+	usedCols := map[int]struct{}{nameCol: {}, priceCol: {}}
+	if hasCategory {
+		usedCols[categoryCol] = struct{}{}
+	}
+	if hasDescription {
+		usedCols[descriptionCol] = struct{}{}
+	}
+	if hasStock {
+		usedCols[stockCol] = struct{}{}
+	}
+	if hasSKU {
+		usedCols[skuCol] = struct{}{}
+	}
+	if hasCurrency {
+		usedCols[currencyCol] = struct{}{}
+	}
+	if hasImage {
+		usedCols[imageCol] = struct{}{}
+	}
+
+	if p.hasHeader {
+		for idx, raw := range row {
+			if _, used := usedCols[idx]; used {
+				continue
 			}
-			if hasStock {
-				usedCols[stockCol] = struct{}{}
+			value := strings.TrimSpace(raw)
+			if value == "" {
+				continue
 			}
 
-			if hasHeader {
-				for idx, raw := range row {
-					if _, used := usedCols[idx]; used {
-						continue
-					}
-					value := strings.TrimSpace(raw)
-					if value == "" {
-						continue
-					}
-
-					key := fmt.Sprintf("Extra_%d", idx)
-					if idx < len(header) && strings.TrimSpace(header[idx]) != "" {
-						key = strings.TrimSpace(header[idx])
-					}
-					product.Specs[key] = value
-				}
-			} else {
-				if len(row) > 2 {
-					for j := 2; j < len(row); j++ {
-						value := strings.TrimSpace(row[j])
-						if value != "" {
-							key := fmt.Sprintf("Extra_%d", j)
-							product.Specs[key] = value
-						}
-					}
+			key := fmt.Sprintf("Extra_%d", idx)
+			if idx < len(header) && strings.TrimSpace(header[idx]) != "" {
+				key = strings.TrimSpace(header[idx])
+			}
+			if override, ok := p.specKeys[idx]; ok {
+				if override == "" {
+					continue // profilda "skip"
 				}
+				key = override
 			}
-
-			log.Printf("✅ Found: %s - $%.2f (category: %s)", product.Name, product.Price, product.Category)
-			products = append(products, product)
+			product.Specs[key] = value
 		}
 	} else {
-		// Side-by-side formatni parse qilish
-		// Pattern: [Nom1, Narx1, Nom2, Narx2, ...]
-		for i := startRow; i < len(rows); i++ {
-			row := rows[i]
-
-			// Bo'sh qatorlarni skip qilish
-			if len(row) == 0 || isEmptyRow(row) {
-				continue
+		if len(row) > 2 {
+			for j := 2; j < len(row); j++ {
+				value := strings.TrimSpace(row[j])
+				if value != "" {
+					key := fmt.Sprintf("Extra_%d", j)
+					product.Specs[key] = value
+				}
 			}
+		}
+	}
 
-			log.Printf("📝 Row %d: %d columns", i, len(row))
+	p.section.apply(c, &product)
+	c.notePrice(&product, priceStr, price, sheet, i+1)
+	c.accept(product, sheet, i+1)
+}
 
-			// Qatordagi barcha mahsulotlarni topish
-			for colIdx := 0; colIdx < len(row); colIdx += 2 {
-				// Nom va narx borligini tekshirish
-				if colIdx+1 >= len(row) {
-					break
-				}
+// sideBySideRow yonma-yon formatdagi qator: [Nom1, Narx1, Nom2, Narx2, ...]
+func (p *sheetParser) sideBySideRow(i int, row []string) {
+	c, sheet := p.c, p.sheet
+	c.report.TotalRows++
 
-				nameStr := strings.TrimSpace(row[colIdx])
-				priceStr := strings.TrimSpace(row[colIdx+1])
+	// Qatordagi barcha mahsulotlarni topish
+	for colIdx := 0; colIdx < len(row); colIdx += 2 {
+		// Nom va narx borligini tekshirish
+		if colIdx+1 >= len(row) {
+			break
+		}
 
-				// Bo'sh bo'lsa skip
-				if nameStr == "" && priceStr == "" {
-					continue
-				}
+		nameStr := strings.TrimSpace(row[colIdx])
+		priceStr := strings.TrimSpace(row[colIdx+1])
 
-				// Agar narx raqam bo'lmasa, skip
-				price, err := e.parsePrice(priceStr)
-				if err != nil || price == 0 {
-					continue
-				}
+		// Bo'sh bo'lsa skip
+		if nameStr == "" && priceStr == "" {
+			continue
+		}
 
-				// Agar nom juda qisqa bo'lsa skip
-				if len(nameStr) < 3 {
-					continue
-				}
+		price, kind, reason := p.e.validateRow(nameStr, priceStr, p.locale)
+		if kind != "" {
+			c.reject(kind, sheet, i+1, nameStr, priceStr, reason)
+			continue
+		}
 
-				// Mahsulot yaratish
-				product := entity.Product{
-					ID:        uuid.New().String(),
-					Name:      nameStr,
-					Price:     price,
-					Category:  "Boshqa",
-					CreatedAt: now,
-					UpdatedAt: now,
-					Specs:     make(map[string]string),
-				}
+		// Mahsulot yaratish
+		product := entity.Product{
+			ID:        uuid.New().String(),
+			Name:      nameStr,
+			Price:     price.Amount,
+			Currency:  c.priceCurrency(price.Currency),
+			Category:  "Boshqa",
+			CreatedAt: p.now,
+			UpdatedAt: p.now,
+			Specs:     make(map[string]string),
+		}
 
-				// Kategoriyani aniqlash
-				product.Category = e.detectCategory(nameStr)
+		// Kategoriyani aniqlash
+		product.Category, product.CategoryAuto = c.fallbackCategory("", p.sheetCat, nameStr)
+		c.notePrice(&product, priceStr, price, sheet, i+1)
+		c.accept(product, sheet, i+1)
+	}
+}
+
+// validateRow nom va narxni tekshirish. Narx katagida valyuta yozilgan bo'lsa u ham qaytadi.
+// Qator yaroqsiz bo'lsa muammo turi va sababi qaytadi (kind bo'sh bo'lsa qator yaroqli).
+func (e *catalogParser) validateRow(name, priceStr string, locale entity.NumberLocale) (priceValue, entity.ImportIssueKind, string) {
+	switch {
+	case name == "" && priceStr == "":
+		return priceValue{}, entity.ImportIssueSkipped, "nom va narx bo'sh"
+	case name == "":
+		return priceValue{}, entity.ImportIssueSkipped, "nom bo'sh"
+	case priceStr == "":
+		return priceValue{}, entity.ImportIssueSkipped, "narx bo'sh"
+	}
+
+	price, err := parsePrice(priceStr, locale)
+	switch {
+	case err != nil:
+		return priceValue{}, entity.ImportIssueSkipped, fmt.Sprintf("narx o'qilmadi: %q", priceStr)
+	case price.Amount == 0:
+		return priceValue{}, entity.ImportIssueZeroPrice, "narx nol"
+	case price.Amount < 0:
+		return priceValue{}, entity.ImportIssueSkipped, "narx manfiy"
+	case len(name) < 3:
+		return priceValue{}, entity.ImportIssueSkipped, "nom juda qisqa (3 belgidan kam)"
+	}
+	return price, "", ""
+}
 
-				log.Printf("✅ Found: %s - $%.2f (category: %s)", product.Name, product.Price, product.Category)
-				products = append(products, product)
+// priceSamples raqam formatini aniqlash uchun narx kataklari (side-by-side da har ikkinchi ustun)
+func priceSamples(rows [][]string, priceCol int, isTableFormat bool) []string {
+	var samples []string
+	for _, row := range rows {
+		if isTableFormat {
+			if value := cellAt(row, priceCol); value != "" {
+				samples = append(samples, value)
+			}
+			continue
+		}
+		for col := 1; col < len(row); col += 2 {
+			if value := cellAt(row, col); value != "" {
+				samples = append(samples, value)
 			}
 		}
 	}
+	return samples
+}
 
-	log.Printf("📦 Total products parsed: %d", len(products))
-
-	if len(products) == 0 {
-		return nil, fmt.Errorf("no valid products found in excel file (parsed %d rows, but all were invalid)", len(rows)-1)
+// cellAt qatordagi katak qiymati (ustun yo'q bo'lsa bo'sh)
+func cellAt(row []string, col int) string {
+	if col < 0 || col >= len(row) {
+		return ""
 	}
-
-	return products, nil
+	return strings.TrimSpace(row[col])
 }
 
 // isEmptyRow qator bo'sh yoki yo'qligini tekshirish
@@ -349,7 +690,7 @@ func isEmptyRow(row []string) bool {
 // detectTableFormat Excel formatini aniqlash
 // true = Standart jadval (Nom | Narx | ...)
 // false = Side-by-side (Nom1 | Narx1 | Nom2 | Narx2)
-func (e *excelParser) detectTableFormat(rows [][]string, startRow int, priceCol int) bool {
+func (e *catalogParser) detectTableFormat(rows [][]string, startRow int, priceCol int) bool {
 	if len(rows) <= startRow {
 		return true // Default: table format
 	}
@@ -373,7 +714,7 @@ func (e *excelParser) detectTableFormat(rows [][]string, startRow int, priceCol
 
 		// priceCol ustunida narx bo'lsa - bu table format
 		priceCandidate := strings.TrimSpace(row[priceCol])
-		if _, err := e.parsePrice(priceCandidate); err == nil {
+		if _, err := parsePrice(priceCandidate, entity.NumberLocaleAuto); err == nil {
 			validPriceCount++
 		}
 	}
@@ -387,8 +728,10 @@ func (e *excelParser) detectTableFormat(rows [][]string, startRow int, priceCol
 	return float64(validPriceCount)/float64(totalChecked) > 0.7
 }
 
-// detectPriceColumn narx ustunini topish (agar headerda topilmasa)
-func (e *excelParser) detectPriceColumn(rows [][]string, startRow int) int {
+// detectPriceColumn narx ustunini topish (agar headerda topilmasa).
+// Narx odatda nomdan keyin keladi, shuning uchun nom ustuni va undan chapdagilar
+// (tartib raqami "№" kabi) hamda boshqa maydonga bog'langan ustunlar ko'rilmaydi.
+func (e *catalogParser) detectPriceColumn(rows [][]string, startRow, nameCol int, columnMap map[string]int) int {
 	maxCols := 0
 	limitRows := startRow + 15
 	if limitRows > len(rows) {
@@ -401,10 +744,20 @@ func (e *excelParser) detectPriceColumn(rows [][]string, startRow int) int {
 		}
 	}
 
+	skip := make(map[int]bool)
+	for _, field := range []string{"category", "description", "stock", "sku", "currency"} {
+		if idx, ok := columnMap[field]; ok {
+			skip[idx] = true
+		}
+	}
+
 	bestCol := -1
 	bestCount := 0
 
-	for col := 0; col < maxCols; col++ {
+	for col := nameCol + 1; col < maxCols; col++ {
+		if skip[col] {
+			continue
+		}
 		count := 0
 		for i := startRow; i < limitRows; i++ {
 			row := rows[i]
@@ -415,7 +768,7 @@ func (e *excelParser) detectPriceColumn(rows [][]string, startRow int) int {
 			if val == "" {
 				continue
 			}
-			if _, err := e.parsePrice(val); err == nil {
+			if _, err := parsePrice(val, entity.NumberLocaleAuto); err == nil {
 				count++
 			}
 		}
@@ -433,221 +786,147 @@ func (e *excelParser) detectPriceColumn(rows [][]string, startRow int) int {
 	return -1
 }
 
-// mapColumns header qatoridan column mapping yaratish
-func (e *excelParser) mapColumns(header []string) map[string]int {
+// headerKeywords sarlavha kalit so'zlari (maydonlar tekshirish tartibida). Oddiy kalit so'z
+// sarlavhadagi alohida so'zga teng bo'lishi kerak (5+ harfli bo'lsa so'z shu bilan boshlanishi
+// yetarli: "narxi", "kategoriyasi"); bo'shliq yoki belgili iboralar matn ichidan qidiriladi;
+// "=" bilan boshlanganlari butun sarlavhaga teng bo'lishi kerak: "Tur" - kategoriya, lekin
+// "Temperatura" yoki "Memory type" emas; "$" - narx, lekin "Kurs $" emas.
+var headerKeywords = []struct {
+	field    string
+	keywords []string
+}{
+	// SKU / artikul - nomdan oldin ("Product code", "Mahsulot kodi" nom emas)
+	{entity.FieldSKU, []string{"sku", "artikul", "article", "артикул", "код", "kod", "kodi", "code", "part number", "p/n", "mpn"}},
+	// Rasm - nomdan oldin ("Mahsulot rasmi", "Product image" nom emas)
+	{entity.FieldImage, []string{"image", "rasm", "=rasmi", "mahsulot rasmi", "tovar rasmi",
+		"photo", "foto", "фото", "picture", "картинка", "изображение", "img", "surat"}},
+	{entity.FieldName, []string{"name", "nom", "nomi", "название", "наименование", "product", "mahsulot", "tovar", "товар"}},
+	{entity.FieldCategory, []string{"category", "kategoriya", "категория", "группа", "=tur", "=turi", "=тип", "=type", "=guruh"}},
+	// Valyuta - narxdan oldin ("Narx valyutasi" narx ustuni emas)
+	{entity.FieldCurrency, []string{"currency", "valyuta", "валюта"}},
+	{entity.FieldPrice, []string{"price", "narx", "narxi", "summa", "сумма", "цена", "цены", "cost", "стоимость",
+		"=$", "=usd", "=uzs", "=сум", "=so'm", "=som"}},
+	{entity.FieldDescription, []string{"description", "tavsif", "malumot", "ma'lumot", "описание", "info", "details"}},
+	{entity.FieldStock, []string{"stock", "soni", "miqdor", "количество", "остаток", "qty", "quantity"}},
+}
+
+// headerField sarlavha qaysi maydonga tegishli (xususiyat ustuni bo'lsa bo'sh)
+func headerField(header string) string {
+	header = entity.NormalizeHeader(header)
+	whole := strings.Trim(header, " ()[],.:;")
+	words := strings.FieldsFunc(header, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("'’‘ʻ", r)
+	})
+
+	for _, group := range headerKeywords {
+		for _, keyword := range group.keywords {
+			switch {
+			case strings.HasPrefix(keyword, "="):
+				if whole == keyword[1:] {
+					return group.field
+				}
+			case strings.IndexFunc(keyword, func(r rune) bool { return !unicode.IsLetter(r) }) >= 0:
+				if strings.Contains(header, keyword) {
+					return group.field
+				}
+			default:
+				for _, word := range words {
+					if word == keyword || (len([]rune(keyword)) >= 5 && strings.HasPrefix(word, keyword)) {
+						return group.field
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// mapColumns header qatoridan column mapping yaratish. Profil berilgan bo'lsa uning
+// sarlavhalari kalit so'zlardan ustun turadi. Ikkinchi qiymat - xususiyat nomi sarlavhadan
+// farq qiladigan ustunlar (profildagi "spec:Kalit"); bo'sh nom - ustun o'tkazib yuboriladi.
+func (e *catalogParser) mapColumns(header []string, profile *entity.ImportProfile) (map[string]int, map[int]string) {
 	columnMap := make(map[string]int)
+	specKeys := make(map[int]string)
+
+	// Profil maydonlari birinchi band qilinadi, keyin qolgan ustunlar kalit so'zlardan
+	fromProfile := make(map[int]bool)
+	if profile != nil {
+		for i, col := range header {
+			target, ok := profile.ColumnTarget(col)
+			if !ok {
+				continue
+			}
+			fromProfile[i] = true
+			switch {
+			case target == entity.ColumnSkip:
+				specKeys[i] = ""
+			case strings.HasPrefix(target, entity.ColumnSpecPrefix):
+				specKeys[i] = strings.TrimSpace(strings.TrimPrefix(target, entity.ColumnSpecPrefix))
+			default:
+				if _, taken := columnMap[target]; !taken {
+					columnMap[target] = i
+					log.Printf("✅ Profile '%s': mapped '%s' to column %d", profile.Name, target, i)
+				}
+			}
+		}
+	}
 
 	for i, col := range header {
+		if fromProfile[i] {
+			continue
+		}
 		colName := strings.ToLower(strings.TrimSpace(col))
 
 		// Debug
 		log.Printf("🔍 Checking column %d: '%s'", i, colName)
 
-		// Standart maydonlar uchun mapping - JUDA KO'P VARIANTLAR
-		switch {
-		// NAME variants
-		case contains(colName, "name", "nom", "nomi", "название", "product", "mahsulot", "tovar"):
-			columnMap["name"] = i
-			log.Printf("✅ Mapped 'name' to column %d", i)
-
-		// CATEGORY variants
-		case contains(colName, "category", "kategoriya", "tur", "тип", "категория", "type"):
-			columnMap["category"] = i
-			log.Printf("✅ Mapped 'category' to column %d", i)
-
-		// PRICE variants
-		case contains(colName, "price", "narx", "summa", "цена", "сум", "som", "cost", "$", "usd", "uzs"):
-			columnMap["price"] = i
-			log.Printf("✅ Mapped 'price' to column %d", i)
-
-		// DESCRIPTION variants
-		case contains(colName, "description", "tavsif", "malumot", "описание", "info", "details"):
-			columnMap["description"] = i
-			log.Printf("✅ Mapped 'description' to column %d", i)
-
-		// STOCK variants
-		case contains(colName, "stock", "soni", "miqdor", "количество", "qty", "quantity"):
-			columnMap["stock"] = i
-			log.Printf("✅ Mapped 'stock' to column %d", i)
-
-		default:
+		field := headerField(colName)
+		if field == "" {
 			// Boshqa barcha columnlarni specs sifatida saqlash
 			if colName != "" {
 				columnMap[colName] = i
 				log.Printf("📝 Mapped '%s' to specs (column %d)", colName, i)
 			}
+			continue
 		}
-	}
 
-	// Agar asosiy maydonlar topilmasa, birinchi ustunlarni default mapping qilish
-	if _, ok := columnMap["name"]; !ok && len(header) > 0 {
-		columnMap["name"] = 0
-		log.Printf("⚠️ No name column found, using column 0")
-	}
-	if _, ok := columnMap["price"]; !ok && len(header) > 1 {
-		columnMap["price"] = 1
-		log.Printf("⚠️ No price column found, using column 1")
-	}
-
-	return columnMap
-}
-
-// contains tekshirish uchun helper
-func contains(str string, keywords ...string) bool {
-	for _, keyword := range keywords {
-		if strings.Contains(str, keyword) {
-			return true
+		// Birinchi mos ustun olinadi: keyingi "Type", "Max temperature" kabi
+		// xususiyat ustunlari asosiy maydonni bosib ketmaydi va specs ga tushadi
+		if _, taken := columnMap[field]; taken {
+			log.Printf("📝 Column %d ('%s') also looks like '%s', keeping it as spec", i, colName, field)
+			continue
 		}
-	}
-	return false
-}
-
-// parsePrice narxni parse qilish
-func (e *excelParser) parsePrice(priceStr string) (float64, error) {
-	// Turli formatlarni qo'llab-quvvatlash
-	priceStr = strings.ToLower(strings.TrimSpace(priceStr))
-
-	// Bo'sh bo'lsa
-	if priceStr == "" {
-		return 0, fmt.Errorf("empty price")
-	}
-
-	// Tozalash
-	priceStr = strings.ReplaceAll(priceStr, ",", "")
-	priceStr = strings.ReplaceAll(priceStr, " ", "")
-	priceStr = strings.ReplaceAll(priceStr, "$", "")
-	priceStr = strings.ReplaceAll(priceStr, "€", "")
-	priceStr = strings.ReplaceAll(priceStr, "£", "")
-	priceStr = strings.ReplaceAll(priceStr, "₽", "")
-	priceStr = strings.ReplaceAll(priceStr, "¥", "")
-	priceStr = strings.ReplaceAll(priceStr, "so'm", "")
-	priceStr = strings.ReplaceAll(priceStr, "soʻm", "")
-	priceStr = strings.ReplaceAll(priceStr, "soum", "")
-	priceStr = strings.ReplaceAll(priceStr, "som", "")
-	priceStr = strings.ReplaceAll(priceStr, "сум", "")
-	priceStr = strings.ReplaceAll(priceStr, "сом", "")
-	priceStr = strings.ReplaceAll(priceStr, "sum", "")
-	priceStr = strings.ReplaceAll(priceStr, "uzs", "")
-	priceStr = strings.ReplaceAll(priceStr, "usd", "")
-	priceStr = strings.ReplaceAll(priceStr, "eur", "")
-	priceStr = strings.ReplaceAll(priceStr, "руб", "")
-	priceStr = strings.ReplaceAll(priceStr, "р", "")
-
-	price, err := strconv.ParseFloat(priceStr, 64)
-	if err != nil {
-		return 0, fmt.Errorf("invalid price format: %s", priceStr)
+		columnMap[field] = i
+		log.Printf("✅ Mapped '%s' to column %d", field, i)
 	}
 
-	return price, nil
+	// Topilmagan nom/narx ustunlari parseSheetRows da taxmin qilinadi (hisobotga yoziladi)
+	return columnMap, specKeys
 }
 
-// detectCategory mahsulot nomidan kategoriyani aniqlash
-// MUHIM: Eng aniq belgilarni birinchi tekshiramiz!
-func (e *excelParser) detectCategory(name string) string {
-	nameLower := strings.ToLower(name)
-
-	// Monitor - BIRINCHI (aniq kategoriya)
-	if strings.Contains(nameLower, "monitor") || strings.Contains(nameLower, "монитор") ||
-		strings.Contains(nameLower, "display") || strings.Contains(nameLower, "screen") ||
-		strings.Contains(nameLower, "144hz") || strings.Contains(nameLower, "165hz") ||
-		strings.Contains(nameLower, "240hz") || strings.Contains(nameLower, "300hz") ||
-		strings.Contains(nameLower, "ips") || strings.Contains(nameLower, "va panel") ||
-		strings.Contains(nameLower, "curved") || strings.Contains(nameLower, "ultrawide") {
-		return "Monitor"
-	}
-
-	// SSD/Storage
-	if strings.Contains(nameLower, "ssd") || strings.Contains(nameLower, "nvme") ||
-		strings.Contains(nameLower, "hdd") || strings.Contains(nameLower, "hard drive") {
-		return "Storage"
-	}
-
-	// RAM - DDR aniq belgi
-	if strings.Contains(nameLower, "ddr4") || strings.Contains(nameLower, "ddr5") ||
-		strings.Contains(nameLower, "ddr3") || strings.Contains(nameLower, "ddr ") {
-		return "RAM"
-	}
-
-	// CPU - Processor modellari
-	if strings.Contains(nameLower, "intel") || strings.Contains(nameLower, "amd") ||
-		strings.Contains(nameLower, "ryzen") || strings.Contains(nameLower, "core i") ||
-		strings.Contains(nameLower, "processor") || strings.Contains(nameLower, "xeon") {
-		return "CPU"
-	}
-
-	// GPU - Video karta
-	if strings.Contains(nameLower, "rtx") || strings.Contains(nameLower, "gtx") ||
-		strings.Contains(nameLower, "radeon") || strings.Contains(nameLower, "rx ") ||
-		strings.Contains(nameLower, "geforce") || strings.Contains(nameLower, "nvidia") ||
-		strings.Contains(nameLower, "arc") || strings.Contains(nameLower, "inno3d") {
-		return "GPU"
-	}
-
-	// Motherboard - Ona plata
-	if strings.Contains(nameLower, "motherboard") ||
-		strings.Contains(nameLower, "b450") || strings.Contains(nameLower, "b550") ||
-		strings.Contains(nameLower, "b650") || strings.Contains(nameLower, "b760") ||
-		strings.Contains(nameLower, "x570") || strings.Contains(nameLower, "x670") ||
-		strings.Contains(nameLower, "x870") || strings.Contains(nameLower, "z690") ||
-		strings.Contains(nameLower, "z790") || strings.Contains(nameLower, "lga1700") ||
-		strings.Contains(nameLower, "lga1851") || strings.Contains(nameLower, "am4") ||
-		strings.Contains(nameLower, "am5") {
-		return "Motherboard"
-	}
-
-	// PSU - Quvvat bloki
-	if strings.Contains(nameLower, "psu") || strings.Contains(nameLower, "power supply") ||
-		strings.Contains(nameLower, "блок питания") || strings.Contains(nameLower, "watt") ||
-		strings.Contains(nameLower, "w ") && (strings.Contains(nameLower, "80+") || strings.Contains(nameLower, "bronze") || strings.Contains(nameLower, "gold")) {
-		return "PSU"
-	}
-
-	// Case - Korpus
-	if strings.Contains(nameLower, "case") || strings.Contains(nameLower, "корпус") ||
-		strings.Contains(nameLower, "chassis") || strings.Contains(nameLower, "tower") {
-		return "Case"
-	}
-
-	// Cooling - Sovutgich
-	if strings.Contains(nameLower, "cooler") || strings.Contains(nameLower, "cooling") ||
-		strings.Contains(nameLower, "fan") || strings.Contains(nameLower, "aio") ||
-		strings.Contains(nameLower, "liquid") || strings.Contains(nameLower, "air cooler") {
-		return "Cooling"
-	}
-
-	// Chair - Stul
-	if strings.Contains(nameLower, "chair") || strings.Contains(nameLower, "стул") ||
-		strings.Contains(nameLower, "gaming chair") || strings.Contains(nameLower, "office chair") {
-		return "Chair"
-	}
-
-	// Desk - Stol
-	if strings.Contains(nameLower, "desk") || strings.Contains(nameLower, "стол") ||
-		strings.Contains(nameLower, "table") || strings.Contains(nameLower, "gaming desk") {
-		return "Desk"
-	}
-
-	// Keyboard
-	if strings.Contains(nameLower, "keyboard") || strings.Contains(nameLower, "клавиатура") {
-		return "Keyboard"
-	}
-
-	// Mouse
-	if strings.Contains(nameLower, "mouse") || strings.Contains(nameLower, "мышь") {
-		return "Mouse"
-	}
-
-	// Headset
-	if strings.Contains(nameLower, "headset") || strings.Contains(nameLower, "headphone") ||
-		strings.Contains(nameLower, "наушники") {
-		return "Headset"
+// detectCurrency narx katagi yoki sarlavhadagi valyuta ("$", "so'm", "USD", "руб"...).
+// Nomlar faqat alohida so'z sifatida olinadi: "Summa" sarlavhasi so'm hisoblanmaydi.
+// Valyuta topilmasa bo'sh qiymat qaytadi.
+func detectCurrency(text string) entity.Currency {
+	text = strings.ToLower(text)
+	if strings.Contains(text, "у.е.") {
+		return entity.CurrencyUSD
+	}
+	for _, r := range text {
+		if unicode.IsSymbol(r) {
+			if currency, ok := entity.ParseCurrency(string(r)); ok {
+				return currency
+			}
+		}
 	}
 
-	// RAM - Umumiy kalit so'zlar (DDR dan keyin)
-	if strings.Contains(nameLower, "ram") || strings.Contains(nameLower, "memory") ||
-		strings.Contains(nameLower, "corsair vengeance") || strings.Contains(nameLower, "kingston fury") {
-		return "RAM"
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !strings.ContainsRune("'’‘ʻ`", r)
+	})
+	for _, word := range words {
+		if currency, ok := entity.ParseCurrency(word); ok {
+			return currency
+		}
 	}
-
-	return "Boshqa"
+	return ""
 }
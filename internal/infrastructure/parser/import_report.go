@@ -0,0 +1,347 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+// Shubhali narx chegarasi: narx kategoriya (yoki butun katalog) medianidan
+// shuncha marta katta yoki kichik bo'lsa belgilanadi. Odatda bu valyuta
+// aralashganini (so'm va $) yoki ortiqcha/yetishmayotgan nolni bildiradi.
+const (
+	suspiciousPriceRatio       = 100
+	minCategoryForMedian       = 5
+	minProductsForPriceOutlier = 3
+)
+
+// priceRangeSpec oraliq narxning asl matni saqlanadigan xususiyat nomi
+const priceRangeSpec = "Narx oralig'i"
+
+// importCollector parse jarayonida mahsulotlar va hisobotni yig'ish
+type importCollector struct {
+	report   *entity.ImportReport
+	origins  []rowOrigin         // report.Products bilan bir xil tartibda
+	currency entity.Currency     // katak va sarlavhada valyuta bo'lmasa (admin izohidan)
+	locale   entity.NumberLocale // admin izohidan; bo'sh bo'lsa har bir sheet uchun aniqlanadi
+	number   int                 // joriy sheet ning report.Numbers dagi indeksi (-1 - hali yo'q)
+	taxonomy *entity.Taxonomy    // kategoriya ustuni va sheet nomi bo'lmasa nomdan aniqlash
+
+	profiles        []entity.ImportProfile // sarlavhasi solishtiriladigan import profillari
+	forceProfile    bool                   // profil izohda ko'rsatilgan - sarlavha mos kelmasa ham qo'llanadi
+	profileCurrency entity.Currency        // joriy sheet profili valyutasi
+
+	progress func(entity.ImportProgress) // ImportOptions.Progress
+	rowsRead int                         // barcha sheetlarda o'qilgan qatorlar
+
+	images map[string]bool // report.Images dagi rasm ID lari
+}
+
+// rowOrigin mahsulot olingan joy
+type rowOrigin struct {
+	sheet string
+	row   int
+}
+
+func newImportCollector(source, format string, opts entity.ImportOptions, taxonomy *entity.Taxonomy) *importCollector {
+	return &importCollector{
+		report: &entity.ImportReport{
+			Source:    source,
+			Format:    format,
+			CreatedAt: time.Now(),
+		},
+		currency: opts.Currency,
+		locale:   opts.NumberLocale,
+		number:   -1,
+		taxonomy: taxonomy,
+		progress: opts.Progress,
+	}
+}
+
+// progressEvery necha qatorda bir marta jarayon haqida xabar beriladi
+const progressEvery = 2000
+
+// advance o'qilgan qatorni hisoblash va vaqti-vaqti bilan jarayon haqida xabar berish
+func (c *importCollector) advance(sheet string) {
+	c.rowsRead++
+	if c.progress != nil && c.rowsRead%progressEvery == 0 {
+		c.progress(entity.ImportProgress{Sheet: sheet, Rows: c.rowsRead, Products: len(c.report.Products)})
+	}
+}
+
+// priceCurrency narx valyutasi: katak -> valyuta ustuni -> narx sarlavhasi -> admin izohi -> import profili -> default
+func (c *importCollector) priceCurrency(candidates ...entity.Currency) entity.Currency {
+	for _, currency := range append(candidates, c.currency, c.profileCurrency) {
+		if currency != "" {
+			return currency
+		}
+	}
+	return entity.DefaultCurrency
+}
+
+// selectProfile sheet ga mos import profili va sarlavha qatori indeksi (top - birinchi
+// to'la qator). Profil izohda berilgan bo'lsa sarlavhasi mos kelmasa ham olinadi; aks holda
+// profil sarlavha qatoriga (yoki boshidagi bir nechta qatordan biriga) mos kelishi kerak.
+func (c *importCollector) selectProfile(rows [][]string, top int) (*entity.ImportProfile, int, bool) {
+	if len(c.profiles) == 0 {
+		return nil, 0, false
+	}
+
+	headerRow := func(p *entity.ImportProfile) int {
+		if p.HeaderRow > 0 && p.HeaderRow <= len(rows) {
+			return p.HeaderRow - 1
+		}
+		return top
+	}
+
+	if c.forceProfile {
+		return &c.profiles[0], headerRow(&c.profiles[0]), true
+	}
+
+	var best *entity.ImportProfile
+	bestRow, bestScore := 0, 0
+	for i := range c.profiles {
+		p := &c.profiles[i]
+		candidates := []int{headerRow(p)}
+		if p.HeaderRow == 0 {
+			// Sarlavha ustida sarlavha/sana qatorlari bo'lishi mumkin
+			candidates = candidates[:0]
+			for r := top; r < len(rows) && len(candidates) < maxProfileHeaderScan; r++ {
+				if !isEmptyRow(rows[r]) {
+					candidates = append(candidates, r)
+				}
+			}
+		}
+		for _, r := range candidates {
+			if ok, score := p.MatchHeader(rows[r]); ok && score > bestScore {
+				best, bestRow, bestScore = p, r, score
+			}
+		}
+	}
+	return best, bestRow, best != nil
+}
+
+// maxProfileHeaderScan profil sarlavhasi qidiriladigan to'la qatorlar soni
+const maxProfileHeaderScan = 10
+
+// fallbackCategory kategoriya ustuni -> sheet nomi -> taksonomiya qoidalari tartibida.
+// Ikkinchi qiymat kategoriya qoidalar bilan aniqlanganini bildiradi (qayta kategoriyalashda o'zgarishi mumkin).
+func (c *importCollector) fallbackCategory(category, sheetCategory, name string) (string, bool) {
+	if category != "" {
+		return category, false
+	}
+	if sheetCategory != "" {
+		return sheetCategory, false
+	}
+	return c.taxonomy.Categorize(name), true
+}
+
+// beginNumbers sheet narxlari uchun raqam formatini tanlash: admin izohi -> namunalardan aniqlash.
+// Aniqlanmasa Auto qoladi: "1,299" va "1.299" bir xil (minglik) o'qiladi va noaniq deb sanaladi.
+func (c *importCollector) beginNumbers(sheet string, samples []string) {
+	format := entity.NumberFormat{Sheet: sheet, Locale: c.locale}
+	if format.Locale == entity.NumberLocaleAuto {
+		format.Locale = detectNumberLocale(samples)
+		format.Detected = format.Locale != entity.NumberLocaleAuto
+	}
+	c.report.Numbers = append(c.report.Numbers, format)
+	c.number = len(c.report.Numbers) - 1
+}
+
+// numberLocale joriy sheet narxlari uchun locale
+func (c *importCollector) numberLocale() entity.NumberLocale {
+	if c.number < 0 {
+		return c.locale
+	}
+	return c.report.Numbers[c.number].Locale
+}
+
+// notePrice o'qilgan narxni raqam formati statistikasiga qo'shish. Oraliq narxning
+// asl matni mahsulot xususiyatlarida saqlanadi va qator hisobotga yoziladi.
+func (c *importCollector) notePrice(product *entity.Product, raw string, price priceValue, sheet string, row int) {
+	if c.number >= 0 {
+		format := &c.report.Numbers[c.number]
+		if price.Ambiguous {
+			format.Ambiguous++
+		}
+		if price.Scaled {
+			format.Scaled++
+		}
+		if price.IsRange() {
+			format.Ranges++
+		}
+		if format.Example == "" && strings.ContainsAny(raw, ".,") {
+			format.Example = fmt.Sprintf("%s → %s", raw, strconv.FormatFloat(price.Amount, 'f', -1, 64))
+		}
+	}
+
+	if price.IsRange() {
+		if product.Specs == nil {
+			product.Specs = make(map[string]string)
+		}
+		product.Specs[priceRangeSpec] = raw
+		c.reject(entity.ImportIssuePriceRange, sheet, row, product.Name, raw,
+			fmt.Sprintf("narx oralig'i, pastki chegara olindi: %s", entity.FormatMoney(price.Amount, product.PriceCurrency())))
+	}
+}
+
+// accept qabul qilingan mahsulot (texnik atributlari nom va xususiyatlardan aniqlanadi)
+func (c *importCollector) accept(product entity.Product, sheet string, row int) {
+	product.Attributes = entity.ExtractAttributes(product.Name, product.Category, product.Specs)
+	c.report.Products = append(c.report.Products, product)
+	c.origins = append(c.origins, rowOrigin{sheet: sheet, row: row})
+}
+
+// addImage faylga joylangan rasmni hisobotga qo'shish (bir xil rasm bir marta); Product.Image uchun ID qaytaradi
+func (c *importCollector) addImage(image entity.ProductImage) string {
+	if c.images == nil {
+		c.images = make(map[string]bool)
+	}
+	if !c.images[image.ID] {
+		c.images[image.ID] = true
+		c.report.Images = append(c.report.Images, image)
+	}
+	return image.ID
+}
+
+// imageURL rasm ustunidagi qiymat: havola bo'lsa o'zi, aks holda qator rasmsiz qabul qilinadi va hisobotga yoziladi
+func (c *importCollector) imageURL(value, sheet string, row int, name string) string {
+	value = strings.TrimSpace(value)
+	if value == "" || entity.IsImageURL(value) {
+		return value
+	}
+	c.reject(entity.ImportIssueImage, sheet, row, name, value, "rasm havolasi emas (http/https), mahsulot rasmsiz qo'shildi")
+	return ""
+}
+
+// reject qatorni hisobotga yozish
+func (c *importCollector) reject(kind entity.ImportIssueKind, sheet string, row int, name, value, reason string) {
+	c.report.Issues = append(c.report.Issues, entity.ImportIssue{
+		Kind:   kind,
+		Sheet:  sheet,
+		Row:    row,
+		Name:   name,
+		Value:  value,
+		Reason: reason,
+	})
+}
+
+// guess taxmin bilan tanlangan ustunni yozish
+func (c *importCollector) guess(sheet, field string, column int, reason string) {
+	c.report.Guesses = append(c.report.Guesses, entity.ColumnGuess{
+		Sheet:  sheet,
+		Field:  field,
+		Column: column,
+		Reason: reason,
+	})
+}
+
+// finish takroriy nomlar va shubhali narxlarni belgilash, muammolarni joyi bo'yicha saralash
+func (c *importCollector) finish() {
+	c.markDuplicates()
+	c.markSuspiciousPrices()
+
+	sheetOrder := make(map[string]int, len(c.report.Sheets))
+	for i, sheet := range c.report.Sheets {
+		sheetOrder[sheet] = i
+	}
+	sort.SliceStable(c.report.Issues, func(i, j int) bool {
+		a, b := c.report.Issues[i], c.report.Issues[j]
+		if a.Sheet != b.Sheet {
+			return sheetOrder[a.Sheet] < sheetOrder[b.Sheet]
+		}
+		return a.Row < b.Row
+	})
+}
+
+func (c *importCollector) markDuplicates() {
+	first := make(map[string]int, len(c.report.Products))
+	for i, product := range c.report.Products {
+		// Artikuli har xil bo'lgan bir xil nomli mahsulotlar (variantlar) takror emas
+		key := "name:" + strings.ToLower(strings.Join(strings.Fields(product.Name), " "))
+		if sku := entity.NormalizeSKU(product.SKU); sku != "" {
+			key = "sku:" + sku
+		}
+		j, seen := first[key]
+		if !seen {
+			first[key] = i
+			continue
+		}
+		prev := entity.ImportIssue{Sheet: c.origins[j].sheet, Row: c.origins[j].row}
+		c.reject(entity.ImportIssueDuplicate, c.origins[i].sheet, c.origins[i].row, product.Name,
+			fmt.Sprintf("%.2f", product.Price), fmt.Sprintf("takroriy nom yoki artikul (birinchisi: %s)", prev.Location()))
+	}
+}
+
+// markSuspiciousPrices medianidan keskin farq qiladigan narxlarni belgilash.
+// Narxlar faqat o'z valyutasidagilar bilan solishtiriladi. Kategoriyada kamida
+// minCategoryForMedian ta mahsulot bo'lsa kategoriya medianasi, aks holda
+// shu valyutadagi butun import medianasi ishlatiladi.
+func (c *importCollector) markSuspiciousPrices() {
+	products := c.report.Products
+
+	byCurrency := make(map[entity.Currency][]float64)
+	byCategory := make(map[string][]float64)
+	for _, p := range products {
+		currency := p.PriceCurrency()
+		byCurrency[currency] = append(byCurrency[currency], p.Price)
+		key := string(currency) + "|" + p.Category
+		byCategory[key] = append(byCategory[key], p.Price)
+	}
+	medians := make(map[entity.Currency]float64, len(byCurrency))
+	for currency, prices := range byCurrency {
+		if len(prices) >= minProductsForPriceOutlier {
+			medians[currency] = median(prices)
+		}
+	}
+	// Kategoriya medianalari bir marta hisoblanadi (katta fayllarda har bir mahsulot uchun saralash sekin)
+	categoryMedians := make(map[string]float64, len(byCategory))
+	for key, prices := range byCategory {
+		if len(prices) >= minCategoryForMedian {
+			categoryMedians[key] = median(prices)
+		}
+	}
+
+	for i, p := range products {
+		currency := p.PriceCurrency()
+		base, ok := medians[currency]
+		if !ok {
+			continue
+		}
+		if categoryMedian, ok := categoryMedians[string(currency)+"|"+p.Category]; ok {
+			base = categoryMedian
+		}
+		if base <= 0 {
+			continue
+		}
+
+		var reason string
+		switch {
+		case p.Price > base*suspiciousPriceRatio:
+			reason = fmt.Sprintf("narx odatdagidan juda katta (mediana %s)", entity.FormatMoney(base, currency))
+		case p.Price < base/suspiciousPriceRatio:
+			reason = fmt.Sprintf("narx odatdagidan juda kichik (mediana %s)", entity.FormatMoney(base, currency))
+		default:
+			continue
+		}
+		c.reject(entity.ImportIssueSuspiciousPrice, c.origins[i].sheet, c.origins[i].row, p.Name,
+			entity.FormatMoney(p.Price, currency), reason)
+	}
+}
+
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	if n == 0 {
+		return 0
+	}
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
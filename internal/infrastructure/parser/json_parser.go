@@ -0,0 +1,175 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+// jsonCatalog JSON import sxemasi:
+//
+//	{
+//	  "products": [
+//	    {
+//	      "sku": "100-100001015BOX",       // ixtiyoriy, merge importda kalit
+//	      "name": "AMD Ryzen 5 7600",      // majburiy
+//	      "price": 199.9,                  // majburiy, raqam yoki matn ("2 500 000 so'm", "1.299,00", "850k")
+//	      "currency": "USD",               // ixtiyoriy, bo'lmasa narx matnidan yoki admin izohidan
+//	      "category": "CPU",               // ixtiyoriy, bo'lmasa nomdan aniqlanadi
+//	      "description": "6 yadro",        // ixtiyoriy
+//	      "stock": 5,                      // ixtiyoriy, raqam yoki matn
+//	      "image": "https://.../7600.jpg", // ixtiyoriy, rasm havolasi (http/https)
+//	      "specs": {"Socket": "AM5"}       // ixtiyoriy, qiymatlar matn/raqam/bool
+//	    }
+//	  ]
+//	}
+//
+// Yuqori darajadagi massiv ([{...}, ...]) ham qabul qilinadi.
+type jsonCatalog struct {
+	Products []jsonProduct `json:"products"`
+}
+
+type jsonProduct struct {
+	SKU         jsonScalar            `json:"sku"`
+	Name        string                `json:"name"`
+	Category    string                `json:"category"`
+	Price       jsonNumber            `json:"price"`
+	Currency    string                `json:"currency"`
+	Description string                `json:"description"`
+	Stock       jsonNumber            `json:"stock"`
+	Image       string                `json:"image"`
+	Specs       map[string]jsonScalar `json:"specs"`
+}
+
+// jsonScalar raqam, matn yoki bool qiymatni matn ko'rinishida saqlaydi
+type jsonScalar string
+
+// UnmarshalJSON raqam va matnni bir xil qabul qilish
+func (s *jsonScalar) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || string(data) == "null" {
+		*s = ""
+		return nil
+	}
+	if data[0] == '"' {
+		var str string
+		if err := json.Unmarshal(data, &str); err != nil {
+			return err
+		}
+		*s = jsonScalar(str)
+		return nil
+	}
+	if data[0] == '{' || data[0] == '[' {
+		return fmt.Errorf("expected number or string, got %s", data)
+	}
+	*s = jsonScalar(data)
+	return nil
+}
+
+// jsonNumber narx yoki ombor qiymati. JSON raqami (199.9) har doim nuqta o'nlik bilan
+// yoziladi, matn ("1.299,00") esa fayl raqam formati bo'yicha o'qiladi.
+type jsonNumber struct {
+	text    string
+	literal bool
+}
+
+// UnmarshalJSON qiymat JSON raqami ekanini eslab qolish
+func (n *jsonNumber) UnmarshalJSON(data []byte) error {
+	var s jsonScalar
+	if err := s.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	data = bytes.TrimSpace(data)
+	n.text = strings.TrimSpace(string(s))
+	n.literal = n.text != "" && data[0] != '"'
+	return nil
+}
+
+// locale qiymatni o'qish uchun raqam formati
+func (n jsonNumber) locale(fileLocale entity.NumberLocale) entity.NumberLocale {
+	if n.literal {
+		return entity.NumberLocaleEN
+	}
+	return fileLocale
+}
+
+// parseJSON JSON katalogni parse qilish
+func (e *catalogParser) parseJSON(data []byte, c *importCollector) error {
+	data = bytes.TrimSpace(bytes.TrimPrefix(data, utf8BOM))
+
+	var catalog jsonCatalog
+	if len(data) > 0 && data[0] == '[' {
+		if err := json.Unmarshal(data, &catalog.Products); err != nil {
+			return fmt.Errorf("invalid json catalog: %w", err)
+		}
+	} else if err := json.Unmarshal(data, &catalog); err != nil {
+		return fmt.Errorf("invalid json catalog: %w", err)
+	}
+
+	if len(catalog.Products) == 0 {
+		return fmt.Errorf("json catalog has no products")
+	}
+
+	// Raqam formati faqat matn ko'rinishidagi narxlardan aniqlanadi
+	var samples []string
+	for _, item := range catalog.Products {
+		if !item.Price.literal && item.Price.text != "" {
+			samples = append(samples, item.Price.text)
+		}
+	}
+	c.beginNumbers("", samples)
+	locale := c.numberLocale()
+
+	now := time.Now()
+	c.report.TotalRows = len(catalog.Products)
+	for i, item := range catalog.Products {
+		name := strings.TrimSpace(item.Name)
+		priceStr := item.Price.text
+
+		price, kind, reason := e.validateRow(name, priceStr, item.Price.locale(locale))
+		if kind != "" {
+			log.Printf("⚠️ JSON item %d: %s - skipping", i+1, reason)
+			c.reject(kind, "", i+1, name, priceStr, reason)
+			continue
+		}
+
+		product := entity.Product{
+			ID:          uuid.New().String(),
+			SKU:         strings.TrimSpace(string(item.SKU)),
+			Name:        name,
+			Price:       price.Amount,
+			Currency:    c.priceCurrency(detectCurrency(item.Currency), price.Currency),
+			Description: strings.TrimSpace(item.Description),
+			CreatedAt:   now,
+			UpdatedAt:   now,
+			Specs:       make(map[string]string),
+		}
+		product.Category, product.CategoryAuto = c.fallbackCategory(strings.TrimSpace(item.Category), "", name)
+		product.Image = c.imageURL(item.Image, "", i+1, name)
+		if item.Stock.text != "" {
+			if stock, err := parsePrice(item.Stock.text, item.Stock.locale(locale)); err == nil {
+				product.Stock = int(stock.Amount)
+				product.StockTracked = true
+			}
+		}
+		for key, value := range item.Specs {
+			if key = strings.TrimSpace(key); key != "" && strings.TrimSpace(string(value)) != "" {
+				product.Specs[key] = strings.TrimSpace(string(value))
+			}
+		}
+
+		if item.Price.literal {
+			price.Ambiguous = false
+		}
+		c.notePrice(&product, priceStr, price, "", i+1)
+		c.accept(product, "", i+1)
+	}
+
+	return nil
+}
@@ -0,0 +1,251 @@
+package parser
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+// priceValue narx katagidan o'qilgan qiymat
+type priceValue struct {
+	Amount    float64         // oraliqda pastki chegara
+	High      float64         // oraliqning yuqori chegarasi (oraliq bo'lmasa 0)
+	Currency  entity.Currency // katakda yozilgan valyuta (bo'lmasa bo'sh)
+	Scaled    bool            // "k", "mln" kabi qisqartma bor edi
+	Ambiguous bool            // "1,299" - ajratuvchi locale bo'yicha tanlandi
+}
+
+// IsRange narx "120-130" ko'rinishida yozilgan
+func (v priceValue) IsRange() bool {
+	return v.High > 0
+}
+
+// numberMultipliers raqamdan keyingi qisqartmalar (kichik harfda)
+var numberMultipliers = map[string]float64{
+	"k": 1e3, "к": 1e3, "ming": 1e3, "тыс": 1e3, "thousand": 1e3,
+	"m": 1e6, "mln": 1e6, "mio": 1e6, "million": 1e6, "млн": 1e6, "миллион": 1e6,
+	"mlrd": 1e9, "bn": 1e9, "млрд": 1e9,
+}
+
+// parsePrice narx matnini o'qish. Qo'llab-quvvatlanadi:
+//
+//	"1,299.00" / "1.299,00" / "1 299,5" (NBSP ham)  - o'nlik va minglik ajratuvchilar
+//	"850k", "1.2 mln", "5 тыс. руб"                - qisqartmalar
+//	"120-130$", "1,1–1,3 mln so'm"                 - oraliq (pastki chegara olinadi)
+//
+// Yagona ajratuvchidan keyin aynan 3 raqam bo'lsa ("1,299", "1.299") qiymat locale
+// bo'yicha o'qiladi va noaniq deb belgilanadi: EU da nuqta minglik, vergul o'nlik; EN da aksincha.
+// Locale aniqlanmagan bo'lsa (Auto) ikkala ajratuvchi ham minglik hisoblanadi - "1,299" va
+// "1.299" bir xil 1299 bo'ladi.
+func parsePrice(text string, locale entity.NumberLocale) (priceValue, error) {
+	var v priceValue
+	parts, multiplier, currency, err := splitPrice(text)
+	if err != nil {
+		return v, err
+	}
+	v.Currency = currency
+	v.Scaled = multiplier != 1
+
+	for i, part := range parts {
+		amount, ambiguous, err := parseDecimal(part, locale)
+		if err != nil {
+			return priceValue{}, fmt.Errorf("invalid price format: %s", text)
+		}
+		amount *= multiplier
+		if v.Scaled {
+			amount = math.Round(amount*100) / 100
+		}
+		v.Ambiguous = v.Ambiguous || ambiguous
+		if i == 0 {
+			v.Amount = amount
+		} else {
+			v.High = amount
+		}
+	}
+	if v.IsRange() && v.High < v.Amount {
+		v.Amount, v.High = v.High, v.Amount
+	}
+	return v, nil
+}
+
+// splitPrice valyuta va qisqartmalarni ajratib, raqam qismlarini qaytarish (oraliqda ikkita)
+func splitPrice(text string) ([]string, float64, entity.Currency, error) {
+	text = strings.ToLower(strings.TrimSpace(text))
+	if text == "" {
+		return nil, 0, "", fmt.Errorf("empty price")
+	}
+	currency := detectCurrency(text)
+	text = strings.ReplaceAll(text, "у.е.", " ")
+
+	multiplier := 1.0
+	var b strings.Builder
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsLetter(r):
+			j := i
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || strings.ContainsRune("'’‘ʻ`", runes[j])) {
+				j++
+			}
+			word := string(runes[i:j])
+			if m, ok := numberMultipliers[word]; ok {
+				if multiplier != 1 {
+					return nil, 0, "", fmt.Errorf("invalid price format: %s", text)
+				}
+				multiplier = m
+			} else if _, ok := entity.ParseCurrency(word); !ok {
+				return nil, 0, "", fmt.Errorf("invalid price format: %s", text)
+			}
+			// "тыс.", "руб." - qisqartma nuqtasi raqamga tegishli emas
+			if j < len(runes) && runes[j] == '.' && (j+1 == len(runes) || !unicode.IsDigit(runes[j+1])) {
+				j++
+			}
+			b.WriteRune(' ')
+			i = j
+			continue
+		case unicode.Is(unicode.Sc, r):
+			b.WriteRune(' ')
+		default:
+			b.WriteRune(r)
+		}
+		i++
+	}
+
+	body := strings.TrimSpace(b.String())
+	if low, high, ok := splitRange(body); ok {
+		return []string{low, high}, multiplier, currency, nil
+	}
+	return []string{body}, multiplier, currency, nil
+}
+
+// splitRange "120-130", "1,1 – 1,3" oralig'ini ikkiga ajratish (boshidagi minus ishorasi oraliq emas)
+func splitRange(s string) (string, string, bool) {
+	for i, r := range s {
+		if r != '-' && r != '–' && r != '—' {
+			continue
+		}
+		low := strings.TrimSpace(s[:i])
+		high := strings.TrimSpace(s[i+len(string(r)):])
+		if low == "" || high == "" {
+			return "", "", false
+		}
+		return low, high, true
+	}
+	return "", "", false
+}
+
+// parseDecimal ajratuvchili sonni o'qish; ikkinchi qiymat - ajratuvchi locale bo'yicha tanlandimi
+func parseDecimal(s string, locale entity.NumberLocale) (float64, bool, error) {
+	s = stripGrouping(s)
+	if s == "" {
+		return 0, false, fmt.Errorf("empty number")
+	}
+
+	ambiguous := false
+	dots, commas := strings.Count(s, "."), strings.Count(s, ",")
+	switch {
+	case dots > 0 && commas > 0:
+		// Oxirgi ajratuvchi - o'nlik
+		if strings.LastIndex(s, ",") > strings.LastIndex(s, ".") {
+			s = strings.ReplaceAll(strings.ReplaceAll(s, ".", ""), ",", ".")
+		} else {
+			s = strings.ReplaceAll(s, ",", "")
+		}
+	case commas > 1:
+		s = strings.ReplaceAll(s, ",", "")
+	case dots > 1:
+		s = strings.ReplaceAll(s, ".", "")
+	case commas == 1:
+		ambiguous = isThousandsGroup(s, ',')
+		if ambiguous && locale != entity.NumberLocaleEU {
+			s = strings.ReplaceAll(s, ",", "")
+		} else {
+			s = strings.ReplaceAll(s, ",", ".")
+		}
+	case dots == 1:
+		ambiguous = isThousandsGroup(s, '.')
+		if ambiguous && locale != entity.NumberLocaleEN {
+			s = strings.ReplaceAll(s, ".", "")
+		}
+	}
+
+	for _, r := range strings.TrimLeft(s, "+-") {
+		if !unicode.IsDigit(r) && r != '.' {
+			return 0, false, fmt.Errorf("invalid number: %s", s)
+		}
+	}
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, false, err
+	}
+	return value, ambiguous, nil
+}
+
+// stripGrouping minglik bo'shliqlari (NBSP, ingichka bo'shliq) va apostrofni olib tashlash: "1 299", "1'299"
+func stripGrouping(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '\t', '\u00a0', '\u202f', '\u2009', '\'', '’':
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// isThousandsGroup yagona ajratuvchidan keyin aynan 3 raqam, oldin 1-3 raqam bor ("0.500" emas)
+func isThousandsGroup(s string, sep byte) bool {
+	idx := strings.IndexByte(s, sep)
+	whole := strings.TrimLeft(s[:idx], "+-")
+	return len(s)-idx-1 == 3 && len(whole) >= 1 && len(whole) <= 3 && whole != "0"
+}
+
+// separatorEvidence qiymat locale ni aniq ko'rsatadimi ("1.299,00" -> EU, "1,5" -> EU, "12.5" -> EN)
+func separatorEvidence(s string) entity.NumberLocale {
+	s = stripGrouping(s)
+	dots, commas := strings.Count(s, "."), strings.Count(s, ",")
+	switch {
+	case dots > 0 && commas > 0:
+		if strings.LastIndex(s, ",") > strings.LastIndex(s, ".") {
+			return entity.NumberLocaleEU
+		}
+		return entity.NumberLocaleEN
+	case commas > 1:
+		return entity.NumberLocaleEN
+	case dots > 1:
+		return entity.NumberLocaleEU
+	case commas == 1 && !isThousandsGroup(s, ','):
+		return entity.NumberLocaleEU
+	case dots == 1 && !isThousandsGroup(s, '.'):
+		return entity.NumberLocaleEN
+	}
+	return entity.NumberLocaleAuto
+}
+
+// detectNumberLocale narx namunalaridan locale ni aniqlash (ko'pchilik ovozi).
+// Aniq belgi topilmasa NumberLocaleAuto qaytadi.
+func detectNumberLocale(samples []string) entity.NumberLocale {
+	votes := make(map[entity.NumberLocale]int)
+	for _, sample := range samples {
+		parts, _, _, err := splitPrice(sample)
+		if err != nil {
+			continue
+		}
+		for _, part := range parts {
+			if locale := separatorEvidence(part); locale != entity.NumberLocaleAuto {
+				votes[locale]++
+			}
+		}
+	}
+	switch {
+	case votes[entity.NumberLocaleEU] > votes[entity.NumberLocaleEN]:
+		return entity.NumberLocaleEU
+	case votes[entity.NumberLocaleEN] > 0:
+		return entity.NumberLocaleEN
+	}
+	return entity.NumberLocaleAuto
+}
@@ -0,0 +1,360 @@
+package parser
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+// OpenDocument nomlar fazolari
+const (
+	odsTableNS  = "urn:oasis:names:tc:opendocument:xmlns:table:1.0"
+	odsOfficeNS = "urn:oasis:names:tc:opendocument:xmlns:office:1.0"
+	odsTextNS   = "urn:oasis:names:tc:opendocument:xmlns:text:1.0"
+	odsStyleNS  = "urn:oasis:names:tc:opendocument:xmlns:style:1.0"
+	odsFoNS     = "urn:oasis:names:tc:opendocument:xmlns:xsl-fo-compatible:1.0"
+)
+
+// maxODSRepeat takrorlanadigan bo'sh qator/ustunlar chegarasi: .ods oxirida "1048576 ta bo'sh
+// qator" yoziladi, ular faqat keyin to'la qator kelsa ochiladi
+const maxODSRepeat = 1 << 16
+
+// odsStyle katak uslubi (ota uslubdan meros olinadi)
+type odsStyle struct {
+	parent string
+	bold   *bool
+	size   float64
+}
+
+// readODS OpenDocument jadvalini (.ods) sheetlarga o'qish
+func readODS(data []byte) ([]sheetData, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: not a valid .ods (zip) file: %v", entity.ErrUnsupportedFormat, err)
+	}
+
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+	if manifest, err := readZipFile(files["META-INF/manifest.xml"]); err == nil && bytes.Contains(manifest, []byte("encryption-data")) {
+		return nil, fmt.Errorf("%w: spreadsheet is password-protected", entity.ErrUnsupportedFormat)
+	}
+	content, err := readZipFile(files["content.xml"])
+	if err != nil {
+		return nil, fmt.Errorf("%w: .ods file has no content.xml: %v", entity.ErrUnsupportedFormat, err)
+	}
+
+	styles := make(map[string]odsStyle)
+	if named, err := readZipFile(files["styles.xml"]); err == nil {
+		_ = readODSStyles(named, styles)
+	}
+
+	r := &odsReader{styles: styles}
+	if err := r.read(content); err != nil {
+		return nil, fmt.Errorf("failed to read ods content: %w", err)
+	}
+	return r.sheets, nil
+}
+
+func readZipFile(f *zip.File) ([]byte, error) {
+	if f == nil {
+		return nil, fmt.Errorf("file not found")
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+// readODSStyles styles.xml dagi nomli uslublar (content.xml dagi avtomatik uslublar ularga tayanadi)
+func readODSStyles(data []byte, styles map[string]odsStyle) error {
+	d := xml.NewDecoder(bytes.NewReader(data))
+	var current string
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if se, ok := tok.(xml.StartElement); ok {
+			current = readODSStyle(se, current, styles)
+		}
+	}
+}
+
+// readODSStyle style:style va style:text-properties elementlarini styles ga yozish;
+// joriy uslub nomini qaytaradi
+func readODSStyle(se xml.StartElement, current string, styles map[string]odsStyle) string {
+	switch {
+	case se.Name.Space == odsStyleNS && se.Name.Local == "style":
+		current = xmlAttr(se, odsStyleNS, "name")
+		styles[current] = odsStyle{parent: xmlAttr(se, odsStyleNS, "parent-style-name")}
+	case se.Name.Space == odsStyleNS && se.Name.Local == "text-properties" && current != "":
+		style := styles[current]
+		if weight := xmlAttr(se, odsFoNS, "font-weight"); weight != "" {
+			bold := weight == "bold" || weight >= "600" && weight <= "900"
+			style.bold = &bold
+		}
+		if size := strings.TrimSuffix(xmlAttr(se, odsFoNS, "font-size"), "pt"); size != "" {
+			style.size, _ = strconv.ParseFloat(size, 64)
+		}
+		styles[current] = style
+	}
+	return current
+}
+
+// odsReader content.xml ni oqim bilan o'qish
+type odsReader struct {
+	styles map[string]odsStyle
+	sheets []sheetData
+
+	// joriy sheet
+	rows        [][]string
+	cellStyles  map[[2]int]string // to'la kataklar uslubi
+	merges      []cellRange
+	colStyles   []string // ustunlarning standart katak uslubi
+	pendingRows int      // hali ochilmagan bo'sh qatorlar
+}
+
+func (r *odsReader) read(content []byte) error {
+	d := xml.NewDecoder(bytes.NewReader(content))
+	var name, currentStyle string
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch {
+			case t.Name.Space == odsStyleNS:
+				currentStyle = readODSStyle(t, currentStyle, r.styles)
+			case t.Name.Space != odsTableNS:
+			case t.Name.Local == "table":
+				name = xmlAttr(t, odsTableNS, "name")
+				r.rows, r.cellStyles, r.merges, r.colStyles, r.pendingRows = nil, make(map[[2]int]string), nil, nil, 0
+			case t.Name.Local == "table-column":
+				repeat := min(xmlRepeat(t, "number-columns-repeated"), maxODSRepeat)
+				style := xmlAttr(t, odsTableNS, "default-cell-style-name")
+				for i := 0; i < repeat && len(r.colStyles) < maxODSRepeat; i++ {
+					r.colStyles = append(r.colStyles, style)
+				}
+			case t.Name.Local == "table-row":
+				if err := r.readRow(d, t); err != nil {
+					return err
+				}
+			}
+		case xml.EndElement:
+			if t.Name.Space == odsTableNS && t.Name.Local == "table" {
+				r.sheets = append(r.sheets, sheetData{name: name, rows: r.rows, styles: rowStyles(r.rows, r.merges, r.font)})
+			}
+		}
+	}
+}
+
+// readRow table:table-row elementini o'qish (takrorlangan qatorlar bilan)
+func (r *odsReader) readRow(d *xml.Decoder, start xml.StartElement) error {
+	repeat := xmlRepeat(start, "number-rows-repeated")
+	var row []string
+	var styles []string
+	var spans []cellRange
+	pendingCols := 0
+
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Space != odsTableNS || (t.Name.Local != "table-cell" && t.Name.Local != "covered-table-cell") {
+				continue
+			}
+			cellRepeat := xmlRepeat(t, "number-columns-repeated")
+			value, err := odsCellValue(d, t)
+			if err != nil {
+				return err
+			}
+			if value == "" {
+				pendingCols += cellRepeat
+				continue
+			}
+
+			// Bo'sh kataklar faqat keyin to'la katak kelganda ochiladi
+			for ; pendingCols > 0 && len(row) < maxODSRepeat; pendingCols-- {
+				row = append(row, "")
+				styles = append(styles, "")
+			}
+			pendingCols = 0
+			col := len(row)
+			if colSpan, rowSpan := xmlRepeat(t, "number-columns-spanned"), xmlRepeat(t, "number-rows-spanned"); colSpan > 1 || rowSpan > 1 {
+				spans = append(spans, cellRange{startCol: col, endCol: col + colSpan - 1, endRow: rowSpan - 1})
+			}
+			style := xmlAttr(t, odsTableNS, "style-name")
+			if style == "" && col < len(r.colStyles) {
+				style = r.colStyles[col]
+			}
+			for i := 0; i < min(cellRepeat, maxODSRepeat); i++ {
+				row = append(row, value)
+				styles = append(styles, style)
+			}
+		case xml.EndElement:
+			if t.Name.Space != odsTableNS || t.Name.Local != "table-row" {
+				continue
+			}
+			if len(row) == 0 {
+				r.pendingRows += repeat
+				return nil
+			}
+			for ; r.pendingRows > 0 && len(r.rows) < maxODSRepeat; r.pendingRows-- {
+				r.rows = append(r.rows, nil)
+			}
+			r.pendingRows = 0
+			for i := 0; i < min(repeat, maxODSRepeat); i++ {
+				index := len(r.rows)
+				r.rows = append(r.rows, append([]string(nil), row...))
+				for col, style := range styles {
+					if row[col] != "" {
+						r.cellStyles[[2]int{index, col}] = style
+					}
+				}
+				for _, span := range spans {
+					span.startRow, span.endRow = index, index+span.endRow
+					r.merges = append(r.merges, span)
+				}
+			}
+			return nil
+		}
+	}
+}
+
+// font katak uslubidan shrift (ota uslublar bo'ylab)
+func (r *odsReader) font(row, col int) (rowStyle, bool) {
+	name, ok := r.cellStyles[[2]int{row, col}]
+	if !ok || name == "" {
+		return rowStyle{}, false
+	}
+
+	var font rowStyle
+	boldSet := false
+	for depth := 0; name != "" && depth < 10; depth++ {
+		style, ok := r.styles[name]
+		if !ok {
+			break
+		}
+		if !boldSet && style.bold != nil {
+			font.bold, boldSet = *style.bold, true
+		}
+		if font.size == 0 {
+			font.size = style.size
+		}
+		name = style.parent
+	}
+	return font, true
+}
+
+// odsCellValue katak qiymati: sonlar office:value dan (ko'rinishdagi yaxlitlash va ajratuvchilarsiz),
+// valyutali sonlarga valyuta kodi qo'shiladi; qolganlari text:p matni
+func odsCellValue(d *xml.Decoder, start xml.StartElement) (string, error) {
+	text, err := odsText(d, start.Name)
+	if err != nil {
+		return "", err
+	}
+
+	switch xmlAttr(start, odsOfficeNS, "value-type") {
+	case "float", "percentage":
+		if v := xmlAttr(start, odsOfficeNS, "value"); v != "" {
+			return v, nil
+		}
+	case "currency":
+		if v := xmlAttr(start, odsOfficeNS, "value"); v != "" {
+			if currency := xmlAttr(start, odsOfficeNS, "currency"); currency != "" {
+				return v + " " + currency, nil
+			}
+			return v, nil
+		}
+	case "date":
+		if v := xmlAttr(start, odsOfficeNS, "date-value"); v != "" {
+			return strings.Replace(strings.TrimSuffix(v, "T00:00:00"), "T", " ", 1), nil
+		}
+	case "boolean":
+		if v := xmlAttr(start, odsOfficeNS, "boolean-value"); v != "" {
+			return strings.ToUpper(v), nil
+		}
+	}
+	return text, nil
+}
+
+// odsText katak ichidagi matn: text:p qatorlari "\n" bilan, text:s bo'shliqlar, text:tab
+func odsText(d *xml.Decoder, end xml.Name) (string, error) {
+	var b strings.Builder
+	paragraphs := 0
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return "", err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if t.Name.Space != odsTextNS {
+				continue
+			}
+			switch t.Name.Local {
+			case "p", "h":
+				if paragraphs > 0 {
+					b.WriteString("\n")
+				}
+				paragraphs++
+			case "s":
+				b.WriteString(strings.Repeat(" ", xmlCount(t, odsTextNS, "c")))
+			case "tab":
+				b.WriteString("\t")
+			case "line-break":
+				b.WriteString("\n")
+			}
+		case xml.CharData:
+			b.Write(t)
+		case xml.EndElement:
+			if t.Name == end {
+				return strings.TrimSpace(b.String()), nil
+			}
+		}
+	}
+}
+
+func xmlAttr(se xml.StartElement, space, local string) string {
+	for _, a := range se.Attr {
+		if a.Name.Space == space && a.Name.Local == local {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// xmlRepeat table: nomlar fazosidagi takrorlash/birlashtirish soni (yo'q bo'lsa 1)
+func xmlRepeat(se xml.StartElement, local string) int {
+	return xmlCount(se, odsTableNS, local)
+}
+
+func xmlCount(se xml.StartElement, space, local string) int {
+	n, err := strconv.Atoi(xmlAttr(se, space, local))
+	if err != nil || n < 1 {
+		return 1
+	}
+	return n
+}
@@ -0,0 +1,358 @@
+package parser
+
+import (
+	"log"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/xuri/excelize/v2"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+// sectionSpec guruh sarlavhasi (masalan, brend) yoziladigan xususiyat nomi
+const sectionSpec = "Guruh"
+
+// maxSectionTitle bo'lim sarlavhasi uzunligi chegarasi (uzun matn - izoh yoki tavsif)
+const maxSectionTitle = 80
+
+// maxLeadingTitles ustun sarlavhasidan oldin o'tkazib yuboriladigan hujjat sarlavhalari soni
+const maxLeadingTitles = 5
+
+// rowStyle Excel qatoridagi birinchi to'la katak ko'rinishi (bo'lim sarlavhalarini aniqlash uchun)
+type rowStyle struct {
+	merged bool    // katak bir nechta ustunga birlashtirilgan
+	bold   bool    // qalin shrift
+	size   float64 // shrift o'lchami (0 - standart)
+}
+
+// stronger s sarlavhasi o ga nisbatan yuqori darajadami (birlashtirish -> o'lcham -> qalinlik)
+func (s rowStyle) stronger(o rowStyle) bool {
+	if s.merged != o.merged {
+		return s.merged
+	}
+	if s.size != o.size {
+		return s.size > o.size
+	}
+	return s.bold && !o.bold
+}
+
+// cellRange birlashtirilgan kataklar (0 dan boshlanadigan indekslar, oxiri kiradi)
+type cellRange struct {
+	startRow, startCol int
+	endRow, endCol     int
+}
+
+// rowStyles birlashtirilgan kataklar va shriftlardan bo'lim sarlavhasi bo'lishi mumkin bo'lgan
+// qatorlar ko'rinishi. Bir nechta qatorga cho'zilgan birlashtirishlar (masalan, kategoriya ustunida)
+// qiymati rows dagi barcha qatorlarga ko'chiriladi - fayllarda u faqat birinchi katakda bo'ladi.
+// font katak shriftini qaytaradi (ma'lum bo'lmasa false).
+func rowStyles(rows [][]string, merges []cellRange, font func(row, col int) (rowStyle, bool)) map[int]rowStyle {
+	styles := make(map[int]rowStyle)
+
+	for _, m := range merges {
+		if m.startRow < 0 || m.startRow >= len(rows) {
+			continue
+		}
+		if m.endCol > m.startCol {
+			styles[m.startRow] = rowStyle{merged: true}
+		}
+		if value := cellAt(rows[m.startRow], m.startCol); m.endRow > m.startRow && value != "" {
+			for r := m.startRow + 1; r <= m.endRow && r < len(rows); r++ {
+				rows[r] = setCell(rows[r], m.startCol, value)
+			}
+		}
+	}
+
+	// Shrift faqat bir-ikki katakli qatorlarda tekshiriladi (mahsulot qatorlari sarlavha bo'lmaydi)
+	for i, row := range rows {
+		col, count := firstFilledCell(row)
+		if count == 0 || count > 2 {
+			continue
+		}
+		f, ok := font(i, col)
+		if !ok {
+			continue
+		}
+		if f.bold || styles[i].merged {
+			style := styles[i]
+			style.bold, style.size = f.bold, f.size
+			styles[i] = style
+		}
+	}
+	return styles
+}
+
+// xlsxRowStyles .xlsx sheet uchun rowStyles
+func xlsxRowStyles(f *excelize.File, sheet string, rows [][]string) map[int]rowStyle {
+	var merges []cellRange
+	cells, err := f.GetMergeCells(sheet, true)
+	if err != nil {
+		log.Printf("⚠️ Sheet '%s': merged cells not read: %v", sheet, err)
+	}
+	for _, m := range cells {
+		startCol, startRow, err := excelize.CellNameToCoordinates(m.GetStartAxis())
+		if err != nil {
+			continue
+		}
+		endCol, endRow, err := excelize.CellNameToCoordinates(m.GetEndAxis())
+		if err != nil {
+			continue
+		}
+		merges = append(merges, cellRange{startRow: startRow - 1, startCol: startCol - 1, endRow: endRow - 1, endCol: endCol - 1})
+	}
+
+	fonts := make(map[int]rowStyle)
+	return rowStyles(rows, merges, func(row, col int) (rowStyle, bool) {
+		cell, err := excelize.CoordinatesToCellName(col+1, row+1)
+		if err != nil {
+			return rowStyle{}, false
+		}
+		styleID, err := f.GetCellStyle(sheet, cell)
+		if err != nil || styleID == 0 {
+			return rowStyle{}, false
+		}
+		font, ok := fonts[styleID]
+		if !ok {
+			if style, err := f.GetStyle(styleID); err == nil && style.Font != nil {
+				font = rowStyle{bold: style.Font.Bold, size: style.Font.Size}
+			}
+			fonts[styleID] = font
+		}
+		return font, true
+	})
+}
+
+// setCell qatorning col katagiga qiymat yozish (qator qisqa bo'lsa uzaytiriladi)
+func setCell(row []string, col int, value string) []string {
+	for len(row) <= col {
+		row = append(row, "")
+	}
+	if strings.TrimSpace(row[col]) == "" {
+		row[col] = value
+	}
+	return row
+}
+
+// firstFilledCell birinchi to'la katak ustuni va to'la kataklar soni
+func firstFilledCell(row []string) (int, int) {
+	first, count := -1, 0
+	for i, cell := range row {
+		if strings.TrimSpace(cell) == "" {
+			continue
+		}
+		if first < 0 {
+			first = i
+		}
+		count++
+	}
+	return first, count
+}
+
+// isTitleRow qator hujjat yoki bo'lim sarlavhasiga o'xshaydimi: bitta to'la katak,
+// yoki birlashtirilgan/qalin katak va yonida ko'pi bilan bitta izoh
+func isTitleRow(row []string, style rowStyle, styled bool) bool {
+	col, count := firstFilledCell(row)
+	if count == 0 || len([]rune(strings.TrimSpace(row[col]))) > maxSectionTitle {
+		return false
+	}
+	return count == 1 || (styled && (style.merged || style.bold) && count <= 2)
+}
+
+// sectionTitle qatordagi sarlavha matni ("Videokartalar:" -> "Videokartalar")
+func sectionTitle(row []string) string {
+	col, _ := firstFilledCell(row)
+	return strings.TrimRight(strings.TrimSpace(row[col]), ":;.-–— ")
+}
+
+// hasDigit matnda raqam bormi (mahsulot nomlarida odatda model raqami bo'ladi)
+func hasDigit(text string) bool {
+	return strings.IndexFunc(text, unicode.IsDigit) >= 0
+}
+
+// isSectionRow qator bo'lim sarlavhasimi: narx katagi o'qilmaydi va qator birlashtirilgan/qalin,
+// yoki yagona to'la katak nom ustunida emas yoki raqamsiz (narxsiz mahsulot qatorlari hisobotda qoladi)
+func isSectionRow(row []string, style rowStyle, styled bool, nameCol, priceCol int, locale entity.NumberLocale) bool {
+	if !isTitleRow(row, style, styled) {
+		return false
+	}
+	if price, err := parsePrice(cellAt(row, priceCol), locale); err == nil && price.Amount > 0 {
+		return false
+	}
+	col, _ := firstFilledCell(row)
+	return styled || col != nameCol || !hasDigit(row[col])
+}
+
+// sectionRows jadvaldagi bo'lim sarlavhalari va ularning darajasi (isSectionRow).
+// Darajalar: sarlavhalar ko'rinishi har xil bo'lsa eng kuchlisi kategoriya, qolganlari guruh;
+// bir xil bo'lsa, ketma-ket ikki sarlavhaning birinchisi kategoriya, ikkinchisi guruh, va shunday
+// ichma-ich tuzilgan (nested) sheetda alohida turgan sarlavhalar ham guruh bo'ladi.
+func sectionRows(rows [][]string, startRow, nameCol, priceCol int, styles map[int]rowStyle, locale entity.NumberLocale) (map[int]entity.SectionLevel, bool) {
+	var found []int
+	for i := startRow; i < len(rows); i++ {
+		style, styled := styles[i]
+		if isSectionRow(rows[i], style, styled, nameCol, priceCol, locale) {
+			found = append(found, i)
+		}
+	}
+	if len(found) == 0 {
+		return nil, false
+	}
+
+	levels := make(map[int]entity.SectionLevel, len(found))
+
+	// Ko'rinish bo'yicha darajalar
+	distinct := []rowStyle{}
+	for _, i := range found {
+		style := styles[i]
+		seen := false
+		for _, d := range distinct {
+			if d == style {
+				seen = true
+				break
+			}
+		}
+		if !seen {
+			distinct = append(distinct, style)
+		}
+	}
+	if len(distinct) > 1 {
+		sort.Slice(distinct, func(a, b int) bool { return distinct[a].stronger(distinct[b]) })
+		for _, i := range found {
+			levels[i] = entity.SectionGroup
+			if styles[i] == distinct[0] {
+				levels[i] = entity.SectionCategory
+			}
+		}
+		return levels, false
+	}
+
+	// Bir xil ko'rinish - joylashuv bo'yicha
+	isSection := make(map[int]bool, len(found))
+	for _, i := range found {
+		isSection[i] = true
+	}
+	followed := make(map[int]bool, len(found))
+	nested := false
+	for _, i := range found {
+		next := i + 1
+		for next < len(rows) && isEmptyRow(rows[next]) {
+			next++
+		}
+		if isSection[next] {
+			followed[i] = true
+			nested = true
+		}
+	}
+	for _, i := range found {
+		levels[i] = entity.SectionCategory
+		if nested && !followed[i] {
+			levels[i] = entity.SectionGroup
+		}
+	}
+	return levels, nested
+}
+
+// sectionState joriy bo'lim: keyingi sarlavhagacha qatorlarga qo'llanadi
+type sectionState struct {
+	category      string
+	group         string
+	categoryIndex int // report.Sections dagi joriy kategoriya sarlavhasi (-1 - yo'q)
+	groupIndex    int // report.Sections dagi joriy guruh sarlavhasi (-1 - yo'q)
+
+	// Qatorma-qator o'qiladigan qism uchun (sectionRows ko'rmagan qatorlar)
+	nested     bool   // sheet ichma-ich tuzilgan
+	pending    string // darajasi keyingi qatorga qarab aniqlanadigan sarlavha
+	pendingRow int
+}
+
+func newSectionState() *sectionState {
+	return &sectionState{categoryIndex: -1, groupIndex: -1}
+}
+
+// enter bo'lim sarlavhasi qatoriga kirish
+func (s *sectionState) enter(c *importCollector, sheet string, row int, title string, level entity.SectionLevel) {
+	c.report.Sections = append(c.report.Sections, entity.ImportSection{Sheet: sheet, Row: row, Title: title, Level: level})
+	if level == entity.SectionCategory {
+		s.category, s.categoryIndex = title, len(c.report.Sections)-1
+		s.group, s.groupIndex = "", -1
+	} else {
+		s.group, s.groupIndex = title, len(c.report.Sections)-1
+	}
+	log.Printf("📑 Row %d: section %s '%s'", row, level, title)
+}
+
+// stream sectionRows ko'rmagan to'la qator: sarlavha bo'lsa keyingi to'la qatorgacha kutiladi,
+// chunki darajasi undan keyin yana sarlavha kelishiga bog'liq (sectionRows bilan bir xil qoida).
+// row - 1 dan boshlanadigan qator raqami.
+func (s *sectionState) stream(c *importCollector, sheet string, row int, title string, isSection bool) {
+	if s.pending != "" {
+		level := entity.SectionCategory
+		if isSection {
+			s.nested = true
+		} else if s.nested {
+			level = entity.SectionGroup
+		}
+		s.enter(c, sheet, s.pendingRow, s.pending, level)
+		s.pending = ""
+	}
+	if isSection {
+		s.pending, s.pendingRow = title, row
+	}
+}
+
+// flush sheet oxirida kutilayotgan sarlavha
+func (s *sectionState) flush(c *importCollector, sheet string) {
+	if s.pending == "" {
+		return
+	}
+	level := entity.SectionCategory
+	if s.nested {
+		level = entity.SectionGroup
+	}
+	s.enter(c, sheet, s.pendingRow, s.pending, level)
+	s.pending = ""
+}
+
+// apply mahsulotga joriy guruhni yozish va bo'limlar hisobini oshirish
+func (s *sectionState) apply(c *importCollector, product *entity.Product) {
+	if s.group != "" {
+		if _, ok := product.Specs[sectionSpec]; !ok {
+			product.Specs[sectionSpec] = s.group
+		}
+	}
+	for _, i := range []int{s.categoryIndex, s.groupIndex} {
+		if i >= 0 {
+			c.report.Sections[i].Products++
+		}
+	}
+}
+
+// leadingTitles top dan boshlab ketma-ket kelgan sarlavha qatorlaridan keyingi birinchi qator
+// (sarlavha qatorlari bo'lmasa top)
+func leadingTitles(rows [][]string, top int, styles map[int]rowStyle) int {
+	lead, titles := top, 0
+	for lead < len(rows) && titles <= maxLeadingTitles {
+		if isEmptyRow(rows[lead]) {
+			lead++
+			continue
+		}
+		style, styled := styles[lead]
+		if !isTitleRow(rows[lead], style, styled) {
+			return lead
+		}
+		lead++
+		titles++
+	}
+	// Faqat sarlavhalardan iborat (yoki juda ko'p) - odatdagidek o'qiladi
+	return top
+}
+
+// looksLikeData qator mahsulot qatoriga o'xshaydimi (2-ustun narx sifatida o'qiladi)
+func looksLikeData(row []string, locale entity.NumberLocale) bool {
+	if len(row) < 2 {
+		return false
+	}
+	_, err := parsePrice(strings.TrimSpace(row[1]), locale)
+	return err == nil
+}
@@ -0,0 +1,650 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/richardlehane/mscfb"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+// BIFF8 yozuv turlari (faqat katak qiymatlari, sheetlar, shrift va birlashtirish uchun keraklilari)
+const (
+	biffFormula    = 0x0006
+	biffEOF        = 0x000A
+	biffDateMode   = 0x0022
+	biffFilePass   = 0x002F
+	biffFont       = 0x0031
+	biffContinue   = 0x003C
+	biffBoundSheet = 0x0085
+	biffMulRK      = 0x00BD
+	biffRString    = 0x00D6
+	biffXF         = 0x00E0
+	biffMergeCells = 0x00E5
+	biffSST        = 0x00FC
+	biffLabelSST   = 0x00FD
+	biffNumber     = 0x0203
+	biffLabel      = 0x0204
+	biffBoolErr    = 0x0205
+	biffString     = 0x0207
+	biffRK         = 0x027E
+	biffFormat     = 0x041E
+	biffBOF        = 0x0809
+)
+
+// biffVersion8 BOF yozuvidagi BIFF8 (Excel 97-2003) versiyasi
+const biffVersion8 = 0x0600
+
+// boldWeight FONT yozuvidagi qalin shrift og'irligi
+const boldWeight = 700
+
+type biffRecord struct {
+	typ    uint16
+	data   []byte
+	offset int // Workbook oqimidagi joyi (BOUNDSHEET shu bo'yicha sheetni topadi)
+}
+
+type biffSheet struct {
+	name   string
+	offset int
+}
+
+type biffXFormat struct {
+	font   int
+	format int
+}
+
+// biffWorkbook global qismdan o'qilgan ma'lumotlar
+type biffWorkbook struct {
+	records  []biffRecord
+	byPos    map[int]int // yozuv offseti -> records indeksi
+	sheets   []biffSheet
+	sst      []string
+	fonts    []rowStyle
+	xfs      []biffXFormat
+	formats  map[int]string
+	date1904 bool
+}
+
+// readXLS Excel 97-2003 (.xls, BIFF8) faylni sheetlarga o'qish. Excel 95 va undan eski,
+// parolli va Workbook oqimi yo'q OLE2 fayllar uchun entity.ErrUnsupportedFormat qaytadi.
+func readXLS(data []byte) ([]sheetData, error) {
+	doc, err := mscfb.New(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: not a valid .xls (OLE2) file: %v", entity.ErrUnsupportedFormat, err)
+	}
+
+	var stream []byte
+	legacy, encrypted := false, false
+	for entry, err := doc.Next(); err == nil; entry, err = doc.Next() {
+		switch entry.Name {
+		case "Workbook":
+			if stream, err = io.ReadAll(entry); err != nil {
+				return nil, fmt.Errorf("failed to read xls workbook stream: %w", err)
+			}
+		case "Book":
+			legacy = true
+		case "EncryptedPackage":
+			encrypted = true
+		}
+	}
+
+	switch {
+	case stream != nil:
+	case encrypted:
+		return nil, fmt.Errorf("%w: workbook is password-protected", entity.ErrUnsupportedFormat)
+	case legacy:
+		return nil, fmt.Errorf("%w: Excel 5.0/95 workbook (BIFF5), save it as .xlsx", entity.ErrUnsupportedFormat)
+	default:
+		return nil, fmt.Errorf("%w: OLE2 file has no workbook (not an Excel file)", entity.ErrUnsupportedFormat)
+	}
+
+	wb, err := parseBIFFGlobals(stream)
+	if err != nil {
+		return nil, err
+	}
+
+	sheets := make([]sheetData, 0, len(wb.sheets))
+	for _, sheet := range wb.sheets {
+		rows, styles, err := wb.readSheet(sheet)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read sheet %q: %w", sheet.name, err)
+		}
+		sheets = append(sheets, sheetData{name: sheet.name, rows: rows, styles: styles})
+	}
+	return sheets, nil
+}
+
+// splitBIFFRecords oqimni yozuvlarga ajratish
+func splitBIFFRecords(stream []byte) []biffRecord {
+	var records []biffRecord
+	for pos := 0; pos+4 <= len(stream); {
+		typ := binary.LittleEndian.Uint16(stream[pos:])
+		size := int(binary.LittleEndian.Uint16(stream[pos+2:]))
+		end := min(pos+4+size, len(stream))
+		records = append(records, biffRecord{typ: typ, data: stream[pos+4 : end], offset: pos})
+		pos = end
+	}
+	return records
+}
+
+// parseBIFFGlobals workbook global qismi: sheetlar, umumiy satrlar jadvali, shriftlar va formatlar
+func parseBIFFGlobals(stream []byte) (*biffWorkbook, error) {
+	wb := &biffWorkbook{records: splitBIFFRecords(stream), byPos: make(map[int]int), formats: make(map[int]string)}
+	if len(wb.records) == 0 || wb.records[0].typ != biffBOF || len(wb.records[0].data) < 2 {
+		return nil, fmt.Errorf("%w: .xls workbook stream has no BOF record", entity.ErrUnsupportedFormat)
+	}
+	if version := binary.LittleEndian.Uint16(wb.records[0].data); version != biffVersion8 {
+		return nil, fmt.Errorf("%w: Excel 5.0/95 workbook (BIFF version 0x%04X), save it as .xlsx", entity.ErrUnsupportedFormat, version)
+	}
+	for i, rec := range wb.records {
+		wb.byPos[rec.offset] = i
+	}
+
+	for i := 1; i < len(wb.records); i++ {
+		rec := wb.records[i]
+		switch rec.typ {
+		case biffEOF:
+			return wb, nil
+		case biffFilePass:
+			return nil, fmt.Errorf("%w: workbook is password-protected", entity.ErrUnsupportedFormat)
+		case biffDateMode:
+			wb.date1904 = len(rec.data) >= 2 && binary.LittleEndian.Uint16(rec.data) == 1
+		case biffFont:
+			if len(rec.data) >= 8 {
+				wb.fonts = append(wb.fonts, rowStyle{
+					bold: binary.LittleEndian.Uint16(rec.data[6:]) >= boldWeight,
+					size: float64(binary.LittleEndian.Uint16(rec.data)) / 20,
+				})
+			}
+		case biffXF:
+			if len(rec.data) >= 4 {
+				wb.xfs = append(wb.xfs, biffXFormat{
+					font:   int(binary.LittleEndian.Uint16(rec.data)),
+					format: int(binary.LittleEndian.Uint16(rec.data[2:])),
+				})
+			}
+		case biffFormat:
+			if len(rec.data) >= 2 {
+				r := newBIFFReader(rec.data[2:])
+				if code, err := r.unicodeString(2); err == nil {
+					wb.formats[int(binary.LittleEndian.Uint16(rec.data))] = code
+				}
+			}
+		case biffBoundSheet:
+			if len(rec.data) < 8 || rec.data[5] != 0 {
+				continue // diagramma, makros va VB modul sheetlari
+			}
+			r := newBIFFReader(rec.data[6:])
+			name, err := r.unicodeString(1)
+			if err != nil {
+				return nil, fmt.Errorf("invalid xls sheet name: %w", err)
+			}
+			wb.sheets = append(wb.sheets, biffSheet{name: name, offset: int(binary.LittleEndian.Uint32(rec.data))})
+		case biffSST:
+			if len(rec.data) < 8 {
+				continue
+			}
+			segments := append([][]byte{rec.data[8:]}, continuations(wb.records, i)...)
+			sst, err := readSST(segments, int(binary.LittleEndian.Uint32(rec.data[4:])))
+			if err != nil {
+				return nil, fmt.Errorf("invalid xls shared strings: %w", err)
+			}
+			wb.sst = sst
+		}
+	}
+	return wb, nil
+}
+
+// continuations i-yozuvdan keyingi CONTINUE yozuvlari ma'lumoti
+func continuations(records []biffRecord, i int) [][]byte {
+	var segments [][]byte
+	for j := i + 1; j < len(records) && records[j].typ == biffContinue; j++ {
+		segments = append(segments, records[j].data)
+	}
+	return segments
+}
+
+// readSST umumiy satrlar jadvali (satrlar CONTINUE yozuvlariga bo'linib ketishi mumkin)
+func readSST(segments [][]byte, count int) ([]string, error) {
+	r := &biffReader{segs: segments}
+	sst := make([]string, 0, min(count, 1<<16))
+	for i := 0; i < count; i++ {
+		s, err := r.unicodeString(2)
+		if err != nil {
+			return sst, err
+		}
+		sst = append(sst, s)
+	}
+	return sst, nil
+}
+
+// biffCell sheet katagi qiymati va formati (XF indeksi)
+type biffCell struct {
+	value string
+	xf    int
+}
+
+// readSheet sheet oqimini qatorlar matritsasiga o'qish
+func (wb *biffWorkbook) readSheet(sheet biffSheet) ([][]string, map[int]rowStyle, error) {
+	start, ok := wb.byPos[sheet.offset]
+	if !ok || wb.records[start].typ != biffBOF {
+		return nil, nil, fmt.Errorf("sheet BOF record not found at offset %d", sheet.offset)
+	}
+
+	cells := make(map[int]map[int]biffCell)
+	set := func(row, col, xf int, value string) {
+		if value == "" {
+			return
+		}
+		if cells[row] == nil {
+			cells[row] = make(map[int]biffCell)
+		}
+		cells[row][col] = biffCell{value: value, xf: xf}
+	}
+
+	var merges []cellRange
+	pendingRow, pendingCol, pendingXF := -1, -1, 0 // satr natijali formula (qiymati keyingi STRING da)
+	depth := 0
+	for i := start; i < len(wb.records); i++ {
+		rec := wb.records[i]
+		switch rec.typ {
+		case biffBOF:
+			depth++ // ichki diagramma oqimlari o'tkazib yuboriladi
+			continue
+		case biffEOF:
+			depth--
+			if depth == 0 {
+				i = len(wb.records)
+			}
+			continue
+		}
+		if depth != 1 {
+			continue
+		}
+
+		d := rec.data
+		switch rec.typ {
+		case biffLabelSST:
+			if len(d) >= 10 {
+				if idx := int(binary.LittleEndian.Uint32(d[6:])); idx < len(wb.sst) {
+					set(cellRowCol(d, wb.sst[idx]))
+				}
+			}
+		case biffLabel, biffRString:
+			if len(d) >= 8 {
+				r := &biffReader{segs: append([][]byte{d[6:]}, continuations(wb.records, i)...)}
+				if s, err := r.unicodeString(2); err == nil {
+					set(cellRowCol(d, s))
+				}
+			}
+		case biffNumber:
+			if len(d) >= 14 {
+				row, col, xf := cellRef(d)
+				set(row, col, xf, wb.formatNumber(math.Float64frombits(binary.LittleEndian.Uint64(d[6:])), xf))
+			}
+		case biffRK:
+			if len(d) >= 10 {
+				row, col, xf := cellRef(d)
+				set(row, col, xf, wb.formatNumber(decodeRK(binary.LittleEndian.Uint32(d[6:])), xf))
+			}
+		case biffMulRK:
+			if len(d) >= 6 {
+				row := int(binary.LittleEndian.Uint16(d))
+				col := int(binary.LittleEndian.Uint16(d[2:]))
+				for p := 4; p+6 <= len(d)-2; p, col = p+6, col+1 {
+					xf := int(binary.LittleEndian.Uint16(d[p:]))
+					set(row, col, xf, wb.formatNumber(decodeRK(binary.LittleEndian.Uint32(d[p+2:])), xf))
+				}
+			}
+		case biffBoolErr:
+			if len(d) >= 8 {
+				row, col, xf := cellRef(d)
+				set(row, col, xf, boolErrValue(d[6], d[7] != 0))
+			}
+		case biffFormula:
+			if len(d) < 14 {
+				continue
+			}
+			row, col, xf := cellRef(d)
+			result := d[6:14]
+			if binary.LittleEndian.Uint16(result[6:]) != 0xFFFF {
+				set(row, col, xf, wb.formatNumber(math.Float64frombits(binary.LittleEndian.Uint64(result)), xf))
+				continue
+			}
+			switch result[0] {
+			case 0: // satr - keyingi STRING yozuvida
+				pendingRow, pendingCol, pendingXF = row, col, xf
+			case 1:
+				set(row, col, xf, boolErrValue(result[2], false))
+			case 2:
+				set(row, col, xf, boolErrValue(result[2], true))
+			}
+		case biffString:
+			if pendingRow < 0 {
+				continue
+			}
+			r := &biffReader{segs: append([][]byte{d}, continuations(wb.records, i)...)}
+			if s, err := r.unicodeString(2); err == nil {
+				set(pendingRow, pendingCol, pendingXF, s)
+			}
+			pendingRow = -1
+		case biffMergeCells:
+			if len(d) < 2 {
+				continue
+			}
+			n := int(binary.LittleEndian.Uint16(d))
+			for k, p := 0, 2; k < n && p+8 <= len(d); k, p = k+1, p+8 {
+				merges = append(merges, cellRange{
+					startRow: int(binary.LittleEndian.Uint16(d[p:])),
+					endRow:   int(binary.LittleEndian.Uint16(d[p+2:])),
+					startCol: int(binary.LittleEndian.Uint16(d[p+4:])),
+					endCol:   int(binary.LittleEndian.Uint16(d[p+6:])),
+				})
+			}
+		}
+	}
+
+	maxRow := -1
+	for row := range cells {
+		maxRow = max(maxRow, row)
+	}
+	rows := make([][]string, maxRow+1)
+	for row, cols := range cells {
+		maxCol := -1
+		for col := range cols {
+			maxCol = max(maxCol, col)
+		}
+		rows[row] = make([]string, maxCol+1)
+		for col, cell := range cols {
+			rows[row][col] = cell.value
+		}
+	}
+
+	styles := rowStyles(rows, merges, func(row, col int) (rowStyle, bool) {
+		cell, ok := cells[row][col]
+		if !ok || cell.xf >= len(wb.xfs) {
+			return rowStyle{}, false
+		}
+		font := wb.xfs[cell.xf].font
+		if font >= 4 {
+			font-- // BIFF da 4-indeksli shrift yo'q
+		}
+		if font >= len(wb.fonts) {
+			return rowStyle{}, false
+		}
+		return wb.fonts[font], true
+	})
+	return rows, styles, nil
+}
+
+// cellRef katak yozuvining boshidagi qator, ustun va XF indeksi
+func cellRef(d []byte) (int, int, int) {
+	return int(binary.LittleEndian.Uint16(d)), int(binary.LittleEndian.Uint16(d[2:])), int(binary.LittleEndian.Uint16(d[4:]))
+}
+
+func cellRowCol(d []byte, value string) (int, int, int, string) {
+	row, col, xf := cellRef(d)
+	return row, col, xf, value
+}
+
+// decodeRK RK formatidagi sonni o'qish (siqilgan butun son yoki double)
+func decodeRK(rk uint32) float64 {
+	var v float64
+	if rk&0x02 != 0 {
+		v = float64(int32(rk) >> 2)
+	} else {
+		v = math.Float64frombits(uint64(rk&0xFFFFFFFC) << 32)
+	}
+	if rk&0x01 != 0 {
+		v /= 100
+	}
+	return v
+}
+
+// boolErrValue mantiqiy qiymat yoki xato kodi matni (Excel dagi ko'rinishi)
+func boolErrValue(value byte, isError bool) string {
+	if !isError {
+		if value != 0 {
+			return "TRUE"
+		}
+		return "FALSE"
+	}
+	switch value {
+	case 0x00:
+		return "#NULL!"
+	case 0x07:
+		return "#DIV/0!"
+	case 0x0F:
+		return "#VALUE!"
+	case 0x17:
+		return "#REF!"
+	case 0x1D:
+		return "#NAME?"
+	case 0x24:
+		return "#NUM!"
+	default:
+		return "#N/A"
+	}
+}
+
+// formatNumber son katagi matni: sana formatidagi kataklar sana, qolganlari oddiy son
+func (wb *biffWorkbook) formatNumber(v float64, xf int) string {
+	if xf < len(wb.xfs) && wb.isDateFormat(wb.xfs[xf].format) {
+		base := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+		if wb.date1904 {
+			base = time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)
+		}
+		t := base.Add(time.Duration(math.Round(v*86400)) * time.Second)
+		if v == math.Trunc(v) {
+			return t.Format("2006-01-02")
+		}
+		return t.Format("2006-01-02 15:04:05")
+	}
+	// Formulalardagi 0.1+0.2 kabi ikkilik qoldiqlarni 15 xonaga yaxlitlash
+	rounded, _ := strconv.ParseFloat(strconv.FormatFloat(v, 'g', 15, 64), 64)
+	return strconv.FormatFloat(rounded, 'f', -1, 64)
+}
+
+// isDateFormat format sana/vaqtmi: o'rnatilgan 14-22, 45-47 yoki sana/vaqt tokenli maxsus format
+func (wb *biffWorkbook) isDateFormat(id int) bool {
+	switch {
+	case id >= 14 && id <= 22, id >= 45 && id <= 47:
+		return true
+	}
+	code, ok := wb.formats[id]
+	if !ok {
+		return false
+	}
+	return isDateFormatCode(code)
+}
+
+// isDateFormatCode maxsus raqam formati sana/vaqtmi. Faqat birinchi bo'lim (";" gacha) ko'riladi;
+// qo'shtirnoqdagi matn, [..] bloklari (valyuta, rang, shart) va \ bilan ekranlangan belgilar
+// hisobga olinmaydi. Format sana hisoblanadi, agar undagi barcha harf guruhlari sana/vaqt tokenlari
+// (yyyy, mm, d, h, ss, AM/PM) bo'lsa va son o'rinlari (#, ?, 0) bo'lmasa - shunda "#,##0 so'm" yoki
+// "0 UZS" kabi qo'shtirnoqsiz valyuta yozilgan formatlar narx bo'lib qoladi.
+func isDateFormatCode(code string) bool {
+	var b strings.Builder
+	inQuote, inBracket := false, false
+	for i := 0; i < len(code); i++ {
+		ch := code[i]
+		switch {
+		case inQuote:
+			inQuote = ch != '"'
+		case inBracket:
+			inBracket = ch != ']'
+		case ch == '"':
+			inQuote = true
+		case ch == '[':
+			inBracket = true
+		case ch == '\\' || ch == '_' || ch == '*':
+			_, size := utf8.DecodeRuneInString(code[i+1:])
+			i += size
+		case ch == ';':
+			i = len(code)
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	format := strings.ToLower(b.String())
+	format = strings.NewReplacer("am/pm", "", "a/p", "").Replace(format)
+
+	hasToken := false
+	prev := byte(0) // oldingi harf guruhi ("ss.000" - soniya ulushi)
+	for i := 0; i < len(format); {
+		ch := format[i]
+		switch {
+		case isFormatLetter(ch):
+			j := i
+			for j < len(format) && format[j] == ch {
+				j++
+			}
+			if strings.IndexByte("ymdhs", ch) < 0 {
+				return false // sana bo'lmagan harf (UZS, so'm, General)
+			}
+			hasToken, prev = true, ch
+			i = j
+		case ch == '0' && prev == 's' && i > 0 && (format[i-1] == '.' || format[i-1] == '0'):
+			i++
+		case ch == '#' || ch == '?' || ch == '0':
+			return false
+		default:
+			if ch != '.' {
+				prev = 0
+			}
+			i++
+		}
+	}
+	return hasToken
+}
+
+// isFormatLetter format kodidagi harf (ASCII yoki UTF-8 bayti)
+func isFormatLetter(ch byte) bool {
+	return ch >= 'a' && ch <= 'z' || ch >= 0x80
+}
+
+// biffReader CONTINUE yozuvlariga bo'lingan ma'lumotni ketma-ket o'qish
+type biffReader struct {
+	segs [][]byte
+	seg  int
+	pos  int
+}
+
+func newBIFFReader(data []byte) *biffReader {
+	return &biffReader{segs: [][]byte{data}}
+}
+
+func (r *biffReader) u8() (byte, error) {
+	for r.seg < len(r.segs) && r.pos >= len(r.segs[r.seg]) {
+		r.seg, r.pos = r.seg+1, 0
+	}
+	if r.seg >= len(r.segs) {
+		return 0, io.ErrUnexpectedEOF
+	}
+	b := r.segs[r.seg][r.pos]
+	r.pos++
+	return b, nil
+}
+
+func (r *biffReader) u16() (int, error) {
+	lo, err := r.u8()
+	if err != nil {
+		return 0, err
+	}
+	hi, err := r.u8()
+	return int(lo) | int(hi)<<8, err
+}
+
+func (r *biffReader) u32() (int, error) {
+	lo, err := r.u16()
+	if err != nil {
+		return 0, err
+	}
+	hi, err := r.u16()
+	return lo | hi<<16, err
+}
+
+func (r *biffReader) skip(n int) error {
+	for ; n > 0; n-- {
+		if _, err := r.u8(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unicodeString XLUnicodeString (lenSize=2) yoki ShortXLUnicodeString (lenSize=1).
+// Belgilar yangi CONTINUE yozuviga o'tganda u yerda kodlash bayrog'i qaytadan yoziladi.
+func (r *biffReader) unicodeString(lenSize int) (string, error) {
+	var count int
+	var err error
+	if lenSize == 1 {
+		var b byte
+		b, err = r.u8()
+		count = int(b)
+	} else {
+		count, err = r.u16()
+	}
+	if err != nil {
+		return "", err
+	}
+	flags, err := r.u8()
+	if err != nil {
+		return "", err
+	}
+
+	runs, ext := 0, 0
+	if flags&0x08 != 0 {
+		if runs, err = r.u16(); err != nil {
+			return "", err
+		}
+	}
+	if flags&0x04 != 0 {
+		if ext, err = r.u32(); err != nil {
+			return "", err
+		}
+	}
+
+	high := flags&0x01 != 0
+	units := make([]uint16, 0, count)
+	for len(units) < count {
+		if r.seg >= len(r.segs) {
+			return "", io.ErrUnexpectedEOF
+		}
+		seg := r.segs[r.seg]
+		if r.pos >= len(seg) {
+			r.seg, r.pos = r.seg+1, 0
+			if r.seg >= len(r.segs) || len(r.segs[r.seg]) == 0 {
+				return "", io.ErrUnexpectedEOF
+			}
+			high = r.segs[r.seg][0]&0x01 != 0
+			r.pos = 1
+			continue
+		}
+		if high {
+			if r.pos+1 >= len(seg) {
+				r.pos = len(seg)
+				continue
+			}
+			units = append(units, binary.LittleEndian.Uint16(seg[r.pos:]))
+			r.pos += 2
+		} else {
+			units = append(units, uint16(seg[r.pos]))
+			r.pos++
+		}
+	}
+
+	if err := r.skip(4*runs + ext); err != nil {
+		return "", err
+	}
+	return string(utf16.Decode(units)), nil
+}
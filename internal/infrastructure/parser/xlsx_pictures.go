@@ -0,0 +1,76 @@
+package parser
+
+import (
+	"log"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+// cellPicture katakka joylangan (yoki katak ustiga qo'yilgan) rasm
+type cellPicture struct {
+	col   int // 0 dan boshlanadi
+	image entity.ProductImage
+}
+
+// xlsxHasMedia faylda rasmlar (xl/media) bormi: yo'q bo'lsa sheetlar rasm uchun o'qilmaydi
+func xlsxHasMedia(f *excelize.File) bool {
+	found := false
+	f.Pkg.Range(func(key, _ any) bool {
+		if name, ok := key.(string); ok && strings.HasPrefix(name, "xl/media/") {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// xlsxPictures sheet dagi rasmlar qatorlar bo'yicha (indeks 0 dan, qator rasmning yuqori-chap
+// katagi). Telegram ko'rsatmaydigan formatlar (EMF, WMF) o'tkazib yuboriladi. Rasmlarni o'qib
+// bo'lmasa import rasmsiz davom etadi.
+func xlsxPictures(f *excelize.File, sheet string) map[int][]cellPicture {
+	cells, err := f.GetPictureCells(sheet)
+	if err != nil {
+		log.Printf("⚠️ Sheet '%s': failed to read pictures: %v", sheet, err)
+		return nil
+	}
+
+	pictures := make(map[int][]cellPicture)
+	for _, cell := range cells {
+		col, row, err := excelize.CellNameToCoordinates(cell)
+		if err != nil {
+			continue
+		}
+		pics, err := f.GetPictures(sheet, cell)
+		if err != nil {
+			log.Printf("⚠️ Sheet '%s': failed to read picture at %s: %v", sheet, cell, err)
+			continue
+		}
+		for _, pic := range pics {
+			if len(pic.File) == 0 || !entity.IsPhotoExtension(pic.Extension) {
+				continue
+			}
+			pictures[row-1] = append(pictures[row-1], cellPicture{col: col - 1, image: entity.NewProductImage(pic.File, pic.Extension)})
+		}
+	}
+	if len(pictures) > 0 {
+		log.Printf("🖼️ Sheet '%s': pictures in %d rows", sheet, len(pictures))
+	}
+	return pictures
+}
+
+// rowPicture qator rasmi: rasm ustunidagisi, u bo'lmasa qatordagi birinchisi
+func rowPicture(pictures []cellPicture, imageCol int, hasImage bool) (entity.ProductImage, bool) {
+	if len(pictures) == 0 {
+		return entity.ProductImage{}, false
+	}
+	if hasImage {
+		for _, pic := range pictures {
+			if pic.col == imageCol {
+				return pic.image, true
+			}
+		}
+	}
+	return pictures[0].image, true
+}
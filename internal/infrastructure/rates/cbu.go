@@ -0,0 +1,94 @@
+package rates
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/repository"
+)
+
+// cbuURL O'zbekiston Markaziy banki rasmiy kurslari (JSON)
+const cbuURL = "https://cbu.uz/uz/arkhiv-kursov-valyut/json/"
+
+type cbuSource struct {
+	client *http.Client
+	url    string
+}
+
+// NewCBURateSource Markaziy bank (cbu.uz) kurslari manbai
+func NewCBURateSource() repository.RateSource {
+	return &cbuSource{
+		client: &http.Client{Timeout: 15 * time.Second},
+		url:    cbuURL,
+	}
+}
+
+// cbuRate cbu.uz javobidagi bitta valyuta (qiymatlar matn ko'rinishida keladi)
+type cbuRate struct {
+	Ccy     string `json:"Ccy"`
+	Rate    string `json:"Rate"`
+	Nominal string `json:"Nominal"`
+}
+
+// Name manba nomi
+func (s *cbuSource) Name() string {
+	return "cbu"
+}
+
+// FetchRates joriy kurslarni olish (katalogda ishlatiladigan valyutalar)
+func (s *cbuSource) FetchRates(ctx context.Context) ([]entity.ExchangeRate, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("cbu.uz ga ulanib bo'lmadi: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("cbu.uz javobi: %s", resp.Status)
+	}
+
+	var list []cbuRate
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, fmt.Errorf("cbu.uz javobini o'qib bo'lmadi: %w", err)
+	}
+
+	now := time.Now()
+	var rates []entity.ExchangeRate
+	for _, item := range list {
+		currency := entity.Currency(strings.ToUpper(strings.TrimSpace(item.Ccy)))
+		if currency != entity.CurrencyUSD && currency != entity.CurrencyEUR && currency != entity.CurrencyRUB {
+			continue
+		}
+
+		rate, err := strconv.ParseFloat(strings.TrimSpace(item.Rate), 64)
+		if err != nil || rate <= 0 {
+			continue
+		}
+		if nominal, err := strconv.ParseFloat(strings.TrimSpace(item.Nominal), 64); err == nil && nominal > 0 {
+			rate /= nominal
+		}
+
+		rates = append(rates, entity.ExchangeRate{
+			Currency:  currency,
+			Rate:      rate,
+			Source:    s.Name(),
+			UpdatedAt: now,
+		})
+	}
+
+	if len(rates) == 0 {
+		return nil, fmt.Errorf("cbu.uz javobida kerakli kurslar topilmadi")
+	}
+	return rates, nil
+}
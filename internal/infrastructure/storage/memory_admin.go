@@ -82,3 +82,29 @@ func (m *memoryAdminRepository) LogAction(ctx context.Context, action entity.Adm
 	m.actions = append(m.actions, action)
 	return nil
 }
+
+// ListActions filtr bo'yicha harakatlarni olish
+func (m *memoryAdminRepository) ListActions(ctx context.Context, filter entity.AuditFilter) ([]entity.AdminAction, int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var matched []entity.AdminAction
+	for i := len(m.actions) - 1; i >= 0; i-- {
+		if filter.Match(m.actions[i]) {
+			matched = append(matched, m.actions[i])
+		}
+	}
+
+	total := len(matched)
+	if filter.Offset > 0 {
+		if filter.Offset >= len(matched) {
+			return nil, total, nil
+		}
+		matched = matched[filter.Offset:]
+	}
+	if filter.Limit > 0 && len(matched) > filter.Limit {
+		matched = matched[:filter.Limit]
+	}
+
+	return matched, total, nil
+}
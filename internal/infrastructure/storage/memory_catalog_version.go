@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/repository"
+)
+
+type memoryCatalogVersionRepository struct {
+	mu       sync.RWMutex
+	versions []entity.CatalogVersion
+}
+
+// NewMemoryCatalogVersionRepository in-memory katalog versiyalari repository
+func NewMemoryCatalogVersionRepository() repository.CatalogVersionRepository {
+	return &memoryCatalogVersionRepository{}
+}
+
+// SaveVersion katalogni yangi versiya sifatida saqlash
+func (m *memoryCatalogVersionRepository) SaveVersion(ctx context.Context, version entity.CatalogVersion) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	version.Version = len(m.versions) + 1
+	version.ProductCount = len(version.Products)
+	version.Products = append([]entity.Product(nil), version.Products...)
+	m.versions = append(m.versions, version)
+	return version.Version, nil
+}
+
+// ListVersions barcha versiyalarni olish (yangidan eskiga)
+func (m *memoryCatalogVersionRepository) ListVersions(ctx context.Context) ([]entity.CatalogVersion, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	list := make([]entity.CatalogVersion, 0, len(m.versions))
+	for i := len(m.versions) - 1; i >= 0; i-- {
+		v := m.versions[i]
+		v.Products = nil
+		list = append(list, v)
+	}
+	return list, nil
+}
+
+// GetVersion versiyani mahsulotlari bilan olish
+func (m *memoryCatalogVersionRepository) GetVersion(ctx context.Context, version int) (*entity.CatalogVersion, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if version < 1 || version > len(m.versions) {
+		return nil, fmt.Errorf("catalog version not found: %d", version)
+	}
+
+	v := m.versions[version-1]
+	v.Products = append([]entity.Product(nil), v.Products...)
+	return &v, nil
+}
@@ -120,3 +120,45 @@ func (m *memoryChatRepository) GetContext(ctx context.Context, userID int64) (*e
 
 	return chatCtx, nil
 }
+
+// PruneContexts uzoq vaqt ishlatilmagan kontekstlarni o'chirish (LastUsed bo'yicha)
+func (m *memoryChatRepository) PruneContexts(ctx context.Context, before time.Time) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	pruned := 0
+	for userID, chatCtx := range m.contexts {
+		if chatCtx.LastUsed.Before(before) {
+			delete(m.contexts, userID)
+			pruned++
+		}
+	}
+	return pruned, nil
+}
+
+// Search barcha kontekstlar bo'yicha qidiruv (yangidan eskiga)
+func (m *memoryChatRepository) Search(ctx context.Context, query string, limit int) ([]entity.MessageMatch, error) {
+	terms := searchTerms(query)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	m.mu.RLock()
+	var matches []entity.MessageMatch
+	for _, chatCtx := range m.contexts {
+		for _, msg := range chatCtx.Messages {
+			if messageMatches(msg, terms) {
+				matches = append(matches, entity.MessageMatch{Message: msg, Snippet: messageSnippet(msg, terms, 60)})
+			}
+		}
+	}
+	m.mu.RUnlock()
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].Message.Timestamp.After(matches[j].Message.Timestamp)
+	})
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches, nil
+}
@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/repository"
+)
+
+type memoryExchangeRateRepository struct {
+	mu    sync.RWMutex
+	rates map[entity.Currency]entity.ExchangeRate
+}
+
+// NewMemoryExchangeRateRepository in-memory valyuta kurslari repository
+func NewMemoryExchangeRateRepository() repository.ExchangeRateRepository {
+	return &memoryExchangeRateRepository{
+		rates: make(map[entity.Currency]entity.ExchangeRate),
+	}
+}
+
+// SaveRates kurslarni saqlash
+func (m *memoryExchangeRateRepository) SaveRates(ctx context.Context, rates []entity.ExchangeRate) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, rate := range rates {
+		m.rates[rate.Currency] = rate
+	}
+	return nil
+}
+
+// ListRates barcha kurslar (valyuta kodi bo'yicha)
+func (m *memoryExchangeRateRepository) ListRates(ctx context.Context) ([]entity.ExchangeRate, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	list := make([]entity.ExchangeRate, 0, len(m.rates))
+	for _, rate := range m.rates {
+		list = append(list, rate)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Currency < list[j].Currency
+	})
+	return list, nil
+}
@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/repository"
+)
+
+type memoryImportProfileRepository struct {
+	mu       sync.RWMutex
+	profiles map[string]entity.ImportProfile
+}
+
+// NewMemoryImportProfileRepository in-memory import profillari repository
+func NewMemoryImportProfileRepository() repository.ImportProfileRepository {
+	return &memoryImportProfileRepository{
+		profiles: make(map[string]entity.ImportProfile),
+	}
+}
+
+// ListProfiles barcha profillar (nom bo'yicha)
+func (m *memoryImportProfileRepository) ListProfiles(ctx context.Context) ([]entity.ImportProfile, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	list := make([]entity.ImportProfile, 0, len(m.profiles))
+	for _, profile := range m.profiles {
+		profile.Columns = maps.Clone(profile.Columns)
+		list = append(list, profile)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return strings.ToLower(list[i].Name) < strings.ToLower(list[j].Name)
+	})
+	return list, nil
+}
+
+// GetProfile nom bo'yicha profil
+func (m *memoryImportProfileRepository) GetProfile(ctx context.Context, name string) (*entity.ImportProfile, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	profile, ok := m.profiles[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return nil, fmt.Errorf("import profile not found: %s", name)
+	}
+	profile.Columns = maps.Clone(profile.Columns)
+	return &profile, nil
+}
+
+// SaveProfile profilni saqlash
+func (m *memoryImportProfileRepository) SaveProfile(ctx context.Context, profile entity.ImportProfile) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	profile.Columns = maps.Clone(profile.Columns)
+	m.profiles[strings.ToLower(strings.TrimSpace(profile.Name))] = profile
+	return nil
+}
+
+// DeleteProfile profilni o'chirish
+func (m *memoryImportProfileRepository) DeleteProfile(ctx context.Context, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := strings.ToLower(strings.TrimSpace(name))
+	if _, ok := m.profiles[key]; !ok {
+		return fmt.Errorf("import profile not found: %s", name)
+	}
+	delete(m.profiles, key)
+	return nil
+}
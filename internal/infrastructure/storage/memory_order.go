@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/repository"
+)
+
+type memoryOrderRepository struct {
+	mu     sync.RWMutex
+	orders map[string]entity.Order // key: order ID
+}
+
+// NewMemoryOrderRepository in-memory order repository yaratish
+func NewMemoryOrderRepository() repository.OrderRepository {
+	return &memoryOrderRepository{
+		orders: make(map[string]entity.Order),
+	}
+}
+
+// Save buyurtmani saqlash
+func (m *memoryOrderRepository) Save(ctx context.Context, order entity.Order) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	order.Items = append([]entity.OrderItem(nil), order.Items...)
+	m.orders[order.ID] = order
+	return nil
+}
+
+// GetByID ID bo'yicha buyurtmani olish
+func (m *memoryOrderRepository) GetByID(ctx context.Context, id string) (*entity.Order, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	order, exists := m.orders[id]
+	if !exists {
+		return nil, fmt.Errorf("order not found: %s", id)
+	}
+	return &order, nil
+}
+
+// ListByUser foydalanuvchi buyurtmalari
+func (m *memoryOrderRepository) ListByUser(ctx context.Context, userID int64) ([]entity.Order, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var list []entity.Order
+	for _, order := range m.orders {
+		if order.UserID == userID {
+			list = append(list, order)
+		}
+	}
+	sortOrdersDesc(list)
+	return list, nil
+}
+
+// ListByStatus holat bo'yicha buyurtmalar
+func (m *memoryOrderRepository) ListByStatus(ctx context.Context, status entity.OrderStatus, limit int) ([]entity.Order, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var list []entity.Order
+	for _, order := range m.orders {
+		if status == "" || order.Status == status {
+			list = append(list, order)
+		}
+	}
+	sortOrdersDesc(list)
+	if limit > 0 && len(list) > limit {
+		list = list[:limit]
+	}
+	return list, nil
+}
+
+// DeleteByUser foydalanuvchi buyurtmalarini o'chirish
+func (m *memoryOrderRepository) DeleteByUser(ctx context.Context, userID int64) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	deleted := 0
+	for id, order := range m.orders {
+		if order.UserID == userID {
+			delete(m.orders, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func sortOrdersDesc(list []entity.Order) {
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].CreatedAt.After(list[j].CreatedAt)
+	})
+}
@@ -0,0 +1,91 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/repository"
+)
+
+type memoryPriceAlertRepository struct {
+	mu     sync.RWMutex
+	alerts map[string]entity.PriceAlert // key: alert ID
+}
+
+// NewMemoryPriceAlertRepository in-memory narx obunalari repository
+func NewMemoryPriceAlertRepository() repository.PriceAlertRepository {
+	return &memoryPriceAlertRepository{
+		alerts: make(map[string]entity.PriceAlert),
+	}
+}
+
+// SaveAlert obunani saqlash
+func (m *memoryPriceAlertRepository) SaveAlert(ctx context.Context, alert entity.PriceAlert) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.alerts[alert.ID] = alert
+	return nil
+}
+
+// ListAlerts barcha obunalar (eskidan yangiga)
+func (m *memoryPriceAlertRepository) ListAlerts(ctx context.Context) ([]entity.PriceAlert, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	list := make([]entity.PriceAlert, 0, len(m.alerts))
+	for _, alert := range m.alerts {
+		list = append(list, alert)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].CreatedAt.Before(list[j].CreatedAt)
+	})
+	return list, nil
+}
+
+// ListByUser foydalanuvchi obunalari (yangidan eskiga)
+func (m *memoryPriceAlertRepository) ListByUser(ctx context.Context, userID int64) ([]entity.PriceAlert, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var list []entity.PriceAlert
+	for _, alert := range m.alerts {
+		if alert.UserID == userID {
+			list = append(list, alert)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].CreatedAt.After(list[j].CreatedAt)
+	})
+	return list, nil
+}
+
+// DeleteAlert obunani o'chirish
+func (m *memoryPriceAlertRepository) DeleteAlert(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.alerts[id]; !ok {
+		return fmt.Errorf("price alert not found: %s", id)
+	}
+	delete(m.alerts, id)
+	return nil
+}
+
+// DeleteByUser foydalanuvchi obunalarini o'chirish
+func (m *memoryPriceAlertRepository) DeleteByUser(ctx context.Context, userID int64) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	deleted := 0
+	for id, alert := range m.alerts {
+		if alert.UserID == userID {
+			delete(m.alerts, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/repository"
+)
+
+type memoryPriceHistoryRepository struct {
+	mu     sync.RWMutex
+	points map[string][]entity.PricePoint // key: entity.PriceKey, eskidan yangiga
+}
+
+// NewMemoryPriceHistoryRepository in-memory narxlar tarixi repository
+func NewMemoryPriceHistoryRepository() repository.PriceHistoryRepository {
+	return &memoryPriceHistoryRepository{
+		points: make(map[string][]entity.PricePoint),
+	}
+}
+
+// RecordPrices narxlarni tarixga yozish
+func (m *memoryPriceHistoryRepository) RecordPrices(ctx context.Context, points []entity.PricePoint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, p := range points {
+		m.points[p.Key] = append(m.points[p.Key], p)
+	}
+	return nil
+}
+
+// ListPrices kalit bo'yicha narxlar tarixi (yangidan eskiga)
+func (m *memoryPriceHistoryRepository) ListPrices(ctx context.Context, key string, limit int) ([]entity.PricePoint, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	// Qo'shilish tartibi teskari: bir vaqtda yozilganlardan keyingisi birinchi
+	stored := m.points[key]
+	points := make([]entity.PricePoint, 0, len(stored))
+	for i := len(stored) - 1; i >= 0; i-- {
+		points = append(points, stored[i])
+	}
+	sort.SliceStable(points, func(i, j int) bool {
+		return points[i].RecordedAt.After(points[j].RecordedAt)
+	})
+	if limit > 0 && len(points) > limit {
+		points = points[:limit]
+	}
+	return points, nil
+}
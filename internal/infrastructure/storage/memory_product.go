@@ -60,12 +60,90 @@ func (m *memoryProductRepository) GetByID(ctx context.Context, id string) (*enti
 
 // Search mahsulot qidirish
 func (m *memoryProductRepository) Search(ctx context.Context, query string) ([]entity.Product, error) {
+	m.mu.RLock()
+	products := make([]entity.Product, 0, len(m.products))
+	for _, product := range m.products {
+		products = append(products, product)
+	}
+	m.mu.RUnlock()
+
+	return searchProducts(products, query), nil
+}
+
+// GetByCategory kategoriya bo'yicha mahsulotlarni olish
+func (m *memoryProductRepository) GetByCategory(ctx context.Context, category string) ([]entity.Product, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	category = strings.ToLower(strings.TrimSpace(category))
+	var results []entity.Product
+
+	for _, product := range m.products {
+		if strings.ToLower(product.Category) == category {
+			results = append(results, product)
+		}
+	}
+
+	return results, nil
+}
+
+// GetAll barcha mahsulotlarni olish
+func (m *memoryProductRepository) GetAll(ctx context.Context) ([]entity.Product, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	products := make([]entity.Product, 0, len(m.products))
+	for _, product := range m.products {
+		products = append(products, product)
+	}
+
+	return products, nil
+}
+
+// UpdateCatalog butun katalogni yangilash. Yangi katalog lock dan tashqarida tayyorlanadi va
+// bir zumda almashtiriladi - katta katalogda ham o'qiyotganlar yarim yangilangan holatni ko'rmaydi.
+func (m *memoryProductRepository) UpdateCatalog(ctx context.Context, catalog entity.ProductCatalog) error {
+	products := make(map[string]entity.Product, len(catalog.Products))
+	for _, product := range catalog.Products {
+		products[product.ID] = product
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.products = products
+	m.catalog = &catalog
+	return nil
+}
+
+// GetCatalog katalogni olish
+func (m *memoryProductRepository) GetCatalog(ctx context.Context) (*entity.ProductCatalog, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
+	if m.catalog == nil {
+		return nil, fmt.Errorf("catalog not found")
+	}
+
+	return m.catalog, nil
+}
+
+// Clear barcha mahsulotlarni o'chirish
+func (m *memoryProductRepository) Clear(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.products = make(map[string]entity.Product)
+	m.catalog = nil
+	return nil
+}
+
+// searchProducts mahsulotlar ro'yxatidan so'rov bo'yicha qidirish.
+// Memory va SQLite repository lar bir xil natija berishi uchun umumiy.
+func searchProducts(products []entity.Product, query string) []entity.Product {
 	query = strings.ToLower(strings.TrimSpace(query))
 	if query == "" {
-		return nil, nil
+		return nil
 	}
 	queryNorm := normalizeProductString(query)
 	tokens := filterTokens(queryTokens(query))
@@ -76,7 +154,7 @@ func (m *memoryProductRepository) Search(ctx context.Context, query string) ([]e
 	var results []entity.Product
 	var scored []scoredProduct
 
-	for _, product := range m.products {
+	for _, product := range products {
 		nameLower := strings.ToLower(product.Name)
 		catLower := strings.ToLower(product.Category)
 		descLower := strings.ToLower(product.Description)
@@ -150,78 +228,22 @@ func (m *memoryProductRepository) Search(ctx context.Context, query string) ([]e
 		}
 	}
 
-	// MUHIM: Agar hech narsa topilmasa, bo'sh massiv qaytaramiz
-	// Tasodifiy mahsulotlar ko'rsatmaydi!
-	return results, nil
-}
-
-// GetByCategory kategoriya bo'yicha mahsulotlarni olish
-func (m *memoryProductRepository) GetByCategory(ctx context.Context, category string) ([]entity.Product, error) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
-
-	category = strings.ToLower(strings.TrimSpace(category))
-	var results []entity.Product
-
-	for _, product := range m.products {
-		if strings.ToLower(product.Category) == category {
-			results = append(results, product)
+	// So'rovda texnik atribut bo'lsa ("am5", "ddr5", "rtx 4060 8gb"), unga zid mahsulotlar chiqariladi
+	if want := entity.ExtractAttributes(query, "", nil); !want.IsZero() && len(results) > 0 {
+		filtered := make([]entity.Product, 0, len(results))
+		for _, p := range results {
+			if p.Attributes.Matches(want) {
+				filtered = append(filtered, p)
+			}
+		}
+		if len(filtered) > 0 {
+			results = filtered
 		}
 	}
 
-	return results, nil
-}
-
-// GetAll barcha mahsulotlarni olish
-func (m *memoryProductRepository) GetAll(ctx context.Context) ([]entity.Product, error) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
-
-	products := make([]entity.Product, 0, len(m.products))
-	for _, product := range m.products {
-		products = append(products, product)
-	}
-
-	return products, nil
-}
-
-// UpdateCatalog butun katalogni yangilash
-func (m *memoryProductRepository) UpdateCatalog(ctx context.Context, catalog entity.ProductCatalog) error {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-
-	// Eski mahsulotlarni o'chirish
-	m.products = make(map[string]entity.Product)
-
-	// Yangi mahsulotlarni qo'shish
-	for _, product := range catalog.Products {
-		m.products[product.ID] = product
-	}
-
-	m.catalog = &catalog
-	return nil
-}
-
-// GetCatalog katalogni olish
-func (m *memoryProductRepository) GetCatalog(ctx context.Context) (*entity.ProductCatalog, error) {
-	m.mu.RLock()
-	defer m.mu.RUnlock()
-
-	if m.catalog == nil {
-		return nil, fmt.Errorf("catalog not found")
-	}
-
-	return m.catalog, nil
-}
-
-// Clear barcha mahsulotlarni o'chirish
-func (m *memoryProductRepository) Clear(ctx context.Context) error {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-
-	m.products = make(map[string]entity.Product)
-	m.catalog = nil
-	return nil
+	// MUHIM: Agar hech narsa topilmasa, bo'sh massiv qaytaramiz
+	// Tasodifiy mahsulotlar ko'rsatmaydi!
+	return results
 }
 
 // Qidiruv yordamchi funksiyalar
@@ -388,6 +410,9 @@ func isGPUQuery(q string) bool {
 }
 
 func isGPUProduct(p entity.Product) bool {
+	if p.Attributes.VRAMGB > 0 {
+		return true
+	}
 	name := strings.ToLower(p.Name)
 	cat := strings.ToLower(p.Category)
 	if strings.Contains(cat, "gpu") || strings.Contains(cat, "video") || strings.Contains(cat, "karta") || strings.Contains(cat, "videokarta") {
@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/repository"
+)
+
+type memoryProductImageRepository struct {
+	mu     sync.RWMutex
+	images map[string]entity.ProductImage
+}
+
+// NewMemoryProductImageRepository in-memory mahsulot rasmlari repository
+func NewMemoryProductImageRepository() repository.ProductImageRepository {
+	return &memoryProductImageRepository{
+		images: make(map[string]entity.ProductImage),
+	}
+}
+
+// SaveImages rasmlarni saqlash (mavjudlari o'zgarmaydi)
+func (m *memoryProductImageRepository) SaveImages(ctx context.Context, images []entity.ProductImage) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, image := range images {
+		if _, ok := m.images[image.ID]; !ok {
+			m.images[image.ID] = image
+		}
+	}
+	return nil
+}
+
+// GetImage ID bo'yicha rasm
+func (m *memoryProductImageRepository) GetImage(ctx context.Context, id string) (*entity.ProductImage, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	image, ok := m.images[id]
+	if !ok {
+		return nil, fmt.Errorf("product image not found: %s", id)
+	}
+	return &image, nil
+}
+
+// SetFileID Telegram file_id ni saqlash
+func (m *memoryProductImageRepository) SetFileID(ctx context.Context, id, fileID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	image, ok := m.images[id]
+	if !ok {
+		return fmt.Errorf("product image not found: %s", id)
+	}
+	image.FileID = fileID
+	m.images[id] = image
+	return nil
+}
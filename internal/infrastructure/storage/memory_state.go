@@ -0,0 +1,112 @@
+package storage
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/repository"
+)
+
+type memoryStateRepository struct {
+	mu      sync.RWMutex
+	entries map[string]map[string]entity.StateEntry // namespace -> key -> entry
+}
+
+// NewMemoryStateRepository in-memory state repository (restartdan keyin saqlanmaydi)
+func NewMemoryStateRepository() repository.StateRepository {
+	return &memoryStateRepository{
+		entries: make(map[string]map[string]entity.StateEntry),
+	}
+}
+
+// Put holatni saqlash
+func (m *memoryStateRepository) Put(ctx context.Context, entry entity.StateEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if entry.UpdatedAt.IsZero() {
+		entry.UpdatedAt = time.Now()
+	}
+	entry.Value = append([]byte(nil), entry.Value...)
+
+	ns, ok := m.entries[entry.Namespace]
+	if !ok {
+		ns = make(map[string]entity.StateEntry)
+		m.entries[entry.Namespace] = ns
+	}
+	ns[entry.Key] = entry
+	return nil
+}
+
+// Get holatni olish
+func (m *memoryStateRepository) Get(ctx context.Context, namespace, key string) (*entity.StateEntry, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	entry, ok := m.entries[namespace][key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry.Value = append([]byte(nil), entry.Value...)
+	return &entry, true, nil
+}
+
+// Delete holatni o'chirish
+func (m *memoryStateRepository) Delete(ctx context.Context, namespace, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.entries[namespace], key)
+	return nil
+}
+
+// ListByUser foydalanuvchi holatlari
+func (m *memoryStateRepository) ListByUser(ctx context.Context, userID int64) ([]entity.StateEntry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var list []entity.StateEntry
+	for _, ns := range m.entries {
+		for _, entry := range ns {
+			if entry.UserID == userID {
+				entry.Value = append([]byte(nil), entry.Value...)
+				list = append(list, entry)
+			}
+		}
+	}
+	return list, nil
+}
+
+// DeleteByUser foydalanuvchi holatlarini o'chirish
+func (m *memoryStateRepository) DeleteByUser(ctx context.Context, userID int64) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	deleted := 0
+	for _, ns := range m.entries {
+		for key, entry := range ns {
+			if entry.UserID == userID {
+				delete(ns, key)
+				deleted++
+			}
+		}
+	}
+	return deleted, nil
+}
+
+// DeleteOlderThan eskirgan holatlarni o'chirish
+func (m *memoryStateRepository) DeleteOlderThan(ctx context.Context, namespace string, before time.Time) ([]entity.StateEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var expired []entity.StateEntry
+	for key, entry := range m.entries[namespace] {
+		if entry.UpdatedAt.Before(before) {
+			expired = append(expired, entry)
+			delete(m.entries[namespace], key)
+		}
+	}
+	return expired, nil
+}
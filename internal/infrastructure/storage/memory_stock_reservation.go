@@ -0,0 +1,114 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/repository"
+)
+
+type memoryStockReservationRepository struct {
+	mu           sync.RWMutex
+	reservations map[string][]entity.StockReservation // key: order ID
+	productRepo  repository.ProductRepository         // CommitOrder da ombordagi sonni kamaytirish uchun
+}
+
+// NewMemoryStockReservationRepository in-memory ombor bronlari repository
+func NewMemoryStockReservationRepository(productRepo repository.ProductRepository) repository.StockReservationRepository {
+	return &memoryStockReservationRepository{
+		reservations: make(map[string][]entity.StockReservation),
+		productRepo:  productRepo,
+	}
+}
+
+// SaveReservations buyurtma bronlarini saqlash
+func (m *memoryStockReservationRepository) SaveReservations(ctx context.Context, orderID string, reservations []entity.StockReservation) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	list := make([]entity.StockReservation, len(reservations))
+	for i, r := range reservations {
+		r.OrderID = orderID
+		list[i] = r
+	}
+	if len(list) == 0 {
+		delete(m.reservations, orderID)
+		return nil
+	}
+	m.reservations[orderID] = list
+	return nil
+}
+
+// ListReservations barcha ochiq bronlar (eskidan yangiga)
+func (m *memoryStockReservationRepository) ListReservations(ctx context.Context) ([]entity.StockReservation, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var list []entity.StockReservation
+	for _, reservations := range m.reservations {
+		list = append(list, reservations...)
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].CreatedAt.Before(list[j].CreatedAt)
+	})
+	return list, nil
+}
+
+// ListByOrder buyurtma bronlari
+func (m *memoryStockReservationRepository) ListByOrder(ctx context.Context, orderID string) ([]entity.StockReservation, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return append([]entity.StockReservation(nil), m.reservations[orderID]...), nil
+}
+
+// DeleteByOrder buyurtma bronlarini o'chirish
+func (m *memoryStockReservationRepository) DeleteByOrder(ctx context.Context, orderID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.reservations, orderID)
+	return nil
+}
+
+// CommitOrder bronlangan sonni mahsulotlar qoldig'idan ayirish va bronlarni o'chirish
+func (m *memoryStockReservationRepository) CommitOrder(ctx context.Context, orderID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	reservations := m.reservations[orderID]
+	if len(reservations) == 0 {
+		return nil
+	}
+
+	products, err := m.productRepo.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("katalogni o'qib bo'lmadi: %w", err)
+	}
+	required := make(map[string]int, len(reservations))
+	for _, r := range reservations {
+		required[r.Key] += r.Quantity
+	}
+
+	now := time.Now()
+	for _, p := range products {
+		key := entity.PriceKey(p)
+		quantity, ok := required[key]
+		if !ok || !p.StockTracked {
+			continue
+		}
+		delete(required, key)
+		p.Stock = max(p.Stock-quantity, 0)
+		p.UpdatedAt = now
+		if err := m.productRepo.SaveProduct(ctx, p); err != nil {
+			return err
+		}
+	}
+
+	delete(m.reservations, orderID)
+	return nil
+}
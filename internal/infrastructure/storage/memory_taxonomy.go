@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/repository"
+)
+
+type memoryTaxonomyRepository struct {
+	mu     sync.RWMutex
+	rules  map[int]entity.CategoryRule
+	names  map[string]entity.CategoryName
+	nextID int
+}
+
+// NewMemoryTaxonomyRepository in-memory kategoriya taksonomiyasi repository
+func NewMemoryTaxonomyRepository() repository.TaxonomyRepository {
+	return &memoryTaxonomyRepository{
+		rules:  make(map[int]entity.CategoryRule),
+		names:  make(map[string]entity.CategoryName),
+		nextID: 1,
+	}
+}
+
+// ListRules saqlangan qoidalar (ID bo'yicha)
+func (m *memoryTaxonomyRepository) ListRules(ctx context.Context) ([]entity.CategoryRule, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	list := make([]entity.CategoryRule, 0, len(m.rules))
+	for _, rule := range m.rules {
+		list = append(list, cloneRule(rule))
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].ID < list[j].ID
+	})
+	return list, nil
+}
+
+// SaveRule qoidani saqlash
+func (m *memoryTaxonomyRepository) SaveRule(ctx context.Context, rule entity.CategoryRule) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if rule.ID == 0 {
+		rule.ID = m.nextID
+	}
+	if rule.ID >= m.nextID {
+		m.nextID = rule.ID + 1
+	}
+	m.rules[rule.ID] = cloneRule(rule)
+	return rule.ID, nil
+}
+
+// DeleteRule qoidani o'chirish
+func (m *memoryTaxonomyRepository) DeleteRule(ctx context.Context, id int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.rules[id]; !ok {
+		return fmt.Errorf("category rule not found: %d", id)
+	}
+	delete(m.rules, id)
+	return nil
+}
+
+// ReplaceRules barcha qoidalarni almashtirish
+func (m *memoryTaxonomyRepository) ReplaceRules(ctx context.Context, rules []entity.CategoryRule) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.rules = make(map[int]entity.CategoryRule, len(rules))
+	m.nextID = 1
+	for _, rule := range rules {
+		if rule.ID == 0 {
+			rule.ID = m.nextID
+		}
+		if rule.ID >= m.nextID {
+			m.nextID = rule.ID + 1
+		}
+		m.rules[rule.ID] = cloneRule(rule)
+	}
+	return nil
+}
+
+// ListCategoryNames kategoriya nomlari (kategoriya bo'yicha)
+func (m *memoryTaxonomyRepository) ListCategoryNames(ctx context.Context) ([]entity.CategoryName, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	list := make([]entity.CategoryName, 0, len(m.names))
+	for _, name := range m.names {
+		list = append(list, name)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Category < list[j].Category
+	})
+	return list, nil
+}
+
+// SaveCategoryName kategoriya nomini saqlash
+func (m *memoryTaxonomyRepository) SaveCategoryName(ctx context.Context, name entity.CategoryName) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.names[strings.ToLower(name.Category)] = name
+	return nil
+}
+
+// cloneRule qoida nusxasi (slice lar chaqiruvchi bilan bo'lishilmaydi)
+func cloneRule(rule entity.CategoryRule) entity.CategoryRule {
+	rule.Patterns = append([]string(nil), rule.Patterns...)
+	rule.Exclude = append([]string(nil), rule.Exclude...)
+	return rule
+}
@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+// searchTerms qidiruv so'rovini kichik harfli so'zlarga ajratish
+func searchTerms(query string) []string {
+	return strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// messageMatches xabar barcha so'zlarni (matn yoki javobda) o'z ichiga oladimi
+func messageMatches(msg entity.Message, terms []string) bool {
+	if len(terms) == 0 {
+		return false
+	}
+	haystack := strings.ToLower(msg.Text + "\n" + msg.Response)
+	for _, term := range terms {
+		if !strings.Contains(haystack, term) {
+			return false
+		}
+	}
+	return true
+}
+
+// messageSnippet birinchi moslik atrofidagi qisqa parcha (FTS5 bo'lmaganda ishlatiladi)
+func messageSnippet(msg entity.Message, terms []string, radius int) string {
+	for _, source := range []string{msg.Text, msg.Response} {
+		runes := []rune(source)
+		lower := []rune(strings.ToLower(source))
+		if len(lower) != len(runes) {
+			// Kichik harfga o'tkazganda uzunlik o'zgarsa (kamdan-kam), belgisiz parcha
+			lower = runes
+		}
+		for _, term := range terms {
+			idx := indexRunes(lower, []rune(term))
+			if idx < 0 {
+				continue
+			}
+			end := idx + len([]rune(term))
+			from := max(0, idx-radius)
+			to := min(len(runes), end+radius)
+
+			var sb strings.Builder
+			if from > 0 {
+				sb.WriteString("…")
+			}
+			sb.WriteString(string(runes[from:idx]))
+			sb.WriteString("«" + string(runes[idx:end]) + "»")
+			sb.WriteString(string(runes[end:to]))
+			if to < len(runes) {
+				sb.WriteString("…")
+			}
+			return strings.Join(strings.Fields(sb.String()), " ")
+		}
+	}
+	return ""
+}
+
+func indexRunes(s, sub []rune) int {
+	for i := 0; i+len(sub) <= len(s); i++ {
+		match := true
+		for j := range sub {
+			if s[i+j] != sub[j] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return i
+		}
+	}
+	return -1
+}
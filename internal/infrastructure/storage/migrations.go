@@ -0,0 +1,333 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// migration bitta sxema o'zgarishi. Versiyalar ketma-ket (1, 2, 3...) bo'lishi shart.
+type migration struct {
+	Version int
+	Name    string
+	Up      string
+}
+
+// migrations barcha SQLite repositorylar uchun umumiy ro'yxat (bitta baza fayli).
+// Yangi o'zgarish faqat oxiriga qo'shiladi, mavjud migratsiyalar tahrirlanmaydi.
+// Dastlabki migratsiyalar IF NOT EXISTS bilan yozilgan, chunki eski bazalarda
+// jadvallar schema_version paydo bo'lishidan oldin yaratilgan.
+var migrations = []migration{
+	{
+		Version: 1,
+		Name:    "chat messages",
+		Up: `
+CREATE TABLE IF NOT EXISTS messages (
+	id TEXT PRIMARY KEY,
+	user_id INTEGER NOT NULL,
+	username TEXT,
+	text TEXT,
+	response TEXT,
+	ts TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_messages_user_ts ON messages (user_id, ts);
+`,
+	},
+	{
+		Version: 2,
+		Name:    "products and catalog meta",
+		Up: `
+CREATE TABLE IF NOT EXISTS products (
+	id TEXT PRIMARY KEY,
+	name TEXT NOT NULL,
+	category TEXT,
+	price REAL NOT NULL DEFAULT 0,
+	description TEXT,
+	stock INTEGER NOT NULL DEFAULT 0,
+	specs TEXT,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_products_category ON products (category);
+CREATE TABLE IF NOT EXISTS catalog_meta (
+	id INTEGER PRIMARY KEY CHECK (id = 1),
+	source TEXT,
+	updated_at TIMESTAMP NOT NULL
+);
+`,
+	},
+	{
+		Version: 3,
+		Name:    "catalog versions",
+		Up: `
+CREATE TABLE IF NOT EXISTS catalog_versions (
+	version INTEGER PRIMARY KEY AUTOINCREMENT,
+	source TEXT,
+	uploaded_by INTEGER NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	product_count INTEGER NOT NULL,
+	products TEXT NOT NULL
+);
+`,
+	},
+	{
+		Version: 4,
+		Name:    "admin sessions and audit log",
+		Up: `
+CREATE TABLE IF NOT EXISTS admin_sessions (
+	user_id INTEGER PRIMARY KEY,
+	is_admin INTEGER NOT NULL,
+	login_time TIMESTAMP NOT NULL,
+	last_activity TIMESTAMP NOT NULL
+);
+CREATE TABLE IF NOT EXISTS admin_actions (
+	id TEXT PRIMARY KEY,
+	user_id INTEGER NOT NULL,
+	action TEXT NOT NULL,
+	details TEXT,
+	ts TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_admin_actions_ts ON admin_actions (ts);
+CREATE INDEX IF NOT EXISTS idx_admin_actions_user ON admin_actions (user_id, ts);
+`,
+	},
+	{
+		Version: 5,
+		Name:    "orders",
+		Up: `
+CREATE TABLE IF NOT EXISTS orders (
+	id TEXT PRIMARY KEY,
+	user_id INTEGER NOT NULL,
+	chat_id INTEGER NOT NULL,
+	username TEXT,
+	customer_name TEXT,
+	phone TEXT,
+	location TEXT,
+	delivery_method TEXT,
+	note TEXT,
+	summary TEXT,
+	items TEXT NOT NULL,
+	total REAL NOT NULL DEFAULT 0,
+	status TEXT NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_orders_user ON orders (user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_orders_status ON orders (status, created_at);
+`,
+	},
+	{
+		Version: 6,
+		Name:    "bot state",
+		Up: `
+CREATE TABLE IF NOT EXISTS bot_state (
+	namespace TEXT NOT NULL,
+	key TEXT NOT NULL,
+	user_id INTEGER NOT NULL DEFAULT 0,
+	value BLOB NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	PRIMARY KEY (namespace, key)
+);
+CREATE INDEX IF NOT EXISTS idx_bot_state_user ON bot_state (user_id);
+`,
+	},
+	{
+		Version: 7,
+		Name:    "product sku and discontinued flag",
+		Up: `
+ALTER TABLE products ADD COLUMN sku TEXT NOT NULL DEFAULT '';
+ALTER TABLE products ADD COLUMN discontinued INTEGER NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS idx_products_sku ON products (sku);
+`,
+	},
+	{
+		Version: 8,
+		Name:    "price currency and exchange rates",
+		Up: `
+ALTER TABLE products ADD COLUMN currency TEXT NOT NULL DEFAULT 'USD';
+ALTER TABLE orders ADD COLUMN currency TEXT NOT NULL DEFAULT 'USD';
+CREATE TABLE IF NOT EXISTS exchange_rates (
+	currency TEXT PRIMARY KEY,
+	rate REAL NOT NULL,
+	source TEXT,
+	updated_at TIMESTAMP NOT NULL
+);
+`,
+	},
+	{
+		Version: 9,
+		Name:    "product hardware attributes",
+		Up: `
+ALTER TABLE products ADD COLUMN attributes TEXT NOT NULL DEFAULT '';
+`,
+	},
+	{
+		Version: 10,
+		Name:    "category taxonomy",
+		Up: `
+ALTER TABLE products ADD COLUMN category_auto INTEGER NOT NULL DEFAULT 0;
+CREATE TABLE IF NOT EXISTS category_rules (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	category TEXT NOT NULL,
+	patterns TEXT NOT NULL,
+	exclude TEXT NOT NULL DEFAULT '[]',
+	priority INTEGER NOT NULL DEFAULT 0
+);
+CREATE TABLE IF NOT EXISTS category_names (
+	category TEXT PRIMARY KEY COLLATE NOCASE,
+	name_uz TEXT NOT NULL DEFAULT '',
+	name_ru TEXT NOT NULL DEFAULT ''
+);
+`,
+	},
+	{
+		Version: 11,
+		Name:    "import profiles",
+		Up: `
+CREATE TABLE IF NOT EXISTS import_profiles (
+	name TEXT PRIMARY KEY COLLATE NOCASE,
+	columns TEXT NOT NULL,
+	currency TEXT NOT NULL DEFAULT '',
+	header_row INTEGER NOT NULL DEFAULT 0,
+	data_row INTEGER NOT NULL DEFAULT 0,
+	updated_by INTEGER NOT NULL DEFAULT 0,
+	updated_at TIMESTAMP NOT NULL
+);
+`,
+	},
+	{
+		Version: 12,
+		Name:    "product images",
+		Up: `
+ALTER TABLE products ADD COLUMN image TEXT NOT NULL DEFAULT '';
+CREATE TABLE IF NOT EXISTS product_images (
+	id TEXT PRIMARY KEY,
+	data BLOB NOT NULL,
+	extension TEXT NOT NULL DEFAULT '',
+	file_id TEXT NOT NULL DEFAULT '',
+	created_at TIMESTAMP NOT NULL
+);
+`,
+	},
+	{
+		Version: 13,
+		Name:    "price history and alerts",
+		Up: `
+CREATE TABLE IF NOT EXISTS price_history (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	product_key TEXT NOT NULL,
+	product_id TEXT NOT NULL DEFAULT '',
+	name TEXT NOT NULL,
+	price REAL NOT NULL,
+	currency TEXT NOT NULL DEFAULT '',
+	version INTEGER NOT NULL DEFAULT 0,
+	recorded_at TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_price_history_key ON price_history(product_key, recorded_at);
+CREATE TABLE IF NOT EXISTS price_alerts (
+	id TEXT PRIMARY KEY,
+	user_id INTEGER NOT NULL,
+	chat_id INTEGER NOT NULL,
+	product_id TEXT NOT NULL DEFAULT '',
+	product_key TEXT NOT NULL,
+	product_name TEXT NOT NULL,
+	threshold REAL NOT NULL,
+	currency TEXT NOT NULL DEFAULT '',
+	created_at TIMESTAMP NOT NULL,
+	notified_at TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_price_alerts_user ON price_alerts(user_id);
+`,
+	},
+	{
+		Version: 14,
+		Name:    "stock reservations",
+		Up: `
+ALTER TABLE products ADD COLUMN stock_tracked INTEGER NOT NULL DEFAULT 0;
+CREATE TABLE IF NOT EXISTS stock_reservations (
+	order_id TEXT NOT NULL,
+	product_key TEXT NOT NULL,
+	product_id TEXT NOT NULL DEFAULT '',
+	name TEXT NOT NULL,
+	quantity INTEGER NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	PRIMARY KEY (order_id, product_key)
+);
+`,
+	},
+}
+
+// latestSchemaVersion dastur biladigan eng yangi sxema versiyasi
+func latestSchemaVersion() int {
+	return migrations[len(migrations)-1].Version
+}
+
+// migrate bazani eng yangi versiyaga olib chiqish.
+// Har bir qadam alohida tranzaksiyada bajariladi; baza dasturdan yangiroq bo'lsa xatolik.
+func migrate(db *sql.DB) error {
+	const versionTable = `
+CREATE TABLE IF NOT EXISTS schema_version (
+	version INTEGER PRIMARY KEY,
+	name TEXT NOT NULL,
+	applied_at TIMESTAMP NOT NULL
+);
+`
+	if _, err := db.Exec(versionTable); err != nil {
+		return fmt.Errorf("schema_version jadvalini yaratib bo'lmadi: %w", err)
+	}
+
+	current, err := currentSchemaVersion(db)
+	if err != nil {
+		return err
+	}
+	if latest := latestSchemaVersion(); current > latest {
+		return fmt.Errorf("baza sxemasi (v%d) dastur versiyasidan (v%d) yangiroq, eski binary bilan ishga tushirib bo'lmaydi", current, latest)
+	}
+
+	for _, m := range migrations {
+		if m.Version <= current {
+			continue
+		}
+		if err := applyMigration(db, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func currentSchemaVersion(db *sql.DB) (int, error) {
+	var version int
+	if err := db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_version`).Scan(&version); err != nil {
+		return 0, fmt.Errorf("sxema versiyasini o'qib bo'lmadi: %w", err)
+	}
+	return version, nil
+}
+
+func applyMigration(db *sql.DB, m migration) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	// Boshqa repository shu bazani parallel migratsiya qilgan bo'lishi mumkin
+	var applied int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM schema_version WHERE version = ?`, m.Version).Scan(&applied); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if applied > 0 {
+		return tx.Rollback()
+	}
+
+	if _, err := tx.Exec(m.Up); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("migratsiya %d (%s) bajarilmadi: %w", m.Version, m.Name, err)
+	}
+	if _, err := tx.Exec(`INSERT INTO schema_version (version, name, applied_at) VALUES (?, ?, ?)`,
+		m.Version, m.Name, time.Now().UTC()); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,35 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// openSQLite SQLite bazasini ochish (kerak bo'lsa papkasini yaratadi) va migratsiyalarni bajarish.
+// Bir nechta repository bitta faylni ishlatgani uchun busy_timeout qo'yiladi.
+func openSQLite(dbPath string) (*sql.DB, error) {
+	if dbPath == "" {
+		return nil, errors.New("db path bo'sh bo'lmasligi kerak")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dbPath), 0o755); err != nil {
+		return nil, fmt.Errorf("db papkasini yaratib bo'lmadi: %w", err)
+	}
+
+	db, err := sql.Open("sqlite3", dbPath+"?_busy_timeout=5000")
+	if err != nil {
+		return nil, fmt.Errorf("sqlite ochilmadi: %w", err)
+	}
+
+	if err := migrate(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
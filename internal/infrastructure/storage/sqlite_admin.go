@@ -0,0 +1,139 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/repository"
+)
+
+type sqliteAdminRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteAdminRepository SQLite asosidagi admin repository (sessiyalar va audit log)
+func NewSQLiteAdminRepository(dbPath string) (repository.AdminRepository, error) {
+	db, err := openSQLite(dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqliteAdminRepository{db: db}, nil
+}
+
+// CreateSession admin sessiyasini yaratish
+func (s *sqliteAdminRepository) CreateSession(ctx context.Context, session entity.AdminSession) error {
+	session.LastActivity = time.Now()
+	_, err := s.db.ExecContext(ctx, `INSERT OR REPLACE INTO admin_sessions (user_id, is_admin, login_time, last_activity) VALUES (?, ?, ?, ?)`,
+		session.UserID, session.IsAdmin, session.LoginTime, session.LastActivity)
+	return err
+}
+
+// GetSession sessiyani olish
+func (s *sqliteAdminRepository) GetSession(ctx context.Context, userID int64) (*entity.AdminSession, error) {
+	var session entity.AdminSession
+	err := s.db.QueryRowContext(ctx, `SELECT user_id, is_admin, login_time, last_activity FROM admin_sessions WHERE user_id = ?`, userID).
+		Scan(&session.UserID, &session.IsAdmin, &session.LoginTime, &session.LastActivity)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("session not found for user %d", userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// DeleteSession sessiyani o'chirish (logout)
+func (s *sqliteAdminRepository) DeleteSession(ctx context.Context, userID int64) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM admin_sessions WHERE user_id = ?`, userID)
+	return err
+}
+
+// IsAdmin foydalanuvchi admin ekanligini tekshirish
+func (s *sqliteAdminRepository) IsAdmin(ctx context.Context, userID int64) (bool, error) {
+	session, err := s.GetSession(ctx, userID)
+	if err != nil {
+		return false, nil
+	}
+
+	// Session timeout tekshirish (24 soat)
+	if time.Since(session.LastActivity) > 24*time.Hour {
+		return false, nil
+	}
+
+	return session.IsAdmin, nil
+}
+
+// LogAction admin harakatini loglash
+func (s *sqliteAdminRepository) LogAction(ctx context.Context, action entity.AdminAction) error {
+	_, err := s.db.ExecContext(ctx, `INSERT OR REPLACE INTO admin_actions (id, user_id, action, details, ts) VALUES (?, ?, ?, ?, ?)`,
+		action.ID, action.UserID, action.Action, action.Details, action.Timestamp.UTC())
+	return err
+}
+
+// ListActions filtr bo'yicha harakatlarni olish
+func (s *sqliteAdminRepository) ListActions(ctx context.Context, filter entity.AuditFilter) ([]entity.AdminAction, int, error) {
+	// Vaqtlar UTC da saqlanadi, shuning uchun matn ko'rinishida ham to'g'ri solishtiriladi
+	var where []string
+	var args []any
+	if filter.UserID != 0 {
+		where = append(where, "user_id = ?")
+		args = append(args, filter.UserID)
+	}
+	if filter.Action != "" {
+		where = append(where, "action = ?")
+		args = append(args, filter.Action)
+	}
+	if !filter.From.IsZero() {
+		where = append(where, "ts >= ?")
+		args = append(args, filter.From.UTC())
+	}
+	if !filter.To.IsZero() {
+		where = append(where, "ts < ?")
+		args = append(args, filter.To.UTC())
+	}
+
+	cond := ""
+	if len(where) > 0 {
+		cond = " WHERE " + strings.Join(where, " AND ")
+	}
+
+	var total int
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM admin_actions`+cond, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `SELECT id, user_id, action, details, ts FROM admin_actions` + cond + ` ORDER BY ts DESC`
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", filter.Limit)
+		if filter.Offset > 0 {
+			query += fmt.Sprintf(" OFFSET %d", filter.Offset)
+		}
+	} else if filter.Offset > 0 {
+		query += fmt.Sprintf(" LIMIT -1 OFFSET %d", filter.Offset)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var actions []entity.AdminAction
+	for rows.Next() {
+		var action entity.AdminAction
+		var details sql.NullString
+		if err := rows.Scan(&action.ID, &action.UserID, &action.Action, &details, &action.Timestamp); err != nil {
+			return nil, 0, err
+		}
+		action.Details = details.String
+		action.Timestamp = action.Timestamp.Local()
+		actions = append(actions, action)
+	}
+	return actions, total, rows.Err()
+}
@@ -0,0 +1,88 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/repository"
+)
+
+type sqliteCatalogVersionRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteCatalogVersionRepository SQLite asosidagi katalog versiyalari repository
+func NewSQLiteCatalogVersionRepository(dbPath string) (repository.CatalogVersionRepository, error) {
+	db, err := openSQLite(dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqliteCatalogVersionRepository{db: db}, nil
+}
+
+// SaveVersion katalogni yangi versiya sifatida saqlash
+func (s *sqliteCatalogVersionRepository) SaveVersion(ctx context.Context, version entity.CatalogVersion) (int, error) {
+	products, err := json.Marshal(version.Products)
+	if err != nil {
+		return 0, fmt.Errorf("versiya mahsulotlarini saqlab bo'lmadi: %w", err)
+	}
+
+	res, err := s.db.ExecContext(ctx, `INSERT INTO catalog_versions (source, uploaded_by, created_at, product_count, products) VALUES (?, ?, ?, ?, ?)`,
+		version.Source, version.UploadedBy, version.CreatedAt, len(version.Products), string(products))
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+// ListVersions barcha versiyalarni olish (yangidan eskiga)
+func (s *sqliteCatalogVersionRepository) ListVersions(ctx context.Context) ([]entity.CatalogVersion, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT version, source, uploaded_by, created_at, product_count FROM catalog_versions ORDER BY version DESC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []entity.CatalogVersion
+	for rows.Next() {
+		var v entity.CatalogVersion
+		var source sql.NullString
+		if err := rows.Scan(&v.Version, &source, &v.UploadedBy, &v.CreatedAt, &v.ProductCount); err != nil {
+			return nil, err
+		}
+		v.Source = source.String
+		list = append(list, v)
+	}
+	return list, rows.Err()
+}
+
+// GetVersion versiyani mahsulotlari bilan olish
+func (s *sqliteCatalogVersionRepository) GetVersion(ctx context.Context, version int) (*entity.CatalogVersion, error) {
+	var v entity.CatalogVersion
+	var source sql.NullString
+	var products string
+	err := s.db.QueryRowContext(ctx, `SELECT version, source, uploaded_by, created_at, product_count, products FROM catalog_versions WHERE version = ?`, version).
+		Scan(&v.Version, &source, &v.UploadedBy, &v.CreatedAt, &v.ProductCount, &products)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("catalog version not found: %d", version)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	v.Source = source.String
+	if err := json.Unmarshal([]byte(products), &v.Products); err != nil {
+		return nil, fmt.Errorf("versiya mahsulotlarini o'qib bo'lmadi: %w", err)
+	}
+	return &v, nil
+}
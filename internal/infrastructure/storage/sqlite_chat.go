@@ -3,13 +3,11 @@ package storage
 import (
 	"context"
 	"database/sql"
-	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
+	"log"
+	"strings"
 	"time"
 
-	_ "github.com/mattn/go-sqlite3"
 	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
 	"github.com/yourusername/telegram-ai-bot/internal/domain/repository"
 )
@@ -17,47 +15,80 @@ import (
 type sqliteChatRepository struct {
 	db      *sql.DB
 	maxSize int
+	hasFTS  bool // FTS5 mavjud bo'lsa true, aks holda LIKE bilan qidiriladi
 }
 
 // NewSQLiteChatRepository SQLite asosidagi chat repository
 func NewSQLiteChatRepository(dbPath string, maxContextSize int) (repository.ChatRepository, error) {
-	if dbPath == "" {
-		return nil, errors.New("db path bo'sh bo'lmasligi kerak")
-	}
-
-	if err := os.MkdirAll(filepath.Dir(dbPath), 0o755); err != nil {
-		return nil, fmt.Errorf("db papkasini yaratib bo'lmadi: %w", err)
-	}
-
-	db, err := sql.Open("sqlite3", dbPath)
+	db, err := openSQLite(dbPath)
 	if err != nil {
-		return nil, fmt.Errorf("sqlite ochilmadi: %w", err)
+		return nil, err
 	}
 
-	if err := createChatSchema(db); err != nil {
+	hasFTS, err := ensureMessagesFTS(db)
+	if err != nil {
 		return nil, err
 	}
+	if !hasFTS {
+		log.Println("⚠️ SQLite FTS5 mavjud emas (sqlite_fts5 build tag), xabarlar qidiruvi LIKE orqali ishlaydi")
+	}
 
-	return &sqliteChatRepository{db: db, maxSize: maxContextSize}, nil
+	return &sqliteChatRepository{db: db, maxSize: maxContextSize, hasFTS: hasFTS}, nil
 }
 
-func createChatSchema(db *sql.DB) error {
+// messagesFTSTriggers messages_fts indeksini messages bilan sinxron ushlab turuvchi triggerlar
+var messagesFTSTriggers = []string{"messages_fts_ai", "messages_fts_ad", "messages_fts_au"}
+
+// ensureMessagesFTS messages uchun FTS5 indeksini yaratish.
+// Migratsiyalardan tashqarida, chunki FTS5 faqat sqlite_fts5 tegi bilan build qilinganda bor;
+// binary FTS5 siz bo'lsa jadval yaratilmaydi va qidiruv LIKE ga o'tadi. Baza avval FTS5 li
+// build bilan ochilgan bo'lsa, uning triggerlari o'chiriladi (aks holda messages ga yozib bo'lmaydi),
+// FTS5 li build qayta ochganda esa indeks qaytadan to'ldiriladi.
+func ensureMessagesFTS(db *sql.DB) (bool, error) {
+	var available bool
+	if err := db.QueryRow(`SELECT sqlite_compileoption_used('ENABLE_FTS5')`).Scan(&available); err != nil {
+		return false, err
+	}
+	if !available {
+		for _, name := range messagesFTSTriggers {
+			if _, err := db.Exec(`DROP TRIGGER IF EXISTS ` + name); err != nil {
+				return false, fmt.Errorf("messages FTS triggerini o'chirib bo'lmadi: %w", err)
+			}
+		}
+		return false, nil
+	}
+
+	// Jadval yoki triggerlardan biri yo'q bo'lsa indeks messages bilan mos emas
+	var objects int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE (type = 'table' AND name = 'messages_fts')
+		OR (type = 'trigger' AND name IN (?, ?, ?))`, messagesFTSTriggers[0], messagesFTSTriggers[1], messagesFTSTriggers[2]).Scan(&objects); err != nil {
+		return false, err
+	}
+
 	const schema = `
-CREATE TABLE IF NOT EXISTS messages (
-	id TEXT PRIMARY KEY,
-	user_id INTEGER NOT NULL,
-	username TEXT,
-	text TEXT,
-	response TEXT,
-	ts TIMESTAMP NOT NULL
-);
-CREATE INDEX IF NOT EXISTS idx_messages_user_ts ON messages (user_id, ts);
+CREATE VIRTUAL TABLE IF NOT EXISTS messages_fts USING fts5(text, response, content='messages', content_rowid='rowid');
+CREATE TRIGGER IF NOT EXISTS messages_fts_ai AFTER INSERT ON messages BEGIN
+	INSERT INTO messages_fts (rowid, text, response) VALUES (new.rowid, new.text, new.response);
+END;
+CREATE TRIGGER IF NOT EXISTS messages_fts_ad AFTER DELETE ON messages BEGIN
+	INSERT INTO messages_fts (messages_fts, rowid, text, response) VALUES ('delete', old.rowid, old.text, old.response);
+END;
+CREATE TRIGGER IF NOT EXISTS messages_fts_au AFTER UPDATE ON messages BEGIN
+	INSERT INTO messages_fts (messages_fts, rowid, text, response) VALUES ('delete', old.rowid, old.text, old.response);
+	INSERT INTO messages_fts (rowid, text, response) VALUES (new.rowid, new.text, new.response);
+END;
 `
-	_, err := db.Exec(schema)
-	if err != nil {
-		return fmt.Errorf("schema yaratib bo'lmadi: %w", err)
+	if _, err := db.Exec(schema); err != nil {
+		return false, fmt.Errorf("messages FTS indeksini yaratib bo'lmadi: %w", err)
+	}
+
+	// Birinchi marta yaratilganda (yoki FTS5 siz build triggerlarni o'chirgandan keyin) xabarlarni qayta indekslash
+	if objects < 1+len(messagesFTSTriggers) {
+		if _, err := db.Exec(`INSERT INTO messages_fts (messages_fts) VALUES ('rebuild')`); err != nil {
+			return false, fmt.Errorf("messages FTS indeksini to'ldirib bo'lmadi: %w", err)
+		}
 	}
-	return nil
+	return true, nil
 }
 
 // SaveMessage xabarni saqlash
@@ -67,7 +98,11 @@ func (s *sqliteChatRepository) SaveMessage(ctx context.Context, message entity.M
 		return err
 	}
 
-	_, err = tx.ExecContext(ctx, `INSERT OR REPLACE INTO messages (id, user_id, username, text, response, ts) VALUES (?, ?, ?, ?, ?, ?)`,
+	// INSERT OR REPLACE eski qatorni triggersiz o'chiradi va FTS indeksida eski matn qoladi;
+	// upsert esa messages_fts_au triggerini ishga tushiradi
+	_, err = tx.ExecContext(ctx, `INSERT INTO messages (id, user_id, username, text, response, ts) VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT(id) DO UPDATE SET user_id = excluded.user_id, username = excluded.username, text = excluded.text,
+	response = excluded.response, ts = excluded.ts`,
 		message.ID, message.UserID, message.Username, message.Text, message.Response, message.Timestamp)
 	if err != nil {
 		tx.Rollback()
@@ -176,3 +211,84 @@ func (s *sqliteChatRepository) GetContext(ctx context.Context, userID int64) (*e
 		LastUsed: msgs[len(msgs)-1].Timestamp,
 	}, nil
 }
+
+// PruneContexts SQLite da kontekst xotirada saqlanmaydi, tarix esa doimiy bo'lishi kerak
+func (s *sqliteChatRepository) PruneContexts(ctx context.Context, before time.Time) (int, error) {
+	return 0, nil
+}
+
+// Search xabarlar bo'yicha qidiruv (FTS5 bo'lsa indeks orqali, aks holda LIKE)
+func (s *sqliteChatRepository) Search(ctx context.Context, query string, limit int) ([]entity.MessageMatch, error) {
+	terms := searchTerms(query)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+
+	if s.hasFTS {
+		return s.searchFTS(ctx, terms, limit)
+	}
+	return s.searchLike(ctx, terms, limit)
+}
+
+func (s *sqliteChatRepository) searchFTS(ctx context.Context, terms []string, limit int) ([]entity.MessageMatch, error) {
+	// Har bir so'z alohida iqtibosga olinadi, shunda foydalanuvchi matni FTS sintaksisi sifatida o'qilmaydi
+	quoted := make([]string, len(terms))
+	for i, term := range terms {
+		quoted[i] = `"` + strings.ReplaceAll(term, `"`, `""`) + `"`
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+SELECT m.id, m.user_id, m.username, m.text, m.response, m.ts,
+	snippet(messages_fts, -1, '«', '»', '…', 16)
+FROM messages_fts
+JOIN messages m ON m.rowid = messages_fts.rowid
+WHERE messages_fts MATCH ?
+ORDER BY m.ts DESC
+LIMIT ?`, strings.Join(quoted, " "), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var matches []entity.MessageMatch
+	for rows.Next() {
+		var match entity.MessageMatch
+		msg := &match.Message
+		if err := rows.Scan(&msg.ID, &msg.UserID, &msg.Username, &msg.Text, &msg.Response, &msg.Timestamp, &match.Snippet); err != nil {
+			return nil, err
+		}
+		matches = append(matches, match)
+	}
+	return matches, rows.Err()
+}
+
+func (s *sqliteChatRepository) searchLike(ctx context.Context, terms []string, limit int) ([]entity.MessageMatch, error) {
+	var where []string
+	var args []any
+	for _, term := range terms {
+		pattern := "%" + term + "%"
+		where = append(where, "(lower(text) LIKE ? OR lower(response) LIKE ?)")
+		args = append(args, pattern, pattern)
+	}
+	args = append(args, limit)
+
+	rows, err := s.db.QueryContext(ctx, `SELECT id, user_id, username, text, response, ts FROM messages WHERE `+
+		strings.Join(where, " AND ")+` ORDER BY ts DESC LIMIT ?`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var matches []entity.MessageMatch
+	for rows.Next() {
+		var msg entity.Message
+		if err := rows.Scan(&msg.ID, &msg.UserID, &msg.Username, &msg.Text, &msg.Response, &msg.Timestamp); err != nil {
+			return nil, err
+		}
+		matches = append(matches, entity.MessageMatch{Message: msg, Snippet: messageSnippet(msg, terms, 60)})
+	}
+	return matches, rows.Err()
+}
@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/repository"
+)
+
+type sqliteExchangeRateRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteExchangeRateRepository SQLite asosidagi valyuta kurslari repository
+func NewSQLiteExchangeRateRepository(dbPath string) (repository.ExchangeRateRepository, error) {
+	db, err := openSQLite(dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqliteExchangeRateRepository{db: db}, nil
+}
+
+// SaveRates kurslarni saqlash (bitta tranzaksiyada)
+func (s *sqliteExchangeRateRepository) SaveRates(ctx context.Context, rates []entity.ExchangeRate) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, rate := range rates {
+		if _, err := tx.ExecContext(ctx, `INSERT OR REPLACE INTO exchange_rates (currency, rate, source, updated_at) VALUES (?, ?, ?, ?)`,
+			string(rate.Currency), rate.Rate, rate.Source, rate.UpdatedAt.UTC()); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ListRates barcha kurslar (valyuta kodi bo'yicha)
+func (s *sqliteExchangeRateRepository) ListRates(ctx context.Context) ([]entity.ExchangeRate, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT currency, rate, source, updated_at FROM exchange_rates ORDER BY currency`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rates []entity.ExchangeRate
+	for rows.Next() {
+		var rate entity.ExchangeRate
+		var currency string
+		var source sql.NullString
+		if err := rows.Scan(&currency, &rate.Rate, &source, &rate.UpdatedAt); err != nil {
+			return nil, err
+		}
+		rate.Currency = entity.Currency(currency)
+		rate.Source = source.String
+		rate.UpdatedAt = rate.UpdatedAt.Local()
+		rates = append(rates, rate)
+	}
+	return rates, rows.Err()
+}
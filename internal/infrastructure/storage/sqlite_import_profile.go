@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/repository"
+)
+
+type sqliteImportProfileRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteImportProfileRepository SQLite asosidagi import profillari repository
+func NewSQLiteImportProfileRepository(dbPath string) (repository.ImportProfileRepository, error) {
+	db, err := openSQLite(dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqliteImportProfileRepository{db: db}, nil
+}
+
+const importProfileColumns = `name, columns, currency, header_row, data_row, updated_by, updated_at`
+
+// ListProfiles barcha profillar (nom bo'yicha)
+func (s *sqliteImportProfileRepository) ListProfiles(ctx context.Context) ([]entity.ImportProfile, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT `+importProfileColumns+` FROM import_profiles ORDER BY name COLLATE NOCASE`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var profiles []entity.ImportProfile
+	for rows.Next() {
+		profile, err := scanImportProfile(rows)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, profile)
+	}
+	return profiles, rows.Err()
+}
+
+// GetProfile nom bo'yicha profil
+func (s *sqliteImportProfileRepository) GetProfile(ctx context.Context, name string) (*entity.ImportProfile, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+importProfileColumns+` FROM import_profiles WHERE name = ?`, strings.TrimSpace(name))
+	profile, err := scanImportProfile(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("import profile not found: %s", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// SaveProfile profilni saqlash
+func (s *sqliteImportProfileRepository) SaveProfile(ctx context.Context, profile entity.ImportProfile) error {
+	columns, err := json.Marshal(profile.Columns)
+	if err != nil {
+		return fmt.Errorf("profil ustunlarini saqlab bo'lmadi: %w", err)
+	}
+
+	_, err = s.db.ExecContext(ctx, `INSERT OR REPLACE INTO import_profiles (`+importProfileColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		strings.TrimSpace(profile.Name), string(columns), string(profile.Currency), profile.HeaderRow, profile.DataRow,
+		profile.UpdatedBy, profile.UpdatedAt.UTC())
+	return err
+}
+
+// DeleteProfile profilni o'chirish
+func (s *sqliteImportProfileRepository) DeleteProfile(ctx context.Context, name string) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM import_profiles WHERE name = ?`, strings.TrimSpace(name))
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("import profile not found: %s", name)
+	}
+	return nil
+}
+
+func scanImportProfile(row sqlScanner) (entity.ImportProfile, error) {
+	var profile entity.ImportProfile
+	var columns, currency string
+	if err := row.Scan(&profile.Name, &columns, &currency, &profile.HeaderRow, &profile.DataRow,
+		&profile.UpdatedBy, &profile.UpdatedAt); err != nil {
+		return profile, err
+	}
+
+	profile.Currency = entity.Currency(currency)
+	profile.UpdatedAt = profile.UpdatedAt.Local()
+	if err := json.Unmarshal([]byte(columns), &profile.Columns); err != nil {
+		return profile, fmt.Errorf("profil ustunlarini o'qib bo'lmadi: %w", err)
+	}
+	return profile, nil
+}
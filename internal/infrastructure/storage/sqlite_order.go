@@ -0,0 +1,128 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/repository"
+)
+
+type sqliteOrderRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteOrderRepository SQLite asosidagi order repository
+func NewSQLiteOrderRepository(dbPath string) (repository.OrderRepository, error) {
+	db, err := openSQLite(dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqliteOrderRepository{db: db}, nil
+}
+
+const orderColumns = `id, user_id, chat_id, username, customer_name, phone, location, delivery_method, note, summary, items, total, currency, status, created_at, updated_at`
+
+// Save buyurtmani saqlash
+func (s *sqliteOrderRepository) Save(ctx context.Context, order entity.Order) error {
+	items, err := json.Marshal(order.Items)
+	if err != nil {
+		return fmt.Errorf("buyurtma mahsulotlarini saqlab bo'lmadi: %w", err)
+	}
+
+	_, err = s.db.ExecContext(ctx, `INSERT OR REPLACE INTO orders (`+orderColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		order.ID, order.UserID, order.ChatID, order.Username, order.CustomerName, order.Phone, order.Location,
+		string(order.DeliveryMethod), order.Note, order.Summary, string(items), order.Total, string(order.Currency), string(order.Status),
+		order.CreatedAt, order.UpdatedAt)
+	return err
+}
+
+// GetByID ID bo'yicha buyurtmani olish
+func (s *sqliteOrderRepository) GetByID(ctx context.Context, id string) (*entity.Order, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+orderColumns+` FROM orders WHERE id = ?`, id)
+	order, err := scanOrder(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("order not found: %s", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// ListByUser foydalanuvchi buyurtmalari
+func (s *sqliteOrderRepository) ListByUser(ctx context.Context, userID int64) ([]entity.Order, error) {
+	return s.queryOrders(ctx, `SELECT `+orderColumns+` FROM orders WHERE user_id = ? ORDER BY created_at DESC`, userID)
+}
+
+// ListByStatus holat bo'yicha buyurtmalar
+func (s *sqliteOrderRepository) ListByStatus(ctx context.Context, status entity.OrderStatus, limit int) ([]entity.Order, error) {
+	query := `SELECT ` + orderColumns + ` FROM orders`
+	var args []any
+	if status != "" {
+		query += ` WHERE status = ?`
+		args = append(args, string(status))
+	}
+	query += ` ORDER BY created_at DESC`
+	if limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", limit)
+	}
+	return s.queryOrders(ctx, query, args...)
+}
+
+// DeleteByUser foydalanuvchi buyurtmalarini o'chirish
+func (s *sqliteOrderRepository) DeleteByUser(ctx context.Context, userID int64) (int, error) {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM orders WHERE user_id = ?`, userID)
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
+func (s *sqliteOrderRepository) queryOrders(ctx context.Context, query string, args ...any) ([]entity.Order, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orders []entity.Order
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, order)
+	}
+	return orders, rows.Err()
+}
+
+func scanOrder(row sqlScanner) (entity.Order, error) {
+	var order entity.Order
+	var username, name, phone, location, delivery, note, summary, currency sql.NullString
+	var items, status string
+	if err := row.Scan(&order.ID, &order.UserID, &order.ChatID, &username, &name, &phone, &location,
+		&delivery, &note, &summary, &items, &order.Total, &currency, &status, &order.CreatedAt, &order.UpdatedAt); err != nil {
+		return order, err
+	}
+
+	order.Username = username.String
+	order.CustomerName = name.String
+	order.Phone = phone.String
+	order.Location = location.String
+	order.DeliveryMethod = entity.DeliveryMethod(delivery.String)
+	order.Note = note.String
+	order.Summary = summary.String
+	order.Currency = entity.Currency(currency.String)
+	order.Status = entity.OrderStatus(status)
+	if err := json.Unmarshal([]byte(items), &order.Items); err != nil {
+		return order, fmt.Errorf("buyurtma mahsulotlarini o'qib bo'lmadi: %w", err)
+	}
+
+	return order, nil
+}
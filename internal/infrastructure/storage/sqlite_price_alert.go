@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/repository"
+)
+
+type sqlitePriceAlertRepository struct {
+	db *sql.DB
+}
+
+// NewSQLitePriceAlertRepository SQLite asosidagi narx obunalari repository
+func NewSQLitePriceAlertRepository(dbPath string) (repository.PriceAlertRepository, error) {
+	db, err := openSQLite(dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqlitePriceAlertRepository{db: db}, nil
+}
+
+const priceAlertColumns = `id, user_id, chat_id, product_id, product_key, product_name, threshold, currency, created_at, notified_at`
+
+// SaveAlert obunani saqlash
+func (s *sqlitePriceAlertRepository) SaveAlert(ctx context.Context, alert entity.PriceAlert) error {
+	var notified sql.NullTime
+	if !alert.NotifiedAt.IsZero() {
+		notified = sql.NullTime{Time: alert.NotifiedAt.UTC(), Valid: true}
+	}
+	_, err := s.db.ExecContext(ctx, `INSERT OR REPLACE INTO price_alerts (`+priceAlertColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		alert.ID, alert.UserID, alert.ChatID, alert.ProductID, alert.Key, alert.ProductName, alert.Threshold,
+		string(alert.Currency), alert.CreatedAt.UTC(), notified)
+	return err
+}
+
+// ListAlerts barcha obunalar
+func (s *sqlitePriceAlertRepository) ListAlerts(ctx context.Context) ([]entity.PriceAlert, error) {
+	return s.queryAlerts(ctx, `SELECT `+priceAlertColumns+` FROM price_alerts ORDER BY created_at`)
+}
+
+// ListByUser foydalanuvchi obunalari
+func (s *sqlitePriceAlertRepository) ListByUser(ctx context.Context, userID int64) ([]entity.PriceAlert, error) {
+	return s.queryAlerts(ctx, `SELECT `+priceAlertColumns+` FROM price_alerts WHERE user_id = ? ORDER BY created_at DESC`, userID)
+}
+
+// DeleteAlert obunani o'chirish
+func (s *sqlitePriceAlertRepository) DeleteAlert(ctx context.Context, id string) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM price_alerts WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("price alert not found: %s", id)
+	}
+	return nil
+}
+
+// DeleteByUser foydalanuvchi obunalarini o'chirish
+func (s *sqlitePriceAlertRepository) DeleteByUser(ctx context.Context, userID int64) (int, error) {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM price_alerts WHERE user_id = ?`, userID)
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
+func (s *sqlitePriceAlertRepository) queryAlerts(ctx context.Context, query string, args ...any) ([]entity.PriceAlert, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var alerts []entity.PriceAlert
+	for rows.Next() {
+		var alert entity.PriceAlert
+		var currency string
+		var notified sql.NullTime
+		if err := rows.Scan(&alert.ID, &alert.UserID, &alert.ChatID, &alert.ProductID, &alert.Key, &alert.ProductName,
+			&alert.Threshold, &currency, &alert.CreatedAt, &notified); err != nil {
+			return nil, err
+		}
+		alert.Currency = entity.Currency(currency)
+		alert.CreatedAt = alert.CreatedAt.Local()
+		if notified.Valid {
+			alert.NotifiedAt = notified.Time.Local()
+		}
+		alerts = append(alerts, alert)
+	}
+	return alerts, rows.Err()
+}
@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/repository"
+)
+
+type sqlitePriceHistoryRepository struct {
+	db *sql.DB
+}
+
+// NewSQLitePriceHistoryRepository SQLite asosidagi narxlar tarixi repository
+func NewSQLitePriceHistoryRepository(dbPath string) (repository.PriceHistoryRepository, error) {
+	db, err := openSQLite(dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqlitePriceHistoryRepository{db: db}, nil
+}
+
+// RecordPrices narxlarni tarixga yozish (bitta tranzaksiyada)
+func (s *sqlitePriceHistoryRepository) RecordPrices(ctx context.Context, points []entity.PricePoint) error {
+	if len(points) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	stmt, err := tx.PrepareContext(ctx, `INSERT INTO price_history (product_key, product_id, name, price, currency, version, recorded_at) VALUES (?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+
+	for _, p := range points {
+		if _, err := stmt.ExecContext(ctx, p.Key, p.ProductID, p.Name, p.Price, string(p.Currency), p.Version, p.RecordedAt.UTC()); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("narx tarixini saqlab bo'lmadi: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ListPrices kalit bo'yicha narxlar tarixi (yangidan eskiga)
+func (s *sqlitePriceHistoryRepository) ListPrices(ctx context.Context, key string, limit int) ([]entity.PricePoint, error) {
+	query := `SELECT product_key, product_id, name, price, currency, version, recorded_at FROM price_history WHERE product_key = ? ORDER BY recorded_at DESC, id DESC`
+	if limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", limit)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, key)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var points []entity.PricePoint
+	for rows.Next() {
+		var p entity.PricePoint
+		var currency string
+		if err := rows.Scan(&p.Key, &p.ProductID, &p.Name, &p.Price, &currency, &p.Version, &p.RecordedAt); err != nil {
+			return nil, err
+		}
+		p.Currency = entity.Currency(currency)
+		p.RecordedAt = p.RecordedAt.Local()
+		points = append(points, p)
+	}
+	return points, rows.Err()
+}
@@ -0,0 +1,238 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/repository"
+)
+
+type sqliteProductRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteProductRepository SQLite asosidagi product repository.
+// Katalog chat bazasi bilan bir faylda (ChatDBPath) saqlanishi mumkin.
+func NewSQLiteProductRepository(dbPath string) (repository.ProductRepository, error) {
+	db, err := openSQLite(dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqliteProductRepository{db: db}, nil
+}
+
+const productColumns = `id, sku, name, category, category_auto, price, currency, description, stock, stock_tracked, specs, attributes, discontinued, image, created_at, updated_at`
+
+// SaveProduct mahsulotni saqlash
+func (s *sqliteProductRepository) SaveProduct(ctx context.Context, product entity.Product) error {
+	return insertProduct(ctx, s.db, product)
+}
+
+// SaveMany ko'p mahsulotlarni saqlash
+func (s *sqliteProductRepository) SaveMany(ctx context.Context, products []entity.Product) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, product := range products {
+		if err := insertProduct(ctx, tx, product); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetByID ID bo'yicha mahsulotni olish
+func (s *sqliteProductRepository) GetByID(ctx context.Context, id string) (*entity.Product, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+productColumns+` FROM products WHERE id = ?`, id)
+	product, err := scanProduct(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("product not found: %s", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// Search mahsulot qidirish (memory repository bilan bir xil algoritm)
+func (s *sqliteProductRepository) Search(ctx context.Context, query string) ([]entity.Product, error) {
+	products, err := s.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return searchProducts(products, query), nil
+}
+
+// GetByCategory kategoriya bo'yicha mahsulotlarni olish
+func (s *sqliteProductRepository) GetByCategory(ctx context.Context, category string) ([]entity.Product, error) {
+	category = strings.ToLower(strings.TrimSpace(category))
+	return s.queryProducts(ctx, `SELECT `+productColumns+` FROM products WHERE lower(category) = ?`, category)
+}
+
+// GetAll barcha mahsulotlarni olish
+func (s *sqliteProductRepository) GetAll(ctx context.Context) ([]entity.Product, error) {
+	return s.queryProducts(ctx, `SELECT `+productColumns+` FROM products`)
+}
+
+// UpdateCatalog butun katalogni yangilash (bitta tranzaksiyada)
+func (s *sqliteProductRepository) UpdateCatalog(ctx context.Context, catalog entity.ProductCatalog) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	// Eski mahsulotlarni o'chirish
+	if _, err := tx.ExecContext(ctx, `DELETE FROM products`); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Yangi mahsulotlarni qo'shish
+	for _, product := range catalog.Products {
+		if err := insertProduct(ctx, tx, product); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT OR REPLACE INTO catalog_meta (id, source, updated_at) VALUES (1, ?, ?)`,
+		catalog.Source, catalog.UpdatedAt)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetCatalog katalogni olish
+func (s *sqliteProductRepository) GetCatalog(ctx context.Context) (*entity.ProductCatalog, error) {
+	var source sql.NullString
+	var updatedAt time.Time
+	err := s.db.QueryRowContext(ctx, `SELECT source, updated_at FROM catalog_meta WHERE id = 1`).Scan(&source, &updatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("catalog not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := s.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.ProductCatalog{
+		Products:  products,
+		UpdatedAt: updatedAt,
+		Source:    source.String,
+	}, nil
+}
+
+// Clear barcha mahsulotlarni o'chirish
+func (s *sqliteProductRepository) Clear(ctx context.Context) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM products`); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM catalog_meta`); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *sqliteProductRepository) queryProducts(ctx context.Context, query string, args ...any) ([]entity.Product, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var products []entity.Product
+	for rows.Next() {
+		product, err := scanProduct(rows)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, product)
+	}
+	return products, rows.Err()
+}
+
+// sqlExecer *sql.DB va *sql.Tx uchun umumiy interface
+type sqlExecer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// sqlScanner *sql.Row va *sql.Rows uchun umumiy interface
+type sqlScanner interface {
+	Scan(dest ...any) error
+}
+
+func insertProduct(ctx context.Context, db sqlExecer, product entity.Product) error {
+	specs, err := json.Marshal(product.Specs)
+	if err != nil {
+		return fmt.Errorf("specs ni saqlab bo'lmadi: %w", err)
+	}
+	// Bo'sh atributlar bo'sh qator bo'lib saqlanadi va o'qishda nomdan qayta hisoblanadi
+	attributes := ""
+	if !product.Attributes.IsZero() {
+		data, err := json.Marshal(product.Attributes)
+		if err != nil {
+			return fmt.Errorf("atributlarni saqlab bo'lmadi: %w", err)
+		}
+		attributes = string(data)
+	}
+
+	_, err = db.ExecContext(ctx, `INSERT OR REPLACE INTO products (`+productColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		product.ID, product.SKU, product.Name, product.Category, product.CategoryAuto, product.Price, string(product.PriceCurrency()), product.Description, product.Stock, product.StockTracked,
+		string(specs), attributes, product.Discontinued, product.Image, product.CreatedAt, product.UpdatedAt)
+	return err
+}
+
+func scanProduct(row sqlScanner) (entity.Product, error) {
+	var product entity.Product
+	var category, currency, description, specs, attributes sql.NullString
+	if err := row.Scan(&product.ID, &product.SKU, &product.Name, &category, &product.CategoryAuto, &product.Price, &currency, &description, &product.Stock, &product.StockTracked,
+		&specs, &attributes, &product.Discontinued, &product.Image, &product.CreatedAt, &product.UpdatedAt); err != nil {
+		return product, err
+	}
+
+	product.Category = category.String
+	product.Currency = entity.Currency(currency.String)
+	product.Description = description.String
+	product.Specs = make(map[string]string)
+	if specs.Valid && specs.String != "" && specs.String != "null" {
+		if err := json.Unmarshal([]byte(specs.String), &product.Specs); err != nil {
+			return product, fmt.Errorf("specs ni o'qib bo'lmadi: %w", err)
+		}
+	}
+	if attributes.String != "" {
+		if err := json.Unmarshal([]byte(attributes.String), &product.Attributes); err != nil {
+			return product, fmt.Errorf("atributlarni o'qib bo'lmadi: %w", err)
+		}
+	} else {
+		// v9 dan oldingi yoki atributsiz saqlangan mahsulot
+		product.Attributes = entity.ExtractAttributes(product.Name, product.Category, product.Specs)
+	}
+
+	return product, nil
+}
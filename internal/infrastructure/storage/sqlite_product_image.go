@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/repository"
+)
+
+type sqliteProductImageRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteProductImageRepository SQLite asosidagi mahsulot rasmlari repository
+func NewSQLiteProductImageRepository(dbPath string) (repository.ProductImageRepository, error) {
+	db, err := openSQLite(dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqliteProductImageRepository{db: db}, nil
+}
+
+// SaveImages rasmlarni saqlash (bitta tranzaksiyada, mavjudlari o'zgarmaydi)
+func (s *sqliteProductImageRepository) SaveImages(ctx context.Context, images []entity.ProductImage) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, image := range images {
+		if _, err := tx.ExecContext(ctx, `INSERT OR IGNORE INTO product_images (id, data, extension, file_id, created_at) VALUES (?, ?, ?, ?, ?)`,
+			image.ID, image.Data, image.Extension, image.FileID, image.CreatedAt.UTC()); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetImage ID bo'yicha rasm
+func (s *sqliteProductImageRepository) GetImage(ctx context.Context, id string) (*entity.ProductImage, error) {
+	var image entity.ProductImage
+	err := s.db.QueryRowContext(ctx, `SELECT id, data, extension, file_id, created_at FROM product_images WHERE id = ?`, id).
+		Scan(&image.ID, &image.Data, &image.Extension, &image.FileID, &image.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("product image not found: %s", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	image.CreatedAt = image.CreatedAt.Local()
+	return &image, nil
+}
+
+// SetFileID Telegram file_id ni saqlash
+func (s *sqliteProductImageRepository) SetFileID(ctx context.Context, id, fileID string) error {
+	_, err := s.db.ExecContext(ctx, `UPDATE product_images SET file_id = ? WHERE id = ?`, fileID, id)
+	return err
+}
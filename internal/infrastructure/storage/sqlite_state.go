@@ -0,0 +1,125 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/repository"
+)
+
+type sqliteStateRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteStateRepository SQLite asosidagi state repository
+func NewSQLiteStateRepository(dbPath string) (repository.StateRepository, error) {
+	db, err := openSQLite(dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqliteStateRepository{db: db}, nil
+}
+
+// Put holatni saqlash
+func (s *sqliteStateRepository) Put(ctx context.Context, entry entity.StateEntry) error {
+	if entry.UpdatedAt.IsZero() {
+		entry.UpdatedAt = time.Now()
+	}
+	_, err := s.db.ExecContext(ctx, `INSERT OR REPLACE INTO bot_state (namespace, key, user_id, value, updated_at) VALUES (?, ?, ?, ?, ?)`,
+		entry.Namespace, entry.Key, entry.UserID, entry.Value, entry.UpdatedAt.UTC())
+	return err
+}
+
+// Get holatni olish
+func (s *sqliteStateRepository) Get(ctx context.Context, namespace, key string) (*entity.StateEntry, bool, error) {
+	entry := entity.StateEntry{Namespace: namespace, Key: key}
+	err := s.db.QueryRowContext(ctx, `SELECT user_id, value, updated_at FROM bot_state WHERE namespace = ? AND key = ?`, namespace, key).
+		Scan(&entry.UserID, &entry.Value, &entry.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	entry.UpdatedAt = entry.UpdatedAt.Local()
+	return &entry, true, nil
+}
+
+// Delete holatni o'chirish
+func (s *sqliteStateRepository) Delete(ctx context.Context, namespace, key string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM bot_state WHERE namespace = ? AND key = ?`, namespace, key)
+	return err
+}
+
+// ListByUser foydalanuvchi holatlari
+func (s *sqliteStateRepository) ListByUser(ctx context.Context, userID int64) ([]entity.StateEntry, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT namespace, key, user_id, value, updated_at FROM bot_state WHERE user_id = ? ORDER BY namespace, key`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []entity.StateEntry
+	for rows.Next() {
+		var entry entity.StateEntry
+		if err := rows.Scan(&entry.Namespace, &entry.Key, &entry.UserID, &entry.Value, &entry.UpdatedAt); err != nil {
+			return nil, err
+		}
+		entry.UpdatedAt = entry.UpdatedAt.Local()
+		list = append(list, entry)
+	}
+	return list, rows.Err()
+}
+
+// DeleteByUser foydalanuvchi holatlarini o'chirish
+func (s *sqliteStateRepository) DeleteByUser(ctx context.Context, userID int64) (int, error) {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM bot_state WHERE user_id = ?`, userID)
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
+// DeleteOlderThan eskirgan holatlarni o'chirish (bitta tranzaksiyada, qayta xabar bermaslik uchun)
+func (s *sqliteStateRepository) DeleteOlderThan(ctx context.Context, namespace string, before time.Time) ([]entity.StateEntry, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.QueryContext(ctx, `SELECT key, user_id, value, updated_at FROM bot_state WHERE namespace = ? AND updated_at < ?`,
+		namespace, before.UTC())
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	var expired []entity.StateEntry
+	for rows.Next() {
+		entry := entity.StateEntry{Namespace: namespace}
+		if err := rows.Scan(&entry.Key, &entry.UserID, &entry.Value, &entry.UpdatedAt); err != nil {
+			rows.Close()
+			tx.Rollback()
+			return nil, err
+		}
+		entry.UpdatedAt = entry.UpdatedAt.Local()
+		expired = append(expired, entry)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM bot_state WHERE namespace = ? AND updated_at < ?`, namespace, before.UTC()); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	return expired, tx.Commit()
+}
@@ -0,0 +1,162 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/repository"
+)
+
+type sqliteStockReservationRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteStockReservationRepository SQLite asosidagi ombor bronlari repository
+func NewSQLiteStockReservationRepository(dbPath string) (repository.StockReservationRepository, error) {
+	db, err := openSQLite(dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqliteStockReservationRepository{db: db}, nil
+}
+
+const stockReservationColumns = `order_id, product_key, product_id, name, quantity, created_at`
+
+// SaveReservations buyurtma bronlarini saqlash (bitta tranzaksiyada)
+func (s *sqliteStockReservationRepository) SaveReservations(ctx context.Context, orderID string, reservations []entity.StockReservation) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM stock_reservations WHERE order_id = ?`, orderID); err != nil {
+		tx.Rollback()
+		return err
+	}
+	for _, r := range reservations {
+		if _, err := tx.ExecContext(ctx, `INSERT OR REPLACE INTO stock_reservations (`+stockReservationColumns+`) VALUES (?, ?, ?, ?, ?, ?)`,
+			orderID, r.Key, r.ProductID, r.Name, r.Quantity, r.CreatedAt.UTC()); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ListReservations barcha ochiq bronlar
+func (s *sqliteStockReservationRepository) ListReservations(ctx context.Context) ([]entity.StockReservation, error) {
+	return s.queryReservations(ctx, `SELECT `+stockReservationColumns+` FROM stock_reservations ORDER BY created_at`)
+}
+
+// ListByOrder buyurtma bronlari
+func (s *sqliteStockReservationRepository) ListByOrder(ctx context.Context, orderID string) ([]entity.StockReservation, error) {
+	return s.queryReservations(ctx, `SELECT `+stockReservationColumns+` FROM stock_reservations WHERE order_id = ? ORDER BY created_at`, orderID)
+}
+
+// DeleteByOrder buyurtma bronlarini o'chirish
+func (s *sqliteStockReservationRepository) DeleteByOrder(ctx context.Context, orderID string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM stock_reservations WHERE order_id = ?`, orderID)
+	return err
+}
+
+// CommitOrder bronlangan sonni products.stock dan ayirish va bronlarni o'chirish (bitta tranzaksiyada).
+// Faqat stock ustuni yangilanadi, shuning uchun parallel import yoki tahrir yozgan qatorlar ustidan yozilmaydi.
+// Mahsulot ID si (replace importdan keyin) o'zgargan bo'lishi mumkin, shuning uchun kalit bo'yicha topiladi.
+func (s *sqliteStockReservationRepository) CommitOrder(ctx context.Context, orderID string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	required := make(map[string]int)
+	rows, err := tx.QueryContext(ctx, `SELECT product_key, quantity FROM stock_reservations WHERE order_id = ?`, orderID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	for rows.Next() {
+		var key string
+		var quantity int
+		if err := rows.Scan(&key, &quantity); err != nil {
+			rows.Close()
+			tx.Rollback()
+			return err
+		}
+		required[key] += quantity
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if len(required) == 0 {
+		return tx.Commit()
+	}
+
+	// Kalit bo'yicha birinchi hisobga olinadigan mahsulot
+	productIDs := make(map[string]string, len(required))
+	rows, err = tx.QueryContext(ctx, `SELECT id, sku, name FROM products WHERE stock_tracked = 1 ORDER BY rowid`)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	for rows.Next() {
+		var p entity.Product
+		if err := rows.Scan(&p.ID, &p.SKU, &p.Name); err != nil {
+			rows.Close()
+			tx.Rollback()
+			return err
+		}
+		key := entity.PriceKey(p)
+		if _, ok := required[key]; !ok {
+			continue
+		}
+		if _, ok := productIDs[key]; !ok {
+			productIDs[key] = p.ID
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	now := time.Now()
+	for key, id := range productIDs {
+		if _, err := tx.ExecContext(ctx, `UPDATE products SET stock = MAX(stock - ?, 0), updated_at = ? WHERE id = ?`,
+			required[key], now, id); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM stock_reservations WHERE order_id = ?`, orderID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *sqliteStockReservationRepository) queryReservations(ctx context.Context, query string, args ...any) ([]entity.StockReservation, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reservations []entity.StockReservation
+	for rows.Next() {
+		var r entity.StockReservation
+		if err := rows.Scan(&r.OrderID, &r.Key, &r.ProductID, &r.Name, &r.Quantity, &r.CreatedAt); err != nil {
+			return nil, err
+		}
+		r.CreatedAt = r.CreatedAt.Local()
+		reservations = append(reservations, r)
+	}
+	return reservations, rows.Err()
+}
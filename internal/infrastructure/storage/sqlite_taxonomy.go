@@ -0,0 +1,146 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/repository"
+)
+
+type sqliteTaxonomyRepository struct {
+	db *sql.DB
+}
+
+// NewSQLiteTaxonomyRepository SQLite asosidagi kategoriya taksonomiyasi repository
+func NewSQLiteTaxonomyRepository(dbPath string) (repository.TaxonomyRepository, error) {
+	db, err := openSQLite(dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sqliteTaxonomyRepository{db: db}, nil
+}
+
+// ListRules saqlangan qoidalar (ID bo'yicha)
+func (s *sqliteTaxonomyRepository) ListRules(ctx context.Context) ([]entity.CategoryRule, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT id, category, patterns, exclude, priority FROM category_rules ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rules []entity.CategoryRule
+	for rows.Next() {
+		var rule entity.CategoryRule
+		var patterns, exclude string
+		if err := rows.Scan(&rule.ID, &rule.Category, &patterns, &exclude, &rule.Priority); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(patterns), &rule.Patterns); err != nil {
+			return nil, fmt.Errorf("qoida %d naqshlarini o'qib bo'lmadi: %w", rule.ID, err)
+		}
+		if err := json.Unmarshal([]byte(exclude), &rule.Exclude); err != nil {
+			return nil, fmt.Errorf("qoida %d istisnolarini o'qib bo'lmadi: %w", rule.ID, err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, rows.Err()
+}
+
+// SaveRule qoidani saqlash (ID 0 bo'lsa yangi qator)
+func (s *sqliteTaxonomyRepository) SaveRule(ctx context.Context, rule entity.CategoryRule) (int, error) {
+	return saveCategoryRule(ctx, s.db, rule)
+}
+
+// DeleteRule qoidani o'chirish
+func (s *sqliteTaxonomyRepository) DeleteRule(ctx context.Context, id int) error {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM category_rules WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("category rule not found: %d", id)
+	}
+	return nil
+}
+
+// ReplaceRules barcha qoidalarni almashtirish (bitta tranzaksiyada)
+func (s *sqliteTaxonomyRepository) ReplaceRules(ctx context.Context, rules []entity.CategoryRule) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM category_rules`); err != nil {
+		tx.Rollback()
+		return err
+	}
+	for _, rule := range rules {
+		if _, err := saveCategoryRule(ctx, tx, rule); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ListCategoryNames kategoriya nomlari (kategoriya bo'yicha)
+func (s *sqliteTaxonomyRepository) ListCategoryNames(ctx context.Context) ([]entity.CategoryName, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT category, name_uz, name_ru FROM category_names ORDER BY category`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var names []entity.CategoryName
+	for rows.Next() {
+		var name entity.CategoryName
+		if err := rows.Scan(&name.Category, &name.Uz, &name.Ru); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
+
+// SaveCategoryName kategoriya nomini saqlash
+func (s *sqliteTaxonomyRepository) SaveCategoryName(ctx context.Context, name entity.CategoryName) error {
+	_, err := s.db.ExecContext(ctx, `INSERT OR REPLACE INTO category_names (category, name_uz, name_ru) VALUES (?, ?, ?)`,
+		name.Category, name.Uz, name.Ru)
+	return err
+}
+
+func saveCategoryRule(ctx context.Context, db sqlExecer, rule entity.CategoryRule) (int, error) {
+	patterns, err := json.Marshal(rule.Patterns)
+	if err != nil {
+		return 0, err
+	}
+	exclude := []byte("[]")
+	if len(rule.Exclude) > 0 {
+		if exclude, err = json.Marshal(rule.Exclude); err != nil {
+			return 0, err
+		}
+	}
+
+	if rule.ID == 0 {
+		res, err := db.ExecContext(ctx, `INSERT INTO category_rules (category, patterns, exclude, priority) VALUES (?, ?, ?, ?)`,
+			rule.Category, string(patterns), string(exclude), rule.Priority)
+		if err != nil {
+			return 0, err
+		}
+		id, err := res.LastInsertId()
+		return int(id), err
+	}
+
+	_, err = db.ExecContext(ctx, `INSERT OR REPLACE INTO category_rules (id, category, patterns, exclude, priority) VALUES (?, ?, ?, ?, ?)`,
+		rule.ID, rule.Category, string(patterns), string(exclude), rule.Priority)
+	return rule.ID, err
+}
@@ -3,6 +3,9 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"log"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -25,35 +28,78 @@ type AdminUseCase interface {
 	// IsAdmin admin ekanligini tekshirish
 	IsAdmin(ctx context.Context, userID int64) (bool, error)
 
-	// UploadCatalog Excel fayldan katalogni yuklash
-	UploadCatalog(ctx context.Context, userID int64, fileData []byte, filename string) (int, error)
+	// UploadCatalog katalog faylidan (Excel, CSV/TSV, JSON) katalogni yuklash
+	UploadCatalog(ctx context.Context, userID int64, fileData []byte, filename string, opts entity.ImportOptions) (int, error)
+
+	// PreviewCatalog katalog faylini tekshirish (katalog o'zgarmaydi, faqat hisobot; ombordagi soni
+	// ochiq bronlardan kam mahsulotlar Shortages da)
+	PreviewCatalog(ctx context.Context, userID int64, fileData []byte, filename string, opts entity.ImportOptions) (*entity.ImportReport, error)
+
+	// ApplyCatalog tekshirilgan importni tasdiqlash va katalogni yangilash (almashtirish yoki merge)
+	ApplyCatalog(ctx context.Context, userID int64, report *entity.ImportReport, opts entity.ImportOptions) (*entity.ImportResult, error)
+
+	// ExportImportReport import hisobotini .xlsx faylga eksport qilish
+	ExportImportReport(ctx context.Context, report *entity.ImportReport) ([]byte, error)
 
 	// GetCatalogInfo katalog haqida ma'lumot
 	GetCatalogInfo(ctx context.Context) (string, error)
 
 	// CleanAll barcha mahsulotlar va chat tarixlarini tozalash
 	CleanAll(ctx context.Context, userID int64) error
+
+	// ListCatalogVersions yuklangan katalog versiyalari ro'yxati
+	ListCatalogVersions(ctx context.Context) ([]entity.CatalogVersion, error)
+
+	// DiffCatalogVersions ikki versiya orasidagi farqni hisoblash
+	DiffCatalogVersions(ctx context.Context, from, to int) (*entity.CatalogDiff, error)
+
+	// RollbackCatalog katalogni oldingi versiyaga qaytarish
+	RollbackCatalog(ctx context.Context, userID int64, version int) (int, error)
+
+	// GetAuditLog admin harakatlari logini filtr bo'yicha olish
+	GetAuditLog(ctx context.Context, filter entity.AuditFilter) ([]entity.AdminAction, int, error)
+
+	// ExportAuditLog filtrlangan audit logni .xlsx faylga eksport qilish
+	ExportAuditLog(ctx context.Context, filter entity.AuditFilter) ([]byte, error)
+
+	// ExportCatalog bot ishlatayotgan katalogni .xlsx ga eksport qilish (qayta yuklash mumkin)
+	ExportCatalog(ctx context.Context, userID int64) ([]byte, int, error)
 }
 
 type adminUseCase struct {
-	adminRepo   repository.AdminRepository
-	productRepo repository.ProductRepository
-	excelParser repository.ExcelParser
-	chatRepo    repository.ChatRepository
+	adminRepo     repository.AdminRepository
+	productRepo   repository.ProductRepository
+	versionRepo   repository.CatalogVersionRepository
+	imageRepo     repository.ProductImageRepository
+	priceRepo     repository.PriceHistoryRepository
+	stockRepo     repository.StockReservationRepository
+	catalogParser repository.CatalogParser
+	exporter      repository.ExcelExporter
+	chatRepo      repository.ChatRepository
 }
 
 // NewAdminUseCase yangi AdminUseCase yaratish
 func NewAdminUseCase(
 	adminRepo repository.AdminRepository,
 	productRepo repository.ProductRepository,
-	excelParser repository.ExcelParser,
+	versionRepo repository.CatalogVersionRepository,
+	imageRepo repository.ProductImageRepository,
+	priceRepo repository.PriceHistoryRepository,
+	stockRepo repository.StockReservationRepository,
+	catalogParser repository.CatalogParser,
+	exporter repository.ExcelExporter,
 	chatRepo repository.ChatRepository,
 ) AdminUseCase {
 	return &adminUseCase{
-		adminRepo:   adminRepo,
-		productRepo: productRepo,
-		excelParser: excelParser,
-		chatRepo:    chatRepo,
+		adminRepo:     adminRepo,
+		productRepo:   productRepo,
+		versionRepo:   versionRepo,
+		imageRepo:     imageRepo,
+		priceRepo:     priceRepo,
+		stockRepo:     stockRepo,
+		catalogParser: catalogParser,
+		exporter:      exporter,
+		chatRepo:      chatRepo,
 	}
 }
 
@@ -91,7 +137,20 @@ func (u *adminUseCase) Login(ctx context.Context, userID int64, password string)
 
 // Logout admin logout qilish
 func (u *adminUseCase) Logout(ctx context.Context, userID int64) error {
-	return u.adminRepo.DeleteSession(ctx, userID)
+	if err := u.adminRepo.DeleteSession(ctx, userID); err != nil {
+		return err
+	}
+
+	action := entity.AdminAction{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Action:    "logout",
+		Details:   "Admin logged out",
+		Timestamp: time.Now(),
+	}
+	_ = u.adminRepo.LogAction(ctx, action)
+
+	return nil
 }
 
 // IsAdmin admin ekanligini tekshirish
@@ -99,49 +158,142 @@ func (u *adminUseCase) IsAdmin(ctx context.Context, userID int64) (bool, error)
 	return u.adminRepo.IsAdmin(ctx, userID)
 }
 
-// UploadCatalog Excel fayldan katalogni yuklash
-func (u *adminUseCase) UploadCatalog(ctx context.Context, userID int64, fileData []byte, filename string) (int, error) {
+// UploadCatalog katalog faylidan (Excel, CSV/TSV, JSON) katalogni yuklash (tekshirish va tasdiqlash birga)
+func (u *adminUseCase) UploadCatalog(ctx context.Context, userID int64, fileData []byte, filename string, opts entity.ImportOptions) (int, error) {
+	report, err := u.PreviewCatalog(ctx, userID, fileData, filename, opts)
+	if err != nil {
+		return 0, err
+	}
+	result, err := u.ApplyCatalog(ctx, userID, report, opts)
+	if err != nil {
+		return 0, err
+	}
+	return result.Total, nil
+}
+
+// PreviewCatalog katalog faylini tekshirish: qaysi qatorlar qabul qilinadi va qaysilari nima uchun tashlanadi
+func (u *adminUseCase) PreviewCatalog(ctx context.Context, userID int64, fileData []byte, filename string, opts entity.ImportOptions) (*entity.ImportReport, error) {
 	// Admin tekshirish
 	isAdmin, err := u.adminRepo.IsAdmin(ctx, userID)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 	if !isAdmin {
-		return 0, fmt.Errorf("user is not admin")
+		return nil, fmt.Errorf("user is not admin")
 	}
 
-	// Excel faylni parse qilish
-	products, err := u.excelParser.ParseProductsFromBytes(ctx, fileData, filename)
+	// Faylni parse qilish (Excel, CSV/TSV yoki JSON)
+	report, err := u.catalogParser.ParseCatalog(ctx, fileData, filename, opts)
 	if err != nil {
-		return 0, fmt.Errorf("failed to parse excel: %w", err)
+		return nil, fmt.Errorf("failed to parse catalog: %w", err)
 	}
 
-	if len(products) == 0 {
-		return 0, fmt.Errorf("no products found in excel file")
+	reserved, err := loadReserved(ctx, u.stockRepo)
+	if err != nil {
+		return nil, err
+	}
+	report.Shortages = importShortages(report.Products, reserved)
+
+	return report, nil
+}
+
+// ApplyCatalog tekshirilgan importdagi mahsulotlar bilan katalogni yangilash.
+// Replace rejimida katalog to'liq almashtiriladi, merge rejimida mavjud mahsulotlar
+// SKU yoki nom bo'yicha yangilanadi va ularning ID lari saqlanadi.
+func (u *adminUseCase) ApplyCatalog(ctx context.Context, userID int64, report *entity.ImportReport, opts entity.ImportOptions) (*entity.ImportResult, error) {
+	isAdmin, err := u.adminRepo.IsAdmin(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isAdmin {
+		return nil, fmt.Errorf("user is not admin")
+	}
+
+	if len(report.Products) == 0 {
+		return nil, fmt.Errorf("no products found in catalog file")
+	}
+
+	// Faylga joylangan rasmlar faqat tasdiqlangandan keyin saqlanadi (ID - tarkib xeshi, takror
+	// saqlanmaydi), bekor qilingan importlar bazada rasm qoldirmaydi
+	if len(report.Images) > 0 {
+		if err := u.imageRepo.SaveImages(ctx, report.Images); err != nil {
+			return nil, fmt.Errorf("failed to save product images: %w", err)
+		}
+	}
+
+	now := time.Now()
+	products := report.Products
+	result := entity.ImportResult{
+		Mode:  entity.ImportModeReplace,
+		Total: len(products),
+		Added: len(products),
+	}
+
+	// Joriy katalog narxlar tarixi uchun ham kerak
+	existing, err := u.productRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load current catalog: %w", err)
+	}
+	if opts.Mode == entity.ImportModeMerge {
+		products, result = mergeCatalog(existing, report.Products, opts.Missing, now)
 	}
 
 	// Katalogni yangilash
 	catalog := entity.ProductCatalog{
 		Products:  products,
-		UpdatedAt: time.Now(),
-		Source:    filename,
+		UpdatedAt: now,
+		Source:    report.Source,
 	}
 
 	if err := u.productRepo.UpdateCatalog(ctx, catalog); err != nil {
-		return 0, fmt.Errorf("failed to update catalog: %w", err)
+		return nil, fmt.Errorf("failed to update catalog: %w", err)
+	}
+
+	// Katalog allaqachon yozildi: versiya va narx tarixidagi xatolar importni
+	// bekor qilmaydi, faqat loglanadi (aks holda bot muvaffaqiyatsizlik haqida
+	// xabar berib, narx tushishi bildirishnomalarini o'tkazib yuborardi)
+
+	// Har bir yuklashni versiya sifatida saqlaymiz (rollback uchun)
+	version, err := u.versionRepo.SaveVersion(ctx, entity.CatalogVersion{
+		Source:     report.Source,
+		UploadedBy: userID,
+		CreatedAt:  catalog.UpdatedAt,
+		Products:   products,
+	})
+	if err != nil {
+		log.Printf("Katalog versiyasini saqlashda xatolik: %v", err)
+	}
+	result.Version = version
+
+	if err := u.priceRepo.RecordPrices(ctx, priceChanges(existing, products, version, now)); err != nil {
+		log.Printf("Narx tarixini yozishda xatolik: %v", err)
 	}
 
 	// Upload harakatini loglash
+	details := fmt.Sprintf("Uploaded %d products from %s (version %d, %d rows rejected)", len(products), report.Source, version, report.Rejected())
+	if result.Mode == entity.ImportModeMerge {
+		details = fmt.Sprintf("Merged %s into catalog (version %d): %d added, %d updated, %d unchanged, %d removed, %d discontinued, %d rows rejected",
+			report.Source, version, result.Added, result.Updated, result.Unchanged, result.Removed, result.Discontinued, report.Rejected())
+	}
 	action := entity.AdminAction{
 		ID:        uuid.New().String(),
 		UserID:    userID,
 		Action:    "upload_catalog",
-		Details:   fmt.Sprintf("Uploaded %d products from %s", len(products), filename),
+		Details:   details,
 		Timestamp: time.Now(),
 	}
 	_ = u.adminRepo.LogAction(ctx, action)
 
-	return len(products), nil
+	return &result, nil
+}
+
+// ExportImportReport import hisobotini .xlsx faylga eksport qilish
+func (u *adminUseCase) ExportImportReport(ctx context.Context, report *entity.ImportReport) ([]byte, error) {
+	data, err := u.exporter.ExportImportReport(ctx, report)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export import report: %w", err)
+	}
+	return data, nil
 }
 
 // GetCatalogInfo katalog haqida ma'lumot
@@ -153,13 +305,27 @@ func (u *adminUseCase) GetCatalogInfo(ctx context.Context) (string, error) {
 
 	// Kategoriyalarni sanash
 	categories := make(map[string]int)
+	currencies := make(map[entity.Currency]int)
+	discontinued := 0
 	for _, product := range catalog.Products {
+		if product.Discontinued {
+			discontinued++
+			continue
+		}
 		categories[product.Category]++
+		currencies[product.PriceCurrency()]++
 	}
 
 	info := fmt.Sprintf("📦 Katalog: %s\n", catalog.Source)
 	info += fmt.Sprintf("📅 Yangilangan: %s\n", catalog.UpdatedAt.Format("2006-01-02 15:04"))
-	info += fmt.Sprintf("📊 Jami mahsulotlar: %d\n\n", len(catalog.Products))
+	info += fmt.Sprintf("📊 Jami mahsulotlar: %d\n", len(catalog.Products)-discontinued)
+	if discontinued > 0 {
+		info += fmt.Sprintf("⏸️ Sotuvdan olingan: %d\n", discontinued)
+	}
+	if len(currencies) > 1 {
+		info += fmt.Sprintf("💱 Narx valyutalari: %s\n", currencyCounts(currencies))
+	}
+	info += "\n"
 	info += "📂 Kategoriyalar:\n"
 	for cat, count := range categories {
 		info += fmt.Sprintf("  • %s: %d ta\n", cat, count)
@@ -196,3 +362,210 @@ func (u *adminUseCase) CleanAll(ctx context.Context, userID int64) error {
 
 	return nil
 }
+
+// ListCatalogVersions yuklangan katalog versiyalari ro'yxati
+func (u *adminUseCase) ListCatalogVersions(ctx context.Context) ([]entity.CatalogVersion, error) {
+	return u.versionRepo.ListVersions(ctx)
+}
+
+// DiffCatalogVersions ikki versiya orasidagi farqni hisoblash
+func (u *adminUseCase) DiffCatalogVersions(ctx context.Context, from, to int) (*entity.CatalogDiff, error) {
+	fromVersion, err := u.versionRepo.GetVersion(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	toVersion, err := u.versionRepo.GetVersion(ctx, to)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := diffProducts(fromVersion.Products, toVersion.Products)
+	diff.From = from
+	diff.To = to
+	return diff, nil
+}
+
+// RollbackCatalog katalogni oldingi versiyaga qaytarish
+func (u *adminUseCase) RollbackCatalog(ctx context.Context, userID int64, version int) (int, error) {
+	isAdmin, err := u.adminRepo.IsAdmin(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	if !isAdmin {
+		return 0, fmt.Errorf("user is not admin")
+	}
+
+	target, err := u.versionRepo.GetVersion(ctx, version)
+	if err != nil {
+		return 0, err
+	}
+
+	// Eski versiyalarda texnik atributlar saqlanmagan
+	entity.FillAttributes(target.Products)
+
+	current, err := u.productRepo.GetAll(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load current catalog: %w", err)
+	}
+
+	catalog := entity.ProductCatalog{
+		Products:  target.Products,
+		UpdatedAt: time.Now(),
+		Source:    target.Source,
+	}
+	if err := u.productRepo.UpdateCatalog(ctx, catalog); err != nil {
+		return 0, fmt.Errorf("failed to rollback catalog: %w", err)
+	}
+
+	// Katalog qaytarildi: versiya va narx tarixidagi xatolar rollbackni bekor qilmaydi, faqat loglanadi.
+	// Qaytarilgan katalog yangi versiya bo'lib saqlanadi, shunda /versions va /diff jonli katalogni ko'rsatadi.
+	restored, err := u.versionRepo.SaveVersion(ctx, entity.CatalogVersion{
+		Source:     fmt.Sprintf("rollback to v%d (%s)", version, target.Source),
+		UploadedBy: userID,
+		CreatedAt:  catalog.UpdatedAt,
+		Products:   target.Products,
+	})
+	if err != nil {
+		log.Printf("Katalog versiyasini saqlashda xatolik: %v", err)
+		restored = version
+	}
+
+	if err := u.priceRepo.RecordPrices(ctx, priceChanges(current, target.Products, restored, catalog.UpdatedAt)); err != nil {
+		log.Printf("Narx tarixini yozishda xatolik: %v", err)
+	}
+
+	action := entity.AdminAction{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Action:    "rollback_catalog",
+		Details:   fmt.Sprintf("Rolled back catalog to version %d (%s, %d products), saved as version %d", version, target.Source, len(target.Products), restored),
+		Timestamp: time.Now(),
+	}
+	_ = u.adminRepo.LogAction(ctx, action)
+
+	return len(target.Products), nil
+}
+
+// GetAuditLog admin harakatlari logini filtr bo'yicha olish
+func (u *adminUseCase) GetAuditLog(ctx context.Context, filter entity.AuditFilter) ([]entity.AdminAction, int, error) {
+	return u.adminRepo.ListActions(ctx, filter)
+}
+
+// ExportAuditLog filtrlangan audit logni .xlsx faylga eksport qilish (sahifalashsiz)
+func (u *adminUseCase) ExportAuditLog(ctx context.Context, filter entity.AuditFilter) ([]byte, error) {
+	filter.Limit = 0
+	filter.Offset = 0
+
+	actions, _, err := u.adminRepo.ListActions(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load audit log: %w", err)
+	}
+
+	data, err := u.exporter.ExportAuditLog(ctx, actions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export audit log: %w", err)
+	}
+	return data, nil
+}
+
+// ExportCatalog sotuvdagi mahsulotlarni kategoriyalar bo'yicha .xlsx ga yozish.
+// Mahsulotlar soni ham qaytadi; sotuvdan olinganlar eksportga kirmaydi.
+func (u *adminUseCase) ExportCatalog(ctx context.Context, userID int64) ([]byte, int, error) {
+	isAdmin, err := u.adminRepo.IsAdmin(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !isAdmin {
+		return nil, 0, fmt.Errorf("user is not admin")
+	}
+
+	products, err := u.productRepo.GetAll(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to load catalog: %w", err)
+	}
+	products = entity.ActiveProducts(products)
+	if len(products) == 0 {
+		return nil, 0, fmt.Errorf("catalog is empty")
+	}
+
+	data, err := u.exporter.ExportCatalog(ctx, products)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to export catalog: %w", err)
+	}
+
+	action := entity.AdminAction{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Action:    "export_catalog",
+		Details:   fmt.Sprintf("Exported %d products", len(products)),
+		Timestamp: time.Now(),
+	}
+	_ = u.adminRepo.LogAction(ctx, action)
+
+	return data, len(products), nil
+}
+
+// diffProducts ikki mahsulot ro'yxatini artikul (bo'lmasa nom) bo'yicha solishtirish (entity.PriceKey).
+// Replace importda ID lar yangilanadi, shuning uchun ID kalit bo'lolmaydi; artikuli bir xil mahsulot
+// nomi o'zgarsa o'chirilgan + qo'shilgan emas, o'zgargan bo'ladi.
+func diffProducts(from, to []entity.Product) *entity.CatalogDiff {
+	diff := &entity.CatalogDiff{}
+
+	fromMap := make(map[string]entity.Product, len(from))
+	for _, p := range from {
+		fromMap[entity.PriceKey(p)] = p
+	}
+	toMap := make(map[string]entity.Product, len(to))
+	for _, p := range to {
+		toMap[entity.PriceKey(p)] = p
+	}
+
+	for key, after := range toMap {
+		before, ok := fromMap[key]
+		if !ok {
+			diff.Added = append(diff.Added, after)
+			continue
+		}
+		if productChanged(before, after) {
+			diff.Changed = append(diff.Changed, entity.ProductChange{Before: before, After: after})
+		}
+	}
+	for key, before := range fromMap {
+		if _, ok := toMap[key]; !ok {
+			diff.Removed = append(diff.Removed, before)
+		}
+	}
+
+	sort.Slice(diff.Added, func(i, j int) bool { return diff.Added[i].Name < diff.Added[j].Name })
+	sort.Slice(diff.Removed, func(i, j int) bool { return diff.Removed[i].Name < diff.Removed[j].Name })
+	sort.Slice(diff.Changed, func(i, j int) bool { return diff.Changed[i].After.Name < diff.Changed[j].After.Name })
+
+	return diff
+}
+
+// currencyCounts "USD: 120, UZS: 30" ko'rinishidagi qisqa hisob
+func currencyCounts(counts map[entity.Currency]int) string {
+	parts := make([]string, 0, len(counts))
+	for currency, count := range counts {
+		parts = append(parts, fmt.Sprintf("%s: %d", currency, count))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ", ")
+}
+
+func productKey(p entity.Product) string {
+	return strings.ToLower(strings.Join(strings.Fields(p.Name), " "))
+}
+
+func productChanged(a, b entity.Product) bool {
+	return a.Name != b.Name ||
+		a.Price != b.Price ||
+		a.PriceCurrency() != b.PriceCurrency() ||
+		a.Category != b.Category ||
+		a.Stock != b.Stock ||
+		a.StockTracked != b.StockTracked ||
+		a.Description != b.Description ||
+		a.SKU != b.SKU ||
+		a.Image != b.Image ||
+		a.Discontinued != b.Discontinued
+}
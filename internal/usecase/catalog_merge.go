@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"maps"
+	"time"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+// mergeCatalog yangi fayl mahsulotlarini mavjud katalog bilan birlashtirish.
+// Mahsulot avval artikul (SKU), u bo'lmasa normallashtirilgan nom bo'yicha topiladi.
+// Topilganlarning ID va CreatedAt qiymati saqlanadi, faqat o'zgargan maydonlar yangilanadi.
+// Faylda bo'lmagan mahsulotlar missing siyosatiga ko'ra o'chiriladi, to'xtatiladi yoki qoladi.
+func mergeCatalog(existing, incoming []entity.Product, missing entity.MissingPolicy, now time.Time) ([]entity.Product, entity.ImportResult) {
+	result := entity.ImportResult{Mode: entity.ImportModeMerge}
+
+	bySKU := make(map[string]int, len(existing))
+	byName := make(map[string]int, len(existing))
+	for i, p := range existing {
+		if sku := entity.NormalizeSKU(p.SKU); sku != "" {
+			if _, ok := bySKU[sku]; !ok {
+				bySKU[sku] = i
+			}
+		}
+		if _, ok := byName[productKey(p)]; !ok {
+			byName[productKey(p)] = i
+		}
+	}
+
+	matched := make(map[int]bool, len(existing))
+	merged := make([]entity.Product, 0, len(existing)+len(incoming))
+
+	for _, in := range incoming {
+		idx := matchExisting(existing, bySKU, byName, matched, in)
+		if idx < 0 {
+			in.Discontinued = false
+			merged = append(merged, in)
+			result.Added++
+			continue
+		}
+
+		matched[idx] = true
+		product := existing[idx]
+		if updateProduct(&product, in) {
+			product.UpdatedAt = now
+			result.Updated++
+		} else {
+			result.Unchanged++
+		}
+		merged = append(merged, product)
+	}
+
+	for i, p := range existing {
+		if matched[i] {
+			continue
+		}
+		switch missing {
+		case entity.MissingKeep:
+			merged = append(merged, p)
+		case entity.MissingDiscontinue:
+			if !p.Discontinued {
+				p.Discontinued = true
+				p.UpdatedAt = now
+				result.Discontinued++
+			}
+			merged = append(merged, p)
+		default:
+			result.Removed++
+		}
+	}
+
+	result.Total = len(merged)
+	return merged, result
+}
+
+// matchExisting fayldagi mahsulotga mos mavjud mahsulot indeksi (-1 - yangi mahsulot).
+// Nom bo'yicha moslik faqat artikullar zid bo'lmaganda olinadi (bir nomli variantlar
+// har xil artikulga ega bo'lishi mumkin). Bitta mavjud mahsulot ikki marta olinmaydi.
+func matchExisting(existing []entity.Product, bySKU, byName map[string]int, matched map[int]bool, in entity.Product) int {
+	sku := entity.NormalizeSKU(in.SKU)
+	if sku != "" {
+		if idx, ok := bySKU[sku]; ok && !matched[idx] {
+			return idx
+		}
+	}
+
+	idx, ok := byName[productKey(in)]
+	if !ok || matched[idx] {
+		return -1
+	}
+	if existingSKU := entity.NormalizeSKU(existing[idx].SKU); sku != "" && existingSKU != "" && existingSKU != sku {
+		return -1
+	}
+	return idx
+}
+
+// updateProduct fayldagi qiymatlarni mavjud mahsulotga ko'chirish; biror maydon o'zgargan bo'lsa true.
+// Nom va narx (valyutasi bilan) har doim fayldan olinadi. Ombor, tavsif, artikul, xususiyatlar va rasm faqat faylda
+// to'ldirilgan bo'lsa almashtiriladi. Kategoriya ustuni yo'q fayllarda parser kategoriyani taksonomiya
+// qoidalaridan oladi ("Boshqa" bo'lishi ham mumkin), shuning uchun bu qiymat mavjud kategoriyani bosib ketmaydi.
+func updateProduct(dst *entity.Product, src entity.Product) bool {
+	changed := false
+
+	if dst.Name != src.Name {
+		dst.Name = src.Name
+		changed = true
+	}
+	if dst.Price != src.Price {
+		dst.Price = src.Price
+		changed = true
+	}
+	if dst.PriceCurrency() != src.PriceCurrency() {
+		dst.Currency = src.PriceCurrency()
+		changed = true
+	}
+	if src.StockTracked && (dst.Stock != src.Stock || !dst.StockTracked) {
+		dst.Stock = src.Stock
+		dst.StockTracked = true
+		changed = true
+	}
+	if src.Category != "" && dst.Category != src.Category &&
+		(dst.Category == "" || (src.Category != entity.DefaultCategory && (!src.CategoryAuto || dst.CategoryAuto))) {
+		dst.Category = src.Category
+		dst.CategoryAuto = src.CategoryAuto
+		changed = true
+	} else if dst.Category == src.Category && !src.CategoryAuto {
+		dst.CategoryAuto = false
+	}
+	if src.Description != "" && dst.Description != src.Description {
+		dst.Description = src.Description
+		changed = true
+	}
+	if src.SKU != "" && dst.SKU != src.SKU {
+		dst.SKU = src.SKU
+		changed = true
+	}
+	if len(src.Specs) > 0 && !maps.Equal(dst.Specs, src.Specs) {
+		dst.Specs = src.Specs
+		changed = true
+	}
+	if src.Image != "" && dst.Image != src.Image {
+		dst.Image = src.Image
+		changed = true
+	}
+	if dst.Discontinued {
+		dst.Discontinued = false
+		changed = true
+	}
+	// Atributlar nom, kategoriya va xususiyatlardan olinadi, alohida o'zgarish hisoblanmaydi
+	dst.Attributes = entity.ExtractAttributes(dst.Name, dst.Category, dst.Specs)
+
+	return changed
+}
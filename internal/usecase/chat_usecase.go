@@ -17,24 +17,36 @@ type ChatUseCase interface {
 	ClearHistory(ctx context.Context, userID int64) error
 	GetHistory(ctx context.Context, userID int64) ([]entity.Message, error)
 	GetAllMessages(ctx context.Context, limit int) ([]entity.Message, error)
+	PruneContexts(ctx context.Context, olderThan time.Duration) (int, error)
+	SearchMessages(ctx context.Context, query string, limit int) ([]entity.MessageMatch, error)
 }
 
 type chatUseCase struct {
-	aiRepo      repository.AIRepository
-	chatRepo    repository.ChatRepository
-	productRepo repository.ProductRepository
+	aiRepo       repository.AIRepository
+	chatRepo     repository.ChatRepository
+	productRepo  repository.ProductRepository
+	rateRepo     repository.ExchangeRateRepository
+	taxonomyRepo repository.TaxonomyRepository
+	stockRepo    repository.StockReservationRepository
 }
 
-// NewChatUseCase yangi ChatUseCase yaratish
+// NewChatUseCase yangi ChatUseCase yaratish (rateRepo nil bo'lsa narxlar faqat asl valyutada,
+// taxonomyRepo nil bo'lsa kategoriyalar standart o'zbekcha/ruscha nomlar bilan, stockRepo nil bo'lsa bronlar hisobga olinmaydi)
 func NewChatUseCase(
 	aiRepo repository.AIRepository,
 	chatRepo repository.ChatRepository,
 	productRepo repository.ProductRepository,
+	rateRepo repository.ExchangeRateRepository,
+	taxonomyRepo repository.TaxonomyRepository,
+	stockRepo repository.StockReservationRepository,
 ) ChatUseCase {
 	return &chatUseCase{
-		aiRepo:      aiRepo,
-		chatRepo:    chatRepo,
-		productRepo: productRepo,
+		aiRepo:       aiRepo,
+		chatRepo:     chatRepo,
+		productRepo:  productRepo,
+		rateRepo:     rateRepo,
+		taxonomyRepo: taxonomyRepo,
+		stockRepo:    stockRepo,
 	}
 }
 
@@ -51,14 +63,20 @@ func (u *chatUseCase) ProcessMessage(ctx context.Context, userID int64, username
 	}
 
 	// Mahsulotlar borligini tekshirish
-	products, err := u.productRepo.GetAll(ctx)
+	// Omborda qolmagan (bronlar bilan) mahsulotlar AI ga berilmaydi
+	products, err := activeOnly(u.productRepo.GetAll(ctx))
+	if err == nil {
+		products = inStockOnly(ctx, u.stockRepo, products)
+	}
 	hasProducts := err == nil && len(products) > 0
 
 	// Foydalanuvchi xabariga mahsulot katalogini qo'shish
 	enrichedText := text
 	if hasProducts {
 		// HAR DOIM mahsulot ma'lumotini AI ga yuborish
-		productsInfo := u.buildProductsContext(products)
+		rates, _ := loadRates(ctx, u.rateRepo)
+		taxonomy, _ := loadTaxonomy(ctx, u.taxonomyRepo)
+		productsInfo := u.buildProductsContext(products, rates, taxonomy)
 		enrichedText = fmt.Sprintf(`Mijoz: %s
 
 ━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━
@@ -74,6 +92,9 @@ func (u *chatUseCase) ProcessMessage(ctx context.Context, userID int64, username
 5. Agar mijoz budjet aytsa (masalan 1000$), imkon qadar shu budjetga yaqinlash — 0..100$ gacha oshishi mumkin
 6. Jami summani hisoblashda xato qilma
 7. Budjet yetmasa, arzonroq variantlar taklif qil
+8. Narx so'mda bo'lsa so'mda, dollarda bo'lsa dollarda yoz; qavs ichidagi "≈" qiymat kurs bo'yicha taxminiy. Jami summani bitta valyutada hisobla
+9. Konfiguratsiyada "Atributlar" ga qarab moslikni tekshir: protsessor va ona plata socketi bir xil, operativ xotira turi (DDR4/DDR5) ona plataga mos bo'lsin
+10. Mahsulot yonida "Omborda: N ta" bo'lsa, undan ko'p dona taklif qilma
 
 Mijozga javob ber:`, text, productsInfo)
 
@@ -108,8 +129,9 @@ Mijozga javob ber:`, text, productsInfo)
 	return response, nil
 }
 
-// buildProductsContext mahsulotlardan kontekst yaratish
-func (u *chatUseCase) buildProductsContext(products []entity.Product) string {
+// buildProductsContext mahsulotlardan kontekst yaratish (kategoriya nomlari tarjimasi bilan,
+// mijoz "videokarta" yoki "видеокарта" deb so'rashi mumkin)
+func (u *chatUseCase) buildProductsContext(products []entity.Product, rates entity.ExchangeRates, taxonomy *entity.Taxonomy) string {
 	var sb strings.Builder
 
 	// Kategoriyalar bo'yicha guruhlash
@@ -124,10 +146,14 @@ func (u *chatUseCase) buildProductsContext(products []entity.Product) string {
 
 	// Mahsulotlarni yozish
 	for category, prods := range categoryMap {
-		sb.WriteString(fmt.Sprintf("\n📂 %s:\n", category))
+		label := category
+		if taxonomy != nil {
+			label = taxonomy.DisplayName(category)
+		}
+		sb.WriteString(fmt.Sprintf("\n📂 %s:\n", label))
 		for i, p := range prods {
-			// Narxni dollar formatida ko'rsatish (Stock 0 bo'lsa ham ko'rsatamiz - product mavjud)
-			sb.WriteString(fmt.Sprintf("  %d. %s - $%.2f", i+1, p.Name, p.Price))
+			// Narx asl valyutada va kurs bo'yicha so'm/dollarda. Ombor soni faqat hisobi yuritilsa (bronlardan keyin qolgani)
+			sb.WriteString(fmt.Sprintf("  %d. %s - %s", i+1, p.Name, rates.FormatDual(p.Price, p.PriceCurrency())))
 
 			if p.Stock > 0 {
 				sb.WriteString(fmt.Sprintf(" (Omborda: %d ta)", p.Stock))
@@ -147,6 +173,10 @@ func (u *chatUseCase) buildProductsContext(products []entity.Product) string {
 				sb.WriteString(strings.Join(specs, ", "))
 			}
 
+			if !p.Attributes.IsZero() {
+				sb.WriteString("\n     └─ Atributlar: " + p.Attributes.Summary())
+			}
+
 			sb.WriteString("\n")
 		}
 	}
@@ -168,3 +198,16 @@ func (u *chatUseCase) GetHistory(ctx context.Context, userID int64) ([]entity.Me
 func (u *chatUseCase) GetAllMessages(ctx context.Context, limit int) ([]entity.Message, error) {
 	return u.chatRepo.GetAllMessages(ctx, limit)
 }
+
+// SearchMessages yozishmalar bo'yicha qidiruv (admin uchun)
+func (u *chatUseCase) SearchMessages(ctx context.Context, query string, limit int) ([]entity.MessageMatch, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, fmt.Errorf("search query is empty")
+	}
+	return u.chatRepo.Search(ctx, query, limit)
+}
+
+// PruneContexts olderThan davomida ishlatilmagan chat kontekstlarini tozalash
+func (u *chatUseCase) PruneContexts(ctx context.Context, olderThan time.Duration) (int, error) {
+	return u.chatRepo.PruneContexts(ctx, time.Now().Add(-olderThan))
+}
@@ -0,0 +1,311 @@
+package usecase
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/repository"
+)
+
+// CurrencyUseCase valyuta kurslari bilan bog'liq business logic
+type CurrencyUseCase interface {
+	// Rates narxlarni o'tkazish uchun kurslar jadvali (UZS har doim bor)
+	Rates(ctx context.Context) (entity.ExchangeRates, error)
+
+	// ListRates saqlangan kurslar (manba va vaqti bilan)
+	ListRates(ctx context.Context) ([]entity.ExchangeRate, error)
+
+	// SetRate kursni qo'lda o'rnatish (1 birlik necha so'm)
+	SetRate(ctx context.Context, userID int64, currency entity.Currency, rate float64) error
+
+	// ImportRates kurslar faylini yuklash ("USD 12650" qatorlari yoki {"USD": 12650} JSON)
+	ImportRates(ctx context.Context, userID int64, data []byte) ([]entity.ExchangeRate, error)
+
+	// RefreshRates kurslarni tashqi manbadan olish (qo'lda kiritilganlar ham yangilanadi)
+	RefreshRates(ctx context.Context, userID int64) ([]entity.ExchangeRate, error)
+
+	// SyncRates fon yangilanishi: qo'lda kiritilgan kurslarga tegmaydi
+	SyncRates(ctx context.Context) (int, error)
+
+	// HasSource tashqi kurs manbai ulanganmi
+	HasSource() bool
+}
+
+type currencyUseCase struct {
+	rateRepo  repository.ExchangeRateRepository
+	source    repository.RateSource
+	adminRepo repository.AdminRepository
+}
+
+// NewCurrencyUseCase yangi CurrencyUseCase yaratish (source nil bo'lishi mumkin)
+func NewCurrencyUseCase(
+	rateRepo repository.ExchangeRateRepository,
+	source repository.RateSource,
+	adminRepo repository.AdminRepository,
+) CurrencyUseCase {
+	return &currencyUseCase{
+		rateRepo:  rateRepo,
+		source:    source,
+		adminRepo: adminRepo,
+	}
+}
+
+// Rates kurslar jadvali
+func (u *currencyUseCase) Rates(ctx context.Context) (entity.ExchangeRates, error) {
+	return loadRates(ctx, u.rateRepo)
+}
+
+// ListRates saqlangan kurslar
+func (u *currencyUseCase) ListRates(ctx context.Context) ([]entity.ExchangeRate, error) {
+	return u.rateRepo.ListRates(ctx)
+}
+
+// SetRate kursni qo'lda o'rnatish
+func (u *currencyUseCase) SetRate(ctx context.Context, userID int64, currency entity.Currency, rate float64) error {
+	if err := u.requireAdmin(ctx, userID); err != nil {
+		return err
+	}
+	if err := validateRate(currency, rate); err != nil {
+		return err
+	}
+
+	if err := u.rateRepo.SaveRates(ctx, []entity.ExchangeRate{{
+		Currency:  currency,
+		Rate:      rate,
+		Source:    entity.RateSourceAdmin,
+		UpdatedAt: time.Now(),
+	}}); err != nil {
+		return fmt.Errorf("failed to save exchange rate: %w", err)
+	}
+
+	u.logAction(ctx, userID, "set_rate", fmt.Sprintf("1 %s = %.2f UZS", currency, rate))
+	return nil
+}
+
+// ImportRates kurslar faylini yuklash
+func (u *currencyUseCase) ImportRates(ctx context.Context, userID int64, data []byte) ([]entity.ExchangeRate, error) {
+	if err := u.requireAdmin(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	rates, err := parseRatesFile(data, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := u.rateRepo.SaveRates(ctx, rates); err != nil {
+		return nil, fmt.Errorf("failed to save exchange rates: %w", err)
+	}
+
+	u.logAction(ctx, userID, "import_rates", fmt.Sprintf("Imported %d exchange rates: %s", len(rates), ratesSummary(rates)))
+	return rates, nil
+}
+
+// RefreshRates kurslarni tashqi manbadan olish
+func (u *currencyUseCase) RefreshRates(ctx context.Context, userID int64) ([]entity.ExchangeRate, error) {
+	if err := u.requireAdmin(ctx, userID); err != nil {
+		return nil, err
+	}
+	if u.source == nil {
+		return nil, fmt.Errorf("rate source is not configured")
+	}
+
+	rates, err := u.source.FetchRates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch rates from %s: %w", u.source.Name(), err)
+	}
+	if err := u.rateRepo.SaveRates(ctx, rates); err != nil {
+		return nil, fmt.Errorf("failed to save exchange rates: %w", err)
+	}
+
+	u.logAction(ctx, userID, "refresh_rates", fmt.Sprintf("Fetched %d rates from %s: %s", len(rates), u.source.Name(), ratesSummary(rates)))
+	return rates, nil
+}
+
+// SyncRates tashqi manbadan kurslarni yangilash (qo'lda kiritilgan valyutalar o'tkazib yuboriladi).
+// Yangilangan kurslar sonini qaytaradi; manba ulanmagan bo'lsa 0.
+func (u *currencyUseCase) SyncRates(ctx context.Context) (int, error) {
+	if u.source == nil {
+		return 0, nil
+	}
+
+	fetched, err := u.source.FetchRates(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch rates from %s: %w", u.source.Name(), err)
+	}
+
+	current, err := u.rateRepo.ListRates(ctx)
+	if err != nil {
+		return 0, err
+	}
+	manual := make(map[entity.Currency]bool, len(current))
+	for _, rate := range current {
+		if rate.IsManual() {
+			manual[rate.Currency] = true
+		}
+	}
+
+	rates := make([]entity.ExchangeRate, 0, len(fetched))
+	for _, rate := range fetched {
+		if !manual[rate.Currency] {
+			rates = append(rates, rate)
+		}
+	}
+	if len(rates) == 0 {
+		return 0, nil
+	}
+	if err := u.rateRepo.SaveRates(ctx, rates); err != nil {
+		return 0, fmt.Errorf("failed to save exchange rates: %w", err)
+	}
+	return len(rates), nil
+}
+
+// HasSource tashqi kurs manbai ulanganmi
+func (u *currencyUseCase) HasSource() bool {
+	return u.source != nil
+}
+
+func (u *currencyUseCase) requireAdmin(ctx context.Context, userID int64) error {
+	isAdmin, err := u.adminRepo.IsAdmin(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return fmt.Errorf("user is not admin")
+	}
+	return nil
+}
+
+func (u *currencyUseCase) logAction(ctx context.Context, userID int64, name, details string) {
+	action := entity.AdminAction{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Action:    name,
+		Details:   details,
+		Timestamp: time.Now(),
+	}
+	_ = u.adminRepo.LogAction(ctx, action)
+}
+
+// loadRates repositorydagi kurslardan jadval yaratish (rateRepo nil bo'lsa faqat UZS)
+func loadRates(ctx context.Context, rateRepo repository.ExchangeRateRepository) (entity.ExchangeRates, error) {
+	if rateRepo == nil {
+		return entity.NewExchangeRates(nil), nil
+	}
+	list, err := rateRepo.ListRates(ctx)
+	if err != nil {
+		return entity.NewExchangeRates(nil), fmt.Errorf("failed to load exchange rates: %w", err)
+	}
+	return entity.NewExchangeRates(list), nil
+}
+
+func validateRate(currency entity.Currency, rate float64) error {
+	if currency == "" || currency == entity.CurrencyUZS {
+		return fmt.Errorf("invalid currency for rate: %q", currency)
+	}
+	if rate <= 0 {
+		return fmt.Errorf("rate must be positive: %v", rate)
+	}
+	return nil
+}
+
+// parseRatesFile kurslar faylini o'qish. Qo'llab-quvvatlanadigan ko'rinishlar:
+//
+//	USD 12650
+//	EUR;13 720,50
+//	{"USD": 12650, "EUR": 13720.5}
+//
+// Bo'sh va "#" bilan boshlangan qatorlar, hamda sarlavha (valyuta aniqlanmagan qator) o'tkazib yuboriladi.
+func parseRatesFile(data []byte, now time.Time) ([]entity.ExchangeRate, error) {
+	data = bytes.TrimSpace(bytes.TrimPrefix(data, []byte{0xEF, 0xBB, 0xBF}))
+	if len(data) == 0 {
+		return nil, fmt.Errorf("rates file is empty")
+	}
+
+	raw := make(map[string]string)
+	var order []string
+	if data[0] == '{' {
+		var obj map[string]json.Number
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return nil, fmt.Errorf("invalid rates json: %w", err)
+		}
+		for code, value := range obj {
+			raw[code] = value.String()
+			order = append(order, code)
+		}
+		sort.Strings(order)
+	} else {
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			code, value, ok := splitRateLine(line)
+			if !ok {
+				continue
+			}
+			if _, seen := raw[code]; !seen {
+				order = append(order, code)
+			}
+			raw[code] = value
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read rates file: %w", err)
+		}
+	}
+
+	var rates []entity.ExchangeRate
+	for _, code := range order {
+		currency, ok := entity.ParseCurrency(code)
+		if !ok || currency == entity.CurrencyUZS {
+			continue
+		}
+		rate, err := entity.ParseAmount(raw[code])
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate for %s: %q", code, raw[code])
+		}
+		if err := validateRate(currency, rate); err != nil {
+			return nil, err
+		}
+		rates = append(rates, entity.ExchangeRate{
+			Currency:  currency,
+			Rate:      rate,
+			Source:    entity.RateSourceFile,
+			UpdatedAt: now,
+		})
+	}
+
+	if len(rates) == 0 {
+		return nil, fmt.Errorf("no exchange rates found in file")
+	}
+	return rates, nil
+}
+
+// splitRateLine "USD 12650", "USD;12650", "USD,12650" yoki "USD\t12650" qatorini ajratish
+func splitRateLine(line string) (string, string, bool) {
+	idx := strings.IndexAny(line, " \t;,=:")
+	if idx <= 0 {
+		return "", "", false
+	}
+	code := strings.TrimSpace(line[:idx])
+	if _, ok := entity.ParseCurrency(code); !ok {
+		return "", "", false
+	}
+	return code, strings.Trim(strings.TrimSpace(line[idx+1:]), ";,=:\t "), true
+}
+
+func ratesSummary(rates []entity.ExchangeRate) string {
+	parts := make([]string, 0, len(rates))
+	for _, rate := range rates {
+		parts = append(parts, fmt.Sprintf("%s=%.2f", rate.Currency, rate.Rate))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/repository"
+)
+
+// ImportProfileUseCase yetkazib beruvchilar import profillari bilan bog'liq business logic
+type ImportProfileUseCase interface {
+	// ListProfiles barcha profillar
+	ListProfiles(ctx context.Context) ([]entity.ImportProfile, error)
+
+	// GetProfile nom bo'yicha profil
+	GetProfile(ctx context.Context, name string) (*entity.ImportProfile, error)
+
+	// SaveProfile profil matnini (entity.ParseImportProfile formati) o'qib saqlash
+	SaveProfile(ctx context.Context, userID int64, name, text string) (*entity.ImportProfile, error)
+
+	// DeleteProfile profilni o'chirish
+	DeleteProfile(ctx context.Context, userID int64, name string) error
+}
+
+type importProfileUseCase struct {
+	profileRepo repository.ImportProfileRepository
+	adminRepo   repository.AdminRepository
+}
+
+// NewImportProfileUseCase yangi ImportProfileUseCase yaratish
+func NewImportProfileUseCase(
+	profileRepo repository.ImportProfileRepository,
+	adminRepo repository.AdminRepository,
+) ImportProfileUseCase {
+	return &importProfileUseCase{
+		profileRepo: profileRepo,
+		adminRepo:   adminRepo,
+	}
+}
+
+// ListProfiles barcha profillar
+func (u *importProfileUseCase) ListProfiles(ctx context.Context) ([]entity.ImportProfile, error) {
+	return u.profileRepo.ListProfiles(ctx)
+}
+
+// GetProfile nom bo'yicha profil
+func (u *importProfileUseCase) GetProfile(ctx context.Context, name string) (*entity.ImportProfile, error) {
+	return u.profileRepo.GetProfile(ctx, strings.TrimSpace(name))
+}
+
+// SaveProfile profilni saqlash (shu nomli profil almashtiriladi)
+func (u *importProfileUseCase) SaveProfile(ctx context.Context, userID int64, name, text string) (*entity.ImportProfile, error) {
+	if err := u.requireAdmin(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	profile, err := entity.ParseImportProfile(name, text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid import profile: %w", err)
+	}
+	profile.UpdatedBy = userID
+	profile.UpdatedAt = time.Now()
+
+	if err := u.profileRepo.SaveProfile(ctx, profile); err != nil {
+		return nil, fmt.Errorf("failed to save import profile: %w", err)
+	}
+
+	u.logAction(ctx, userID, "save_import_profile", fmt.Sprintf("Saved import profile %q (%d columns)", profile.Name, len(profile.Columns)))
+	return &profile, nil
+}
+
+// DeleteProfile profilni o'chirish
+func (u *importProfileUseCase) DeleteProfile(ctx context.Context, userID int64, name string) error {
+	if err := u.requireAdmin(ctx, userID); err != nil {
+		return err
+	}
+
+	name = strings.TrimSpace(name)
+	if err := u.profileRepo.DeleteProfile(ctx, name); err != nil {
+		return fmt.Errorf("failed to delete import profile: %w", err)
+	}
+
+	u.logAction(ctx, userID, "delete_import_profile", fmt.Sprintf("Deleted import profile %q", name))
+	return nil
+}
+
+func (u *importProfileUseCase) requireAdmin(ctx context.Context, userID int64) error {
+	isAdmin, err := u.adminRepo.IsAdmin(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return fmt.Errorf("user is not admin")
+	}
+	return nil
+}
+
+func (u *importProfileUseCase) logAction(ctx context.Context, userID int64, name, details string) {
+	action := entity.AdminAction{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Action:    name,
+		Details:   details,
+		Timestamp: time.Now(),
+	}
+	_ = u.adminRepo.LogAction(ctx, action)
+}
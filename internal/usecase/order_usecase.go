@@ -0,0 +1,330 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/repository"
+)
+
+// OrderUseCase buyurtmalar bilan bog'liq business logic
+type OrderUseCase interface {
+	// PlaceOrder yangi buyurtmani saqlash (ID, holat va jami summa shu yerda beriladi)
+	PlaceOrder(ctx context.Context, order entity.Order) (*entity.Order, error)
+
+	// UpdateStatus buyurtma holatini o'zgartirish (faqat ruxsat etilgan o'tishlar). Tasdiqlanganda
+	// mahsulotlar omborda bron qilinadi (yetmasa *StockShortageError), bekor qilinganda bron bo'shatiladi,
+	// topshirilganda ombordagi sondan ayiriladi.
+	UpdateStatus(ctx context.Context, orderID string, status entity.OrderStatus) (*entity.Order, error)
+
+	// GetOrder ID bo'yicha buyurtmani olish
+	GetOrder(ctx context.Context, orderID string) (*entity.Order, error)
+
+	// ListUserOrders foydalanuvchi buyurtmalari
+	ListUserOrders(ctx context.Context, userID int64) ([]entity.Order, error)
+
+	// ListOrders holat bo'yicha buyurtmalar (admin uchun)
+	ListOrders(ctx context.Context, status entity.OrderStatus, limit int) ([]entity.Order, error)
+
+	// MatchCatalogItems matnda nomi tilga olingan katalog mahsulotlarini topish
+	MatchCatalogItems(ctx context.Context, text string) ([]entity.OrderItem, error)
+
+	// CheckCompatibility buyurtmadagi mahsulotlar bir-biriga mosligini tekshirish (socket, xotira turi)
+	CheckCompatibility(ctx context.Context, items []entity.OrderItem) ([]string, error)
+}
+
+type orderUseCase struct {
+	orderRepo   repository.OrderRepository
+	productRepo repository.ProductRepository
+	rateRepo    repository.ExchangeRateRepository
+	stockRepo   repository.StockReservationRepository
+
+	// stockMu ombor tekshiruvi va bron bir vaqtda ikki buyurtmaga bir xil mahsulotni bermasligi uchun
+	stockMu sync.Mutex
+}
+
+// NewOrderUseCase yangi OrderUseCase yaratish (rateRepo nil bo'lsa har xil valyutali jami hisoblanmaydi,
+// stockRepo nil bo'lsa ombor bron qilinmaydi)
+func NewOrderUseCase(
+	orderRepo repository.OrderRepository,
+	productRepo repository.ProductRepository,
+	rateRepo repository.ExchangeRateRepository,
+	stockRepo repository.StockReservationRepository,
+) OrderUseCase {
+	return &orderUseCase{
+		orderRepo:   orderRepo,
+		productRepo: productRepo,
+		rateRepo:    rateRepo,
+		stockRepo:   stockRepo,
+	}
+}
+
+// PlaceOrder yangi buyurtmani saqlash
+func (u *orderUseCase) PlaceOrder(ctx context.Context, order entity.Order) (*entity.Order, error) {
+	now := time.Now()
+	order.ID = uuid.New().String()
+	order.Status = entity.OrderStatusNew
+	order.CreatedAt = now
+	order.UpdatedAt = now
+
+	rates, _ := loadRates(ctx, u.rateRepo)
+	order.Total, order.Currency = orderTotal(order.Items, rates)
+
+	if err := u.orderRepo.Save(ctx, order); err != nil {
+		return nil, fmt.Errorf("failed to save order: %w", err)
+	}
+
+	return &order, nil
+}
+
+// UpdateStatus buyurtma holatini o'zgartirish. Ombor amali holat saqlanishidan oldin bajariladi:
+// bron qilib bo'lmasa buyurtma holati o'zgarmaydi.
+func (u *orderUseCase) UpdateStatus(ctx context.Context, orderID string, status entity.OrderStatus) (*entity.Order, error) {
+	u.stockMu.Lock()
+	defer u.stockMu.Unlock()
+
+	order, err := u.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !order.Status.CanTransitionTo(status) {
+		return nil, fmt.Errorf("order %s: cannot change status from %s to %s", orderID, order.Status, status)
+	}
+
+	if u.stockRepo != nil {
+		switch status {
+		case entity.OrderStatusConfirmed:
+			err = u.reserveStock(ctx, order)
+		case entity.OrderStatusCancelled:
+			if err = u.stockRepo.DeleteByOrder(ctx, order.ID); err != nil {
+				err = fmt.Errorf("failed to release stock: %w", err)
+			}
+		case entity.OrderStatusDelivered:
+			// Qoldiq va bronlar bitta tranzaksiyada yangilanadi (mahsulot qatorlari qayta yozilmaydi)
+			if err = u.stockRepo.CommitOrder(ctx, order.ID); err != nil {
+				err = fmt.Errorf("failed to commit stock: %w", err)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	order.Status = status
+	order.UpdatedAt = time.Now()
+	if err := u.orderRepo.Save(ctx, *order); err != nil {
+		if status == entity.OrderStatusConfirmed && u.stockRepo != nil {
+			u.stockRepo.DeleteByOrder(ctx, order.ID)
+		}
+		return nil, fmt.Errorf("failed to update order: %w", err)
+	}
+
+	return order, nil
+}
+
+// reserveStock buyurtma mahsulotlarini bron qilish. Ombor hisobi yuritilmaydigan va katalogda
+// topilmagan mahsulotlar bron qilinmaydi; biror mahsulot yetmasa hech narsa bron qilinmaydi.
+func (u *orderUseCase) reserveStock(ctx context.Context, order *entity.Order) error {
+	products, err := u.productRepo.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load catalog: %w", err)
+	}
+	reservations, err := u.stockRepo.ListReservations(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load stock reservations: %w", err)
+	}
+	others := reservations[:0]
+	for _, r := range reservations {
+		if r.OrderID != order.ID {
+			others = append(others, r)
+		}
+	}
+	reserved := entity.ReservedStock(others)
+
+	catalog := newProductIndex(products)
+	now := time.Now()
+	var list []entity.StockReservation
+	required := make(map[string]int)
+	for _, item := range order.Items {
+		product, ok := catalog.find(item.ProductID, item.Name)
+		if !ok || !product.StockTracked {
+			continue
+		}
+		key := entity.PriceKey(product)
+		if _, seen := required[key]; !seen {
+			list = append(list, entity.StockReservation{
+				OrderID:   order.ID,
+				ProductID: product.ID,
+				Key:       key,
+				Name:      product.Name,
+				CreatedAt: now,
+			})
+		}
+		required[key] += max(item.Quantity, 1)
+	}
+
+	var shortages []entity.StockShortage
+	for i := range list {
+		r := &list[i]
+		r.Quantity = required[r.Key]
+		product, _ := catalog.find(r.ProductID, r.Name)
+		if product.Stock-reserved[r.Key] < r.Quantity {
+			shortages = append(shortages, entity.StockShortage{
+				Name:     r.Name,
+				Stock:    product.Stock,
+				Reserved: reserved[r.Key],
+				Required: r.Quantity,
+			})
+		}
+	}
+	if len(shortages) > 0 {
+		return &StockShortageError{Shortages: shortages}
+	}
+
+	if err := u.stockRepo.SaveReservations(ctx, order.ID, list); err != nil {
+		return fmt.Errorf("failed to reserve stock: %w", err)
+	}
+	return nil
+}
+
+// GetOrder ID bo'yicha buyurtmani olish
+func (u *orderUseCase) GetOrder(ctx context.Context, orderID string) (*entity.Order, error) {
+	return u.orderRepo.GetByID(ctx, orderID)
+}
+
+// ListUserOrders foydalanuvchi buyurtmalari
+func (u *orderUseCase) ListUserOrders(ctx context.Context, userID int64) ([]entity.Order, error) {
+	return u.orderRepo.ListByUser(ctx, userID)
+}
+
+// ListOrders holat bo'yicha buyurtmalar
+func (u *orderUseCase) ListOrders(ctx context.Context, status entity.OrderStatus, limit int) ([]entity.Order, error) {
+	return u.orderRepo.ListByStatus(ctx, status, limit)
+}
+
+// MatchCatalogItems matnda nomi tilga olingan katalog mahsulotlarini topish (omborda qolmaganlarsiz).
+// AI va preview matnlari mahsulot nomini aynan katalogdan ko'chiradi, shuning uchun
+// nomning matnda uchrashi yetarli. Uzun nomlar birinchi tekshiriladi, qisqa nom
+// uzunroq nomning bir qismi bo'lsa ikki marta qo'shilmaydi.
+func (u *orderUseCase) MatchCatalogItems(ctx context.Context, text string) ([]entity.OrderItem, error) {
+	products, err := activeOnly(u.productRepo.GetAll(ctx))
+	if err != nil {
+		return nil, err
+	}
+	products = inStockOnly(ctx, u.stockRepo, products)
+
+	haystack := strings.ToLower(text)
+	sort.Slice(products, func(i, j int) bool {
+		return len(products[i].Name) > len(products[j].Name)
+	})
+
+	var items []entity.OrderItem
+	for _, p := range products {
+		name := strings.ToLower(strings.TrimSpace(p.Name))
+		if len(name) < 3 || !strings.Contains(haystack, name) {
+			continue
+		}
+		items = append(items, entity.OrderItem{
+			ProductID: p.ID,
+			Name:      p.Name,
+			Price:     p.Price,
+			Currency:  p.PriceCurrency(),
+			Quantity:  1,
+		})
+		// Bir xil matn bo'lagi boshqa (qisqaroq) nomga ham mos kelmasligi uchun
+		haystack = strings.Replace(haystack, name, " ", 1)
+	}
+
+	return items, nil
+}
+
+// CheckCompatibility buyurtmadagi mahsulotlar mos kelmasliklari. Katalogdan o'chirilgan
+// mahsulotlar tekshirilmaydi.
+func (u *orderUseCase) CheckCompatibility(ctx context.Context, items []entity.OrderItem) ([]string, error) {
+	products := make([]entity.Product, 0, len(items))
+	for _, item := range items {
+		if item.ProductID == "" {
+			continue
+		}
+		product, err := u.productRepo.GetByID(ctx, item.ProductID)
+		if err != nil {
+			continue
+		}
+		products = append(products, *product)
+	}
+	return entity.CompatibilityIssues(products), nil
+}
+
+// orderTotal buyurtma jami summasi va valyutasi.
+// Barcha mahsulotlar bir valyutada bo'lsa jami shu valyutada, aks holda so'mga o'tkaziladi.
+// Biror valyuta kursi bo'lmasa jami hisoblanmaydi (0 va bo'sh valyuta).
+func orderTotal(items []entity.OrderItem, rates entity.ExchangeRates) (float64, entity.Currency) {
+	if len(items) == 0 {
+		return 0, entity.DefaultCurrency
+	}
+
+	currency := itemCurrency(items[0])
+	for _, item := range items[1:] {
+		if itemCurrency(item) != currency {
+			currency = entity.CurrencyUZS
+			break
+		}
+	}
+
+	total := 0.0
+	for _, item := range items {
+		amount, ok := rates.Convert(item.Price*float64(item.Quantity), itemCurrency(item), currency)
+		if !ok {
+			return 0, ""
+		}
+		total += amount
+	}
+	return total, currency
+}
+
+func itemCurrency(item entity.OrderItem) entity.Currency {
+	if item.Currency == "" {
+		return entity.DefaultCurrency
+	}
+	return item.Currency
+}
+
+// productIndex buyurtma mahsulotlarini katalogdan topish: avval ID, keyin nom bo'yicha
+// (buyurtmadan keyin replace import bo'lsa ID lar yangilanadi)
+type productIndex struct {
+	products []entity.Product
+	byID     map[string]int
+	byName   map[string]int
+}
+
+func newProductIndex(products []entity.Product) productIndex {
+	index := productIndex{
+		products: products,
+		byID:     make(map[string]int, len(products)),
+		byName:   make(map[string]int, len(products)),
+	}
+	for i, p := range products {
+		index.byID[p.ID] = i
+		if _, ok := index.byName[productKey(p)]; !ok {
+			index.byName[productKey(p)] = i
+		}
+	}
+	return index
+}
+
+func (x productIndex) find(id, name string) (entity.Product, bool) {
+	if i, ok := x.byID[id]; ok && id != "" {
+		return x.products[i], true
+	}
+	if i, ok := x.byName[productKey(entity.Product{Name: name})]; ok {
+		return x.products[i], true
+	}
+	return entity.Product{}, false
+}
@@ -0,0 +1,223 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/repository"
+)
+
+// MaxPriceAlertsPerUser bitta mijozning narx obunalari chegarasi
+const MaxPriceAlertsPerUser = 20
+
+// PriceAlertUseCase narxlar tarixi va mijozlarning "narx tushsa xabar ber" obunalari
+type PriceAlertUseCase interface {
+	// Subscribe mahsulot narxi hozirgidan tushsa xabar berish uchun obuna (shu mahsulotga obuna bo'lsa o'sha qaytadi)
+	Subscribe(ctx context.Context, userID, chatID int64, productID string) (*entity.PriceAlert, error)
+
+	// Unsubscribe foydalanuvchi obunasini o'chirish
+	Unsubscribe(ctx context.Context, userID int64, alertID string) error
+
+	// ListUserAlerts foydalanuvchi obunalari
+	ListUserAlerts(ctx context.Context, userID int64) ([]entity.PriceAlert, error)
+
+	// CheckAlerts joriy katalogda narxi chegaradan tushgan obunalar (obunalar o'zgartirilmaydi)
+	CheckAlerts(ctx context.Context) ([]entity.PriceDrop, error)
+
+	// MarkNotified xabar yuborilgandan keyin obuna chegarasini yangi narxga tushirish
+	MarkNotified(ctx context.Context, drop entity.PriceDrop) error
+
+	// GetPriceHistory so'rovga mos mahsulotlar narxlari tarixi (admin uchun)
+	GetPriceHistory(ctx context.Context, userID int64, query string, limit int) ([]ProductPriceHistory, error)
+}
+
+// ProductPriceHistory bitta mahsulot narxlari tarixi (yangidan eskiga)
+type ProductPriceHistory struct {
+	Product entity.Product
+	Points  []entity.PricePoint
+}
+
+type priceAlertUseCase struct {
+	alertRepo   repository.PriceAlertRepository
+	priceRepo   repository.PriceHistoryRepository
+	productRepo repository.ProductRepository
+	rateRepo    repository.ExchangeRateRepository
+	adminRepo   repository.AdminRepository
+}
+
+// NewPriceAlertUseCase yangi PriceAlertUseCase yaratish (rateRepo nil bo'lsa valyutasi
+// o'zgargan mahsulotlar narxi obuna chegarasi bilan solishtirilmaydi)
+func NewPriceAlertUseCase(
+	alertRepo repository.PriceAlertRepository,
+	priceRepo repository.PriceHistoryRepository,
+	productRepo repository.ProductRepository,
+	rateRepo repository.ExchangeRateRepository,
+	adminRepo repository.AdminRepository,
+) PriceAlertUseCase {
+	return &priceAlertUseCase{
+		alertRepo:   alertRepo,
+		priceRepo:   priceRepo,
+		productRepo: productRepo,
+		rateRepo:    rateRepo,
+		adminRepo:   adminRepo,
+	}
+}
+
+// Subscribe narx obunasi: chegara - mahsulotning hozirgi narxi
+func (u *priceAlertUseCase) Subscribe(ctx context.Context, userID, chatID int64, productID string) (*entity.PriceAlert, error) {
+	product, err := u.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	if product.Discontinued {
+		return nil, fmt.Errorf("product %s is discontinued", productID)
+	}
+
+	alerts, err := u.alertRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load price alerts: %w", err)
+	}
+	key := entity.PriceKey(*product)
+	for _, alert := range alerts {
+		if alert.Key == key {
+			return &alert, nil
+		}
+	}
+	if len(alerts) >= MaxPriceAlertsPerUser {
+		return nil, fmt.Errorf("too many price alerts (max %d)", MaxPriceAlertsPerUser)
+	}
+
+	alert := entity.PriceAlert{
+		ID:          uuid.New().String(),
+		UserID:      userID,
+		ChatID:      chatID,
+		ProductID:   product.ID,
+		Key:         key,
+		ProductName: product.Name,
+		Threshold:   product.Price,
+		Currency:    product.PriceCurrency(),
+		CreatedAt:   time.Now(),
+	}
+	if err := u.alertRepo.SaveAlert(ctx, alert); err != nil {
+		return nil, fmt.Errorf("failed to save price alert: %w", err)
+	}
+	return &alert, nil
+}
+
+// Unsubscribe obunani o'chirish (faqat o'z obunasi)
+func (u *priceAlertUseCase) Unsubscribe(ctx context.Context, userID int64, alertID string) error {
+	alerts, err := u.alertRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to load price alerts: %w", err)
+	}
+	for _, alert := range alerts {
+		if alert.ID == alertID {
+			return u.alertRepo.DeleteAlert(ctx, alertID)
+		}
+	}
+	return fmt.Errorf("price alert not found: %s", alertID)
+}
+
+// ListUserAlerts foydalanuvchi obunalari
+func (u *priceAlertUseCase) ListUserAlerts(ctx context.Context, userID int64) ([]entity.PriceAlert, error) {
+	return u.alertRepo.ListByUser(ctx, userID)
+}
+
+// CheckAlerts obunalarni joriy katalog bilan solishtirish. Mahsulot avval ID, keyin artikul yoki
+// nom (entity.PriceKey) bo'yicha topiladi. Valyuta o'zgarmagan bo'lsa narxlar o'z valyutasida
+// solishtiriladi (kurs o'zgarishi narx tushishi deb hisoblanmaydi); valyutasi o'zgargan mahsulot
+// narxi kurs bo'yicha obuna valyutasiga o'tkaziladi (kurs bo'lmasa o'tkazib yuboriladi).
+func (u *priceAlertUseCase) CheckAlerts(ctx context.Context) ([]entity.PriceDrop, error) {
+	alerts, err := u.alertRepo.ListAlerts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load price alerts: %w", err)
+	}
+	if len(alerts) == 0 {
+		return nil, nil
+	}
+
+	products, err := activeOnly(u.productRepo.GetAll(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load catalog: %w", err)
+	}
+	byID := make(map[string]int, len(products))
+	byKey := make(map[string]int, len(products))
+	for i, p := range products {
+		byID[p.ID] = i
+		if _, ok := byKey[entity.PriceKey(p)]; !ok {
+			byKey[entity.PriceKey(p)] = i
+		}
+	}
+	rates, _ := loadRates(ctx, u.rateRepo)
+
+	var drops []entity.PriceDrop
+	for _, alert := range alerts {
+		idx, ok := byID[alert.ProductID]
+		if !ok {
+			if idx, ok = byKey[alert.Key]; !ok {
+				continue
+			}
+		}
+		product := products[idx]
+
+		price := product.Price
+		if currency := product.PriceCurrency(); currency != alert.Currency {
+			if price, ok = rates.Convert(product.Price, currency, alert.Currency); !ok {
+				continue
+			}
+		}
+		if price <= 0 || price >= alert.Threshold {
+			continue
+		}
+		drops = append(drops, entity.PriceDrop{Alert: alert, Product: product, NewPrice: price})
+	}
+	return drops, nil
+}
+
+// MarkNotified obuna chegarasini mahsulotning yangi narxiga (o'z valyutasida) tushirish, shunda
+// keyingi tekshiruvlar kursga bog'liq bo'lmaydi va shu narx uchun qayta xabar yuborilmaydi
+func (u *priceAlertUseCase) MarkNotified(ctx context.Context, drop entity.PriceDrop) error {
+	alert := drop.Alert
+	alert.Threshold = drop.Product.Price
+	alert.Currency = drop.Product.PriceCurrency()
+	alert.ProductID = drop.Product.ID
+	alert.ProductName = drop.Product.Name
+	alert.NotifiedAt = time.Now()
+	if err := u.alertRepo.SaveAlert(ctx, alert); err != nil {
+		return fmt.Errorf("failed to update price alert: %w", err)
+	}
+	return nil
+}
+
+// GetPriceHistory so'rov bo'yicha topilgan mahsulotlar (birinchi 5 tasi) narxlari tarixi
+func (u *priceAlertUseCase) GetPriceHistory(ctx context.Context, userID int64, query string, limit int) ([]ProductPriceHistory, error) {
+	isAdmin, err := u.adminRepo.IsAdmin(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isAdmin {
+		return nil, fmt.Errorf("user is not admin")
+	}
+
+	products, err := u.productRepo.Search(ctx, strings.TrimSpace(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search products: %w", err)
+	}
+	if len(products) > 5 {
+		products = products[:5]
+	}
+
+	history := make([]ProductPriceHistory, 0, len(products))
+	for _, p := range products {
+		points, err := u.priceRepo.ListPrices(ctx, entity.PriceKey(p), limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load price history: %w", err)
+		}
+		history = append(history, ProductPriceHistory{Product: p, Points: points})
+	}
+	return history, nil
+}
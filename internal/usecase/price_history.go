@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+)
+
+// priceChanges katalog o'zgarishidagi narx tarixi yozuvlari: yangi mahsulotlar va narxi yoki
+// valyutasi o'zgarganlar. Mahsulotlar entity.PriceKey bo'yicha solishtiriladi (replace importda ID yangilanadi).
+func priceChanges(before, after []entity.Product, version int, now time.Time) []entity.PricePoint {
+	previous := make(map[string]entity.Product, len(before))
+	for _, p := range before {
+		if _, ok := previous[entity.PriceKey(p)]; !ok {
+			previous[entity.PriceKey(p)] = p
+		}
+	}
+
+	var points []entity.PricePoint
+	seen := make(map[string]bool, len(after))
+	for _, p := range after {
+		key := entity.PriceKey(p)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		if old, ok := previous[key]; ok && old.Price == p.Price && old.PriceCurrency() == p.PriceCurrency() {
+			continue
+		}
+		points = append(points, entity.PricePoint{
+			Key:        key,
+			ProductID:  p.ID,
+			Name:       p.Name,
+			Price:      p.Price,
+			Currency:   p.PriceCurrency(),
+			Version:    version,
+			RecordedAt: now,
+		})
+	}
+	return points
+}
@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/repository"
+)
+
+// PrivacyUseCase foydalanuvchi ma'lumotlarini eksport qilish va o'chirish
+type PrivacyUseCase interface {
+	// ExportUserData foydalanuvchi haqida saqlangan barcha ma'lumotlar (JSON)
+	ExportUserData(ctx context.Context, userID int64) ([]byte, error)
+
+	// ForgetUser foydalanuvchi ma'lumotlarini barcha repositorylardan o'chirish
+	ForgetUser(ctx context.Context, userID int64) (*ForgetReport, error)
+}
+
+// ForgetReport o'chirilgan yozuvlar soni
+type ForgetReport struct {
+	Messages     int
+	Orders       int
+	State        int
+	Alerts       int
+	Reservations int // ochiq buyurtmalarning bo'shatilgan bronlari
+}
+
+// userDataExport /mydata faylining tuzilishi
+type userDataExport struct {
+	UserID       int64                     `json:"user_id"`
+	ExportedAt   time.Time                 `json:"exported_at"`
+	Messages     []exportedMessage         `json:"messages"`
+	Orders       []entity.Order            `json:"orders"`
+	Reservations []entity.StockReservation `json:"stock_reservations"`
+	Alerts       []entity.PriceAlert       `json:"price_alerts"`
+	State        []exportedState           `json:"state"`
+}
+
+type exportedMessage struct {
+	Time     time.Time `json:"time"`
+	Text     string    `json:"text"`
+	Response string    `json:"response"`
+}
+
+// exportedState sessiyalar, feedback va guruh threadlari (namespace bo'yicha)
+type exportedState struct {
+	Kind      string          `json:"kind"`
+	UpdatedAt time.Time       `json:"updated_at"`
+	Value     json.RawMessage `json:"value"`
+}
+
+type privacyUseCase struct {
+	chatRepo  repository.ChatRepository
+	orderRepo repository.OrderRepository
+	alertRepo repository.PriceAlertRepository
+	stockRepo repository.StockReservationRepository
+	stateRepo repository.StateRepository
+	adminRepo repository.AdminRepository
+}
+
+// NewPrivacyUseCase yangi PrivacyUseCase yaratish
+func NewPrivacyUseCase(
+	chatRepo repository.ChatRepository,
+	orderRepo repository.OrderRepository,
+	alertRepo repository.PriceAlertRepository,
+	stockRepo repository.StockReservationRepository,
+	stateRepo repository.StateRepository,
+	adminRepo repository.AdminRepository,
+) PrivacyUseCase {
+	return &privacyUseCase{
+		chatRepo:  chatRepo,
+		orderRepo: orderRepo,
+		alertRepo: alertRepo,
+		stockRepo: stockRepo,
+		stateRepo: stateRepo,
+		adminRepo: adminRepo,
+	}
+}
+
+// ExportUserData foydalanuvchi ma'lumotlarini yig'ish
+func (u *privacyUseCase) ExportUserData(ctx context.Context, userID int64) ([]byte, error) {
+	messages, err := u.chatRepo.GetHistory(ctx, userID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get messages: %w", err)
+	}
+	orders, err := u.orderRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get orders: %w", err)
+	}
+	reservations := []entity.StockReservation{}
+	for _, order := range orders {
+		list, err := u.stockRepo.ListByOrder(ctx, order.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get stock reservations: %w", err)
+		}
+		reservations = append(reservations, list...)
+	}
+	alerts, err := u.alertRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get price alerts: %w", err)
+	}
+	states, err := u.stateRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get state: %w", err)
+	}
+
+	export := userDataExport{
+		UserID:       userID,
+		ExportedAt:   time.Now(),
+		Messages:     make([]exportedMessage, 0, len(messages)),
+		Orders:       orders,
+		Reservations: reservations,
+		Alerts:       alerts,
+		State:        make([]exportedState, 0, len(states)),
+	}
+	if export.Orders == nil {
+		export.Orders = []entity.Order{}
+	}
+	if export.Alerts == nil {
+		export.Alerts = []entity.PriceAlert{}
+	}
+	for _, m := range messages {
+		export.Messages = append(export.Messages, exportedMessage{Time: m.Timestamp, Text: m.Text, Response: m.Response})
+	}
+	for _, s := range states {
+		value := json.RawMessage(s.Value)
+		if !json.Valid(value) {
+			value, _ = json.Marshal(string(s.Value))
+		}
+		export.State = append(export.State, exportedState{Kind: s.Namespace, UpdatedAt: s.UpdatedAt, Value: value})
+	}
+
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode export: %w", err)
+	}
+
+	u.logPrivacyAction(ctx, userID, "user_data_export",
+		fmt.Sprintf("messages=%d orders=%d reservations=%d alerts=%d state=%d", len(messages), len(orders), len(reservations), len(alerts), len(states)))
+
+	return data, nil
+}
+
+// ForgetUser foydalanuvchi ma'lumotlarini o'chirish.
+// Admin audit log o'chirilmaydi: unda faqat harakat va yozuvlar soni qoladi.
+func (u *privacyUseCase) ForgetUser(ctx context.Context, userID int64) (*ForgetReport, error) {
+	report := &ForgetReport{}
+
+	messages, err := u.chatRepo.GetHistory(ctx, userID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get messages: %w", err)
+	}
+	if err := u.chatRepo.ClearHistory(ctx, userID); err != nil {
+		return nil, fmt.Errorf("failed to delete messages: %w", err)
+	}
+	report.Messages = len(messages)
+
+	// Ochiq buyurtmalar bronlari buyurtmalardan oldin bo'shatiladi, aks holda ombor soni band bo'lib qoladi
+	orders, err := u.orderRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return report, fmt.Errorf("failed to get orders: %w", err)
+	}
+	for _, order := range orders {
+		if order.Status.IsFinal() {
+			continue
+		}
+		reservations, err := u.stockRepo.ListByOrder(ctx, order.ID)
+		if err != nil {
+			return report, fmt.Errorf("failed to get stock reservations: %w", err)
+		}
+		if err := u.stockRepo.DeleteByOrder(ctx, order.ID); err != nil {
+			return report, fmt.Errorf("failed to release stock reservations: %w", err)
+		}
+		report.Reservations += len(reservations)
+	}
+
+	if report.Orders, err = u.orderRepo.DeleteByUser(ctx, userID); err != nil {
+		return report, fmt.Errorf("failed to delete orders: %w", err)
+	}
+	if report.Alerts, err = u.alertRepo.DeleteByUser(ctx, userID); err != nil {
+		return report, fmt.Errorf("failed to delete price alerts: %w", err)
+	}
+	if report.State, err = u.stateRepo.DeleteByUser(ctx, userID); err != nil {
+		return report, fmt.Errorf("failed to delete state: %w", err)
+	}
+
+	u.logPrivacyAction(ctx, userID, "user_forget",
+		fmt.Sprintf("messages=%d orders=%d reservations=%d alerts=%d state=%d", report.Messages, report.Orders, report.Reservations, report.Alerts, report.State))
+
+	return report, nil
+}
+
+func (u *privacyUseCase) logPrivacyAction(ctx context.Context, userID int64, action, details string) {
+	_ = u.adminRepo.LogAction(ctx, entity.AdminAction{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Action:    action,
+		Details:   details,
+		Timestamp: time.Now(),
+	})
+}
@@ -25,37 +25,75 @@ type ProductUseCase interface {
 
 	// HasProducts mahsulotlar borligini tekshirish
 	HasProducts(ctx context.Context) (bool, error)
+
+	// GetImage katalog fayliga joylangan mahsulot rasmi (Product.Image ID si bo'yicha)
+	GetImage(ctx context.Context, id string) (*entity.ProductImage, error)
+
+	// SetImageFileID rasm Telegram ga yuborilgandan keyingi file_id ni saqlash
+	SetImageFileID(ctx context.Context, id, fileID string) error
 }
 
 type productUseCase struct {
 	productRepo repository.ProductRepository
+	rateRepo    repository.ExchangeRateRepository
+	imageRepo   repository.ProductImageRepository
+	stockRepo   repository.StockReservationRepository
 }
 
-// NewProductUseCase yangi ProductUseCase yaratish
-func NewProductUseCase(productRepo repository.ProductRepository) ProductUseCase {
+// NewProductUseCase yangi ProductUseCase yaratish (rateRepo nil bo'lsa narxlar faqat asl valyutada,
+// stockRepo nil bo'lsa bronlar hisobga olinmaydi)
+func NewProductUseCase(
+	productRepo repository.ProductRepository,
+	rateRepo repository.ExchangeRateRepository,
+	imageRepo repository.ProductImageRepository,
+	stockRepo repository.StockReservationRepository,
+) ProductUseCase {
 	return &productUseCase{
 		productRepo: productRepo,
+		rateRepo:    rateRepo,
+		imageRepo:   imageRepo,
+		stockRepo:   stockRepo,
 	}
 }
 
-// Search mahsulot qidirish
+// Search mahsulot qidirish (sotuvdan olingan va omborda qolmaganlarsiz)
 func (u *productUseCase) Search(ctx context.Context, query string) ([]entity.Product, error) {
-	return u.productRepo.Search(ctx, query)
+	products, err := activeOnly(u.productRepo.Search(ctx, query))
+	if err != nil {
+		return nil, err
+	}
+	return inStockOnly(ctx, u.stockRepo, products), nil
 }
 
-// GetByCategory kategoriya bo'yicha mahsulotlarni olish
+// GetByCategory kategoriya bo'yicha mahsulotlarni olish (sotuvdan olingan va omborda qolmaganlarsiz)
 func (u *productUseCase) GetByCategory(ctx context.Context, category string) ([]entity.Product, error) {
-	return u.productRepo.GetByCategory(ctx, category)
+	products, err := activeOnly(u.productRepo.GetByCategory(ctx, category))
+	if err != nil {
+		return nil, err
+	}
+	return inStockOnly(ctx, u.stockRepo, products), nil
 }
 
-// GetAll barcha mahsulotlarni olish
+// GetAll mijozga taklif qilinadigan barcha mahsulotlarni olish
 func (u *productUseCase) GetAll(ctx context.Context) ([]entity.Product, error) {
-	return u.productRepo.GetAll(ctx)
+	products, err := activeOnly(u.productRepo.GetAll(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return inStockOnly(ctx, u.stockRepo, products), nil
+}
+
+// activeOnly repository natijasidan sotuvdan olingan mahsulotlarni chiqarib tashlash
+func activeOnly(products []entity.Product, err error) ([]entity.Product, error) {
+	if err != nil {
+		return nil, err
+	}
+	return entity.ActiveProducts(products), nil
 }
 
 // GetProductsAsText mahsulotlarni text formatda olish (AI uchun)
 func (u *productUseCase) GetProductsAsText(ctx context.Context) (string, error) {
-	products, err := u.productRepo.GetAll(ctx)
+	products, err := u.GetAll(ctx)
 	if err != nil {
 		return "", err
 	}
@@ -64,6 +102,9 @@ func (u *productUseCase) GetProductsAsText(ctx context.Context) (string, error)
 		return "", fmt.Errorf("no products available")
 	}
 
+	// Kurslar bo'lmasa narxlar asl valyutada qoladi
+	rates, _ := loadRates(ctx, u.rateRepo)
+
 	var sb strings.Builder
 	sb.WriteString("=== MAVJUD MAHSULOTLAR ===\n\n")
 
@@ -81,7 +122,7 @@ func (u *productUseCase) GetProductsAsText(ctx context.Context) (string, error)
 	for category, prods := range categoryMap {
 		sb.WriteString(fmt.Sprintf("📂 %s:\n", category))
 		for i, p := range prods {
-			sb.WriteString(fmt.Sprintf("%d. %s - $%.2f", i+1, p.Name, p.Price))
+			sb.WriteString(fmt.Sprintf("%d. %s - %s", i+1, p.Name, rates.FormatDual(p.Price, p.PriceCurrency())))
 			if p.Stock > 0 {
 				sb.WriteString(fmt.Sprintf(" (Omborda: %d)", p.Stock))
 			}
@@ -104,9 +145,19 @@ func (u *productUseCase) GetProductsAsText(ctx context.Context) (string, error)
 
 // HasProducts mahsulotlar borligini tekshirish
 func (u *productUseCase) HasProducts(ctx context.Context) (bool, error) {
-	products, err := u.productRepo.GetAll(ctx)
+	products, err := u.GetAll(ctx)
 	if err != nil {
 		return false, err
 	}
 	return len(products) > 0, nil
 }
+
+// GetImage katalog fayliga joylangan mahsulot rasmi
+func (u *productUseCase) GetImage(ctx context.Context, id string) (*entity.ProductImage, error) {
+	return u.imageRepo.GetImage(ctx, id)
+}
+
+// SetImageFileID rasmning Telegram file_id sini saqlash (keyingi safar fayl qayta yuklanmaydi)
+func (u *productUseCase) SetImageFileID(ctx context.Context, id, fileID string) error {
+	return u.imageRepo.SetFileID(ctx, id, fileID)
+}
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/repository"
+)
+
+// StockShortageError buyurtmani tasdiqlash uchun omborda yetarli mahsulot yo'q
+type StockShortageError struct {
+	Shortages []entity.StockShortage
+}
+
+func (e *StockShortageError) Error() string {
+	parts := make([]string, 0, len(e.Shortages))
+	for _, s := range e.Shortages {
+		parts = append(parts, fmt.Sprintf("%s (need %d, available %d)", s.Name, s.Required, max(s.Stock-s.Reserved, 0)))
+	}
+	return "not enough stock: " + strings.Join(parts, ", ")
+}
+
+// loadReserved ochiq bronlar mahsulot kaliti bo'yicha (stockRepo nil bo'lsa bo'sh)
+func loadReserved(ctx context.Context, stockRepo repository.StockReservationRepository) (map[string]int, error) {
+	if stockRepo == nil {
+		return map[string]int{}, nil
+	}
+	reservations, err := stockRepo.ListReservations(ctx)
+	if err != nil {
+		return map[string]int{}, fmt.Errorf("failed to load stock reservations: %w", err)
+	}
+	return entity.ReservedStock(reservations), nil
+}
+
+// inStockOnly mijozga taklif qilinadigan mahsulotlar (bronlardan keyin soni qolganlar). Bronlarni
+// o'qib bo'lmasa ombordagi son bo'yicha tekshiriladi.
+func inStockOnly(ctx context.Context, stockRepo repository.StockReservationRepository, products []entity.Product) []entity.Product {
+	reserved, _ := loadReserved(ctx, stockRepo)
+	return entity.InStockProducts(products, reserved)
+}
+
+// importShortages yangi katalogda ombordagi soni ochiq bronlardan kam mahsulotlar
+// (ombor ustuni bo'lmagan mahsulotlar tekshirilmaydi)
+func importShortages(products []entity.Product, reserved map[string]int) []entity.StockShortage {
+	var shortages []entity.StockShortage
+	seen := make(map[string]bool)
+	for _, p := range products {
+		key := entity.PriceKey(p)
+		if !p.StockTracked || seen[key] {
+			continue
+		}
+		seen[key] = true
+		if p.Stock < reserved[key] {
+			shortages = append(shortages, entity.StockShortage{Name: p.Name, Stock: p.Stock, Reserved: reserved[key]})
+		}
+	}
+	return shortages
+}
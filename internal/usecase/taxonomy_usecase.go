@@ -0,0 +1,421 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/entity"
+	"github.com/yourusername/telegram-ai-bot/internal/domain/repository"
+)
+
+// TaxonomyUseCase kategoriya qoidalari bilan bog'liq business logic
+type TaxonomyUseCase interface {
+	// Taxonomy joriy qoidalar va nomlar (saqlanmagan bo'lsa standart qoidalar)
+	Taxonomy(ctx context.Context) (*entity.Taxonomy, error)
+
+	// AddRule yangi qoida qo'shish va uning ID sini qaytarish
+	AddRule(ctx context.Context, userID int64, rule entity.CategoryRule) (int, error)
+
+	// DeleteRule qoidani o'chirish
+	DeleteRule(ctx context.Context, userID int64, id int) error
+
+	// SetCategoryName kategoriyaning o'zbekcha va ruscha nomini o'rnatish
+	SetCategoryName(ctx context.Context, userID int64, name entity.CategoryName) error
+
+	// ImportTaxonomy JSON fayldan qoidalar (va nomlar) ni yuklash, qoidalar butunlay almashtiriladi
+	ImportTaxonomy(ctx context.Context, userID int64, data []byte) (*entity.Taxonomy, error)
+
+	// ExportTaxonomy joriy qoidalar va nomlarni JSON ko'rinishida olish (ImportTaxonomy bilan qayta yuklanadi)
+	ExportTaxonomy(ctx context.Context) ([]byte, error)
+
+	// ResetTaxonomy standart qoidalarga qaytish
+	ResetTaxonomy(ctx context.Context, userID int64) error
+
+	// Recategorize joriy katalogni qoidalar bo'yicha qayta kategoriyalash. all=false bo'lsa faqat
+	// kategoriyasi qoidalar bilan aniqlangan mahsulotlar, all=true bo'lsa barchasi. O'zgarganlar qaytariladi.
+	Recategorize(ctx context.Context, userID int64, all bool) ([]entity.ProductChange, error)
+}
+
+type taxonomyUseCase struct {
+	taxonomyRepo repository.TaxonomyRepository
+	productRepo  repository.ProductRepository
+	versionRepo  repository.CatalogVersionRepository
+	adminRepo    repository.AdminRepository
+}
+
+// NewTaxonomyUseCase yangi TaxonomyUseCase yaratish
+func NewTaxonomyUseCase(
+	taxonomyRepo repository.TaxonomyRepository,
+	productRepo repository.ProductRepository,
+	versionRepo repository.CatalogVersionRepository,
+	adminRepo repository.AdminRepository,
+) TaxonomyUseCase {
+	return &taxonomyUseCase{
+		taxonomyRepo: taxonomyRepo,
+		productRepo:  productRepo,
+		versionRepo:  versionRepo,
+		adminRepo:    adminRepo,
+	}
+}
+
+// Taxonomy joriy taksonomiya
+func (u *taxonomyUseCase) Taxonomy(ctx context.Context) (*entity.Taxonomy, error) {
+	return loadTaxonomy(ctx, u.taxonomyRepo)
+}
+
+// AddRule yangi qoida qo'shish
+func (u *taxonomyUseCase) AddRule(ctx context.Context, userID int64, rule entity.CategoryRule) (int, error) {
+	if err := u.requireAdmin(ctx, userID); err != nil {
+		return 0, err
+	}
+	rule.ID = 0
+	if err := entity.ValidateRule(rule); err != nil {
+		return 0, err
+	}
+	if err := u.materializeDefaults(ctx); err != nil {
+		return 0, err
+	}
+
+	id, err := u.taxonomyRepo.SaveRule(ctx, rule)
+	if err != nil {
+		return 0, fmt.Errorf("failed to save category rule: %w", err)
+	}
+
+	rule.ID = id
+	u.logAction(ctx, userID, "add_category_rule", ruleSummary(rule))
+	return id, nil
+}
+
+// DeleteRule qoidani o'chirish (oxirgi qoidani o'chirib bo'lmaydi - bo'sh ro'yxat standart qoidalarni qaytaradi)
+func (u *taxonomyUseCase) DeleteRule(ctx context.Context, userID int64, id int) error {
+	if err := u.requireAdmin(ctx, userID); err != nil {
+		return err
+	}
+	if err := u.materializeDefaults(ctx); err != nil {
+		return err
+	}
+
+	rules, err := u.taxonomyRepo.ListRules(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load category rules: %w", err)
+	}
+	var deleted *entity.CategoryRule
+	for i := range rules {
+		if rules[i].ID == id {
+			deleted = &rules[i]
+		}
+	}
+	if deleted == nil {
+		return fmt.Errorf("category rule not found: %d", id)
+	}
+	if len(rules) == 1 {
+		return fmt.Errorf("cannot delete the last category rule")
+	}
+
+	if err := u.taxonomyRepo.DeleteRule(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete category rule: %w", err)
+	}
+
+	u.logAction(ctx, userID, "delete_category_rule", ruleSummary(*deleted))
+	return nil
+}
+
+// SetCategoryName kategoriya nomini o'rnatish
+func (u *taxonomyUseCase) SetCategoryName(ctx context.Context, userID int64, name entity.CategoryName) error {
+	if err := u.requireAdmin(ctx, userID); err != nil {
+		return err
+	}
+	name.Category = strings.TrimSpace(name.Category)
+	if name.Category == "" {
+		return fmt.Errorf("category is empty")
+	}
+
+	if err := u.taxonomyRepo.SaveCategoryName(ctx, name); err != nil {
+		return fmt.Errorf("failed to save category name: %w", err)
+	}
+
+	u.logAction(ctx, userID, "set_category_name", fmt.Sprintf("%s: uz=%q ru=%q", name.Category, name.Uz, name.Ru))
+	return nil
+}
+
+// ImportTaxonomy JSON fayldan qoidalarni yuklash
+func (u *taxonomyUseCase) ImportTaxonomy(ctx context.Context, userID int64, data []byte) (*entity.Taxonomy, error) {
+	if err := u.requireAdmin(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	rules, names, err := parseTaxonomyFile(data)
+	if err != nil {
+		return nil, err
+	}
+	// Qoidalarni saqlashdan oldin hammasi to'g'riligini tekshiramiz
+	taxonomy, err := entity.NewTaxonomy(rules, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.taxonomyRepo.ReplaceRules(ctx, rules); err != nil {
+		return nil, fmt.Errorf("failed to save category rules: %w", err)
+	}
+	for _, name := range names {
+		if err := u.taxonomyRepo.SaveCategoryName(ctx, name); err != nil {
+			return nil, fmt.Errorf("failed to save category name: %w", err)
+		}
+	}
+
+	u.logAction(ctx, userID, "import_taxonomy", fmt.Sprintf("Imported %d category rules and %d names", len(taxonomy.Rules), len(names)))
+	return loadTaxonomy(ctx, u.taxonomyRepo)
+}
+
+// ExportTaxonomy joriy qoidalar va nomlarni JSON ga yozish
+func (u *taxonomyUseCase) ExportTaxonomy(ctx context.Context) ([]byte, error) {
+	taxonomy, err := loadTaxonomy(ctx, u.taxonomyRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	file := taxonomyFile{}
+	for _, rule := range taxonomy.Rules {
+		file.Rules = append(file.Rules, taxonomyFileRule{
+			ID:       rule.ID,
+			Category: rule.Category,
+			Priority: rule.Priority,
+			Patterns: rule.Patterns,
+			Exclude:  rule.Exclude,
+		})
+	}
+	for _, name := range taxonomy.Names {
+		file.Names = append(file.Names, taxonomyFileName{Category: name.Category, Uz: name.Uz, Ru: name.Ru})
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(file); err != nil {
+		return nil, fmt.Errorf("failed to encode taxonomy: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// ResetTaxonomy saqlangan qoidalarni o'chirish (standart qoidalar ishlaydi)
+func (u *taxonomyUseCase) ResetTaxonomy(ctx context.Context, userID int64) error {
+	if err := u.requireAdmin(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := u.taxonomyRepo.ReplaceRules(ctx, nil); err != nil {
+		return fmt.Errorf("failed to reset category rules: %w", err)
+	}
+
+	u.logAction(ctx, userID, "reset_taxonomy", "Category rules reset to defaults")
+	return nil
+}
+
+// Recategorize katalogni qayta kategoriyalash. O'zgarish bo'lsa katalog yangi versiya sifatida
+// saqlanadi (rollback bilan qaytarish mumkin).
+func (u *taxonomyUseCase) Recategorize(ctx context.Context, userID int64, all bool) ([]entity.ProductChange, error) {
+	if err := u.requireAdmin(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	taxonomy, err := loadTaxonomy(ctx, u.taxonomyRepo)
+	if err != nil {
+		return nil, err
+	}
+	catalog, err := u.productRepo.GetCatalog(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load current catalog: %w", err)
+	}
+
+	now := time.Now()
+	var changes []entity.ProductChange
+	for i := range catalog.Products {
+		p := &catalog.Products[i]
+		if !all && !p.CategoryAuto {
+			continue
+		}
+		category := taxonomy.Categorize(p.Name)
+		if category == p.Category {
+			p.CategoryAuto = true
+			continue
+		}
+
+		before := *p
+		p.Category = category
+		p.CategoryAuto = true
+		p.Attributes = entity.ExtractAttributes(p.Name, p.Category, p.Specs)
+		p.UpdatedAt = now
+		changes = append(changes, entity.ProductChange{Before: before, After: *p})
+	}
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	catalog.UpdatedAt = now
+	if err := u.productRepo.UpdateCatalog(ctx, *catalog); err != nil {
+		return nil, fmt.Errorf("failed to update catalog: %w", err)
+	}
+	version, err := u.versionRepo.SaveVersion(ctx, entity.CatalogVersion{
+		Source:     catalog.Source,
+		UploadedBy: userID,
+		CreatedAt:  now,
+		Products:   catalog.Products,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save catalog version: %w", err)
+	}
+
+	scope := "auto-categorized"
+	if all {
+		scope = "all"
+	}
+	u.logAction(ctx, userID, "recategorize", fmt.Sprintf("Recategorized %d products (%s), version %d", len(changes), scope, version))
+	return changes, nil
+}
+
+// materializeDefaults birinchi tahrirdan oldin standart qoidalarni bazaga yozish
+// (aks holda yangi qoida standartlarning o'rnini egallab qoladi)
+func (u *taxonomyUseCase) materializeDefaults(ctx context.Context) error {
+	rules, err := u.taxonomyRepo.ListRules(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load category rules: %w", err)
+	}
+	if len(rules) > 0 {
+		return nil
+	}
+	if err := u.taxonomyRepo.ReplaceRules(ctx, entity.DefaultCategoryRules()); err != nil {
+		return fmt.Errorf("failed to save default category rules: %w", err)
+	}
+	return nil
+}
+
+func (u *taxonomyUseCase) requireAdmin(ctx context.Context, userID int64) error {
+	isAdmin, err := u.adminRepo.IsAdmin(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return fmt.Errorf("user is not admin")
+	}
+	return nil
+}
+
+func (u *taxonomyUseCase) logAction(ctx context.Context, userID int64, name, details string) {
+	action := entity.AdminAction{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Action:    name,
+		Details:   details,
+		Timestamp: time.Now(),
+	}
+	_ = u.adminRepo.LogAction(ctx, action)
+}
+
+// loadTaxonomy repositorydagi qoidalardan taksonomiya (taxonomyRepo nil bo'lsa standart qoidalar)
+func loadTaxonomy(ctx context.Context, taxonomyRepo repository.TaxonomyRepository) (*entity.Taxonomy, error) {
+	if taxonomyRepo == nil {
+		return entity.EffectiveTaxonomy(nil, nil)
+	}
+	rules, err := taxonomyRepo.ListRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load category rules: %w", err)
+	}
+	names, err := taxonomyRepo.ListCategoryNames(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load category names: %w", err)
+	}
+	return entity.EffectiveTaxonomy(rules, names)
+}
+
+// taxonomyFile /taxonomy export fayli
+//
+//	{
+//	  "rules": [{"category": "GPU", "priority": 65, "patterns": ["rtx", "re:\\brx\\s?\\d{4}"], "exclude": []}],
+//	  "names": [{"category": "GPU", "uz": "Videokarta", "ru": "Видеокарта"}]
+//	}
+type taxonomyFile struct {
+	Rules []taxonomyFileRule `json:"rules"`
+	Names []taxonomyFileName `json:"names,omitempty"`
+}
+
+type taxonomyFileRule struct {
+	ID       int      `json:"id,omitempty"`
+	Category string   `json:"category"`
+	Priority int      `json:"priority"`
+	Patterns []string `json:"patterns"`
+	Exclude  []string `json:"exclude,omitempty"`
+}
+
+type taxonomyFileName struct {
+	Category string `json:"category"`
+	Uz       string `json:"uz"`
+	Ru       string `json:"ru"`
+}
+
+// parseTaxonomyFile taksonomiya JSON faylini o'qish (ID berilmagan qoidalar tartib bo'yicha raqamlanadi)
+func parseTaxonomyFile(data []byte) ([]entity.CategoryRule, []entity.CategoryName, error) {
+	data = bytes.TrimSpace(bytes.TrimPrefix(data, []byte{0xEF, 0xBB, 0xBF}))
+	if len(data) == 0 {
+		return nil, nil, fmt.Errorf("taxonomy file is empty")
+	}
+
+	var file taxonomyFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, nil, fmt.Errorf("invalid taxonomy json: %w", err)
+	}
+	if len(file.Rules) == 0 {
+		return nil, nil, fmt.Errorf("no category rules found in file")
+	}
+
+	used := make(map[int]bool, len(file.Rules))
+	for _, r := range file.Rules {
+		if r.ID > 0 {
+			if used[r.ID] {
+				return nil, nil, fmt.Errorf("duplicate category rule id: %d", r.ID)
+			}
+			used[r.ID] = true
+		}
+	}
+
+	rules := make([]entity.CategoryRule, 0, len(file.Rules))
+	nextID := 1
+	for _, r := range file.Rules {
+		id := r.ID
+		if id <= 0 {
+			for used[nextID] {
+				nextID++
+			}
+			id = nextID
+			used[id] = true
+		}
+		rules = append(rules, entity.CategoryRule{
+			ID:       id,
+			Category: strings.TrimSpace(r.Category),
+			Patterns: r.Patterns,
+			Exclude:  r.Exclude,
+			Priority: r.Priority,
+		})
+	}
+
+	var names []entity.CategoryName
+	for _, n := range file.Names {
+		if category := strings.TrimSpace(n.Category); category != "" {
+			names = append(names, entity.CategoryName{Category: category, Uz: strings.TrimSpace(n.Uz), Ru: strings.TrimSpace(n.Ru)})
+		}
+	}
+	return rules, names, nil
+}
+
+func ruleSummary(rule entity.CategoryRule) string {
+	summary := fmt.Sprintf("#%d %s (priority %d): %s", rule.ID, rule.Category, rule.Priority, strings.Join(rule.Patterns, ", "))
+	if len(rule.Exclude) > 0 {
+		summary += " | exclude: " + strings.Join(rule.Exclude, ", ")
+	}
+	return summary
+}